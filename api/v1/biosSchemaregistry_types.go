@@ -29,12 +29,28 @@ type BiosSchemaRegistrySpec struct {
 	RegistryVersion  string              `json:"RegistryVersion,omitempty"`
 	Attributes       []map[string]string `json:"Attributes,omitempty"`
 	SupportedSystems []SupportedSystems  `json:"SupportedSystems,omitempty"`
+	// Dependencies holds the Dependencies section of the attribute registry
+	Dependencies []BiosAttributeDependency `json:"Dependencies,omitempty"`
 }
 
 // BiosSchemaRegistryStatus defines the observed state of BiosSchemaRegistry
 type BiosSchemaRegistryStatus struct {
-	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
-	// Important: Run "make" to regenerate code after modifying this file
+	// UsedBy holds the names of the Bmc objects whose BIOS attribute registry is this registry, followed by
+	// the BiosSnapshot objects captured with this registry and the BiosSetting objects importing them
+	UsedBy []string `json:"usedBy,omitempty"`
+	// UnusedSince is the time since no Bmc or BiosSnapshot object uses the registry, the registry is deleted after a grace period
+	UnusedSince *metav1.Time `json:"unusedSince,omitempty"`
+}
+
+// BiosRegistryChange is the difference between the BIOS attribute registries of a BMC before and after a firmware update
+type BiosRegistryChange struct {
+	PreviousRegistry  string   `json:"previousRegistry"`
+	CurrentRegistry   string   `json:"currentRegistry"`
+	AddedAttributes   []string `json:"addedAttributes,omitempty"`
+	RemovedAttributes []string `json:"removedAttributes,omitempty"`
+	// ChangedAttributes holds the changed properties of the attributes present in both registries, like their bounds
+	ChangedAttributes map[string]string `json:"changedAttributes,omitempty"`
+	ChangeTime        metav1.Time       `json:"changeTime"`
 }
 
 // SupportedSystems defines all the supported system for schema
@@ -44,10 +60,36 @@ type SupportedSystems struct {
 	FirmwareVersion string `json:"FirmwareVersion,omitempty"`
 }
 
+// BiosAttributeDependency is an entry of the Dependencies section of the attribute registry
+type BiosAttributeDependency struct {
+	DependencyFor string            `json:"DependencyFor,omitempty"`
+	Type          string            `json:"Type,omitempty"`
+	Dependency    BiosDependencyMap `json:"Dependency,omitempty"`
+}
+
+// BiosDependencyMap sets a property of an attribute when the MapFrom conditions are met
+type BiosDependencyMap struct {
+	MapFrom        []BiosDependencyCondition `json:"MapFrom,omitempty"`
+	MapToAttribute string                    `json:"MapToAttribute,omitempty"`
+	MapToProperty  string                    `json:"MapToProperty,omitempty"`
+	MapToValue     string                    `json:"MapToValue,omitempty"`
+}
+
+// BiosDependencyCondition is a condition on a property of an attribute, MapTerms combines it with the previous condition
+type BiosDependencyCondition struct {
+	MapFromAttribute string `json:"MapFromAttribute,omitempty"`
+	MapFromCondition string `json:"MapFromCondition,omitempty"`
+	MapFromProperty  string `json:"MapFromProperty,omitempty"`
+	MapFromValue     string `json:"MapFromValue,omitempty"`
+	MapTerms         string `json:"MapTerms,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 
 // BiosSchemaRegistry is the Schema for the biosschemaregistries API
+// +kubebuilder:printcolumn:name="UsedBy",type="string",JSONPath=".status.usedBy"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 type BiosSchemaRegistry struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
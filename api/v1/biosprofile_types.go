@@ -0,0 +1,78 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// BiosProfileSpec defines the desired state of BiosProfile
+type BiosProfileSpec struct {
+	// Description of the profile, for example "virtualization host"
+	Description string `json:"description,omitempty"`
+	// Bios holds the BIOS attributes applied to every selected BMC
+	Bios map[string]string `json:"biosAttributes"`
+	// BmcSelector selects the Bmc objects the profile is applied to, no Bmc object is selected when not set
+	// and all Bmc objects are selected by an empty selector
+	BmcSelector *metav1.LabelSelector `json:"bmcSelector,omitempty"`
+}
+
+// BiosProfileCompliance holds the compliance of a BMC with the profile
+type BiosProfileCompliance struct {
+	// Compliance is one of Compliant, Pending, NonCompliant, Invalid and Conflict
+	Compliance string `json:"compliance,omitempty"`
+	// NonCompliantAttributes are the attributes of the profile whose value differs on the BMC
+	NonCompliantAttributes []string     `json:"nonCompliantAttributes,omitempty"`
+	Message                string       `json:"message,omitempty"`
+	LastAppliedTime        *metav1.Time `json:"lastAppliedTime,omitempty"`
+}
+
+// BiosProfileStatus defines the observed state of BiosProfile
+type BiosProfileStatus struct {
+	SelectedBmcs  int                              `json:"selectedBmcs,omitempty"`
+	CompliantBmcs int                              `json:"compliantBmcs,omitempty"`
+	Bmcs          map[string]BiosProfileCompliance `json:"bmcs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// BiosProfile is the Schema for the biosprofiles API
+// +kubebuilder:printcolumn:name="Selected",type="integer",JSONPath=".status.selectedBmcs"
+// +kubebuilder:printcolumn:name="Compliant",type="integer",JSONPath=".status.compliantBmcs"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type BiosProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BiosProfileSpec   `json:"spec,omitempty"`
+	Status BiosProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BiosProfileList contains a list of BiosProfile
+type BiosProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BiosProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BiosProfile{}, &BiosProfileList{})
+}
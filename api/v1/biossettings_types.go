@@ -27,11 +27,62 @@ type BiosSettingSpec struct {
 	SystemID string            `json:"systemID,omitempty"`
 	SerialNo string            `json:"serialNumber,omitempty"`
 	Bios     map[string]string `json:"biosAttributes"`
+	// ResetBios resets the BIOS attributes to their defaults on the next reset of the system, it is cleared once requested
+	ResetBios bool `json:"resetBios,omitempty"`
+	// PasswordChanges change BIOS passwords on the next reset of the system, they are cleared once requested
+	PasswordChanges []BiosPasswordChange `json:"passwordChanges,omitempty"`
+	// SnapshotName is a BiosSnapshot whose attributes are added to Bios, it is cleared once imported
+	SnapshotName string `json:"snapshotName,omitempty"`
+}
+
+// BiosPasswordChange is a change of a BIOS password
+type BiosPasswordChange struct {
+	// PasswordName is the BIOS password to change, for example AdministratorPassword or PowerOnPassword
+	PasswordName string `json:"passwordName"`
+	// SecretName is the secret in the namespace of the BiosSetting holding the oldPassword and newPassword keys
+	SecretName string `json:"secretName"`
+}
+
+// BiosAction is the state of a BIOS reset to defaults or of a BIOS password change
+type BiosAction struct {
+	// Action is ResetBios or ChangePassword
+	Action       string `json:"action"`
+	PasswordName string `json:"passwordName,omitempty"`
+	// State is PendingReset, Applied, NotApplied, Unverified or Failed
+	State         string      `json:"state"`
+	Message       string      `json:"message,omitempty"`
+	RequestedTime metav1.Time `json:"requestedTime"`
+}
+
+// BiosSnapshotImport is the result of the import of a BiosSnapshot
+type BiosSnapshotImport struct {
+	SnapshotName string `json:"snapshotName"`
+	// Registry is the BIOS attribute registry the snapshot was captured with
+	Registry           string `json:"registry,omitempty"`
+	ImportedAttributes int    `json:"importedAttributes,omitempty"`
+	// SkippedAttributes holds the reason of every attribute of the snapshot which is not imported
+	SkippedAttributes map[string]string `json:"skippedAttributes,omitempty"`
+	Message           string            `json:"message,omitempty"`
+	ImportTime        metav1.Time       `json:"importTime"`
 }
 
 // BiosSettingStatus defines the observed state of BiosSetting
 type BiosSettingStatus struct {
 	BiosAttributes map[string]string `json:"attributes,omitempty"`
+	// AttributesInSync tells for every attribute of the spec whether its actual value on the BMC is the desired one
+	AttributesInSync map[string]bool `json:"attributesInSync,omitempty"`
+	// RejectedAttributes holds the reason of every attribute of the spec which is not valid in the attribute registry
+	RejectedAttributes map[string]string `json:"rejectedAttributes,omitempty"`
+	// IgnoredAttributes holds the reason of every attribute of the spec which is valid but not applied
+	IgnoredAttributes map[string]string `json:"ignoredAttributes,omitempty"`
+	// Deferred tells until when applying the attributes is deferred by a maintenance window
+	Deferred string `json:"deferred,omitempty"`
+	// ApplyError holds the reason the attributes of the spec could not be applied in the last attempt
+	ApplyError string `json:"applyError,omitempty"`
+	// Actions holds the state of the last BIOS reset to defaults and of the last change of every BIOS password
+	Actions []BiosAction `json:"actions,omitempty"`
+	// LastSnapshotImport is the result of the last import of a BiosSnapshot
+	LastSnapshotImport *BiosSnapshotImport `json:"lastSnapshotImport,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,70 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// BiosSnapshotSpec defines the desired state of BiosSnapshot
+type BiosSnapshotSpec struct {
+	// SourceBmc is the name of the Bmc object the BIOS attributes are captured from when Bios is empty
+	SourceBmc string `json:"sourceBmc,omitempty"`
+	// ExcludedAttributes are not captured, for example attributes unique to a server like ServerName
+	ExcludedAttributes []string `json:"excludedAttributes,omitempty"`
+	// Registry is the ID of the BIOS attribute registry the attributes belong to
+	Registry string `json:"registry,omitempty"`
+	// RegistryVersion is the version of the BIOS attribute registry
+	RegistryVersion string `json:"registryVersion,omitempty"`
+	// Bios holds the captured BIOS attributes by name
+	Bios map[string]string `json:"biosAttributes,omitempty"`
+}
+
+// BiosSnapshotStatus defines the observed state of BiosSnapshot
+type BiosSnapshotStatus struct {
+	CaptureTime *metav1.Time `json:"captureTime,omitempty"`
+	Message     string       `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// BiosSnapshot is the Schema for the biossnapshots API
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".spec.sourceBmc"
+// +kubebuilder:printcolumn:name="Registry",type="string",JSONPath=".spec.registry"
+// +kubebuilder:printcolumn:name="Captured",type="date",JSONPath=".status.captureTime"
+type BiosSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BiosSnapshotSpec   `json:"spec,omitempty"`
+	Status BiosSnapshotStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BiosSnapshotList contains a list of BiosSnapshot
+type BiosSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BiosSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BiosSnapshot{}, &BiosSnapshotList{})
+}
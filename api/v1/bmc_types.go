@@ -44,13 +44,48 @@ type BMC struct {
 // Credential struct holds username and password for the BMC
 type Credential struct {
 	Username string `json:"username"`
-	Password string `json:"password"`
+	Password string `json:"password,omitempty"`
+	// CredentialRef is the secret name or vault path from which the credentials are read,
+	// based on the credentialProvider configured for the operator
+	CredentialRef string `json:"credentialRef,omitempty"`
+}
+
+// ResetPolicy defines when the changes pending for a reset of the system are applied
+type ResetPolicy struct {
+	// Mode is Manual, Immediate or MaintenanceWindow, Manual by default.
+	// With Manual the system is reset when the approve-reset annotation is set on the Bmc object,
+	// with MaintenanceWindow it is reset in the next window of the MaintenanceWindow objects selecting the Bmc object
+	Mode string `json:"mode,omitempty"`
+	// ResetType is the reset type used to apply the pending changes, ForceRestart by default
+	ResetType string `json:"resetType,omitempty"`
 }
 
 // BmcSpec defines the desired state of Bmc
 type BmcSpec struct {
-	BmcDetails  BMC        `json:"bmc"`
-	Credentials Credential `json:"credentials,omitempty"`
+	BmcDetails  BMC          `json:"bmc"`
+	Credentials Credential   `json:"credentials,omitempty"`
+	ResetPolicy *ResetPolicy `json:"resetPolicy,omitempty"`
+}
+
+// PendingReset is a change applied on the BMC which takes effect on the next reset of the system
+type PendingReset struct {
+	// Kind of the change: Bios, Boot or Volume
+	Kind string `json:"kind"`
+	// Name of the object of the change
+	Name string `json:"name"`
+	// Values expected on the BMC after the reset
+	Values        map[string]string `json:"values,omitempty"`
+	RequestedTime metav1.Time       `json:"requestedTime"`
+}
+
+// ResetVerification is the result of the verification of a pending change after the reset of the system
+type ResetVerification struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Applied bool   `json:"applied"`
+	// Unverified is set for changes which can not be read back from the BMC, Applied is then false
+	Unverified bool   `json:"unverified,omitempty"`
+	Message    string `json:"message,omitempty"`
 }
 
 // BmcStatus defines the observed state of Bmc
@@ -67,6 +102,16 @@ type BmcStatus struct {
 	StorageControllers    map[string]ArrayControllers `json:"storageControllers,omitempty"`
 	SystemReset           string                      `json:"systemReset"`
 	PowerState            string                      `json:"powerState"`
+	PendingResets         []PendingReset              `json:"pendingResets,omitempty"`
+	// ResetScheduledTime is the time the pending changes are applied when waiting for a maintenance window
+	ResetScheduledTime    *metav1.Time        `json:"resetScheduledTime,omitempty"`
+	LastResetTime         *metav1.Time        `json:"lastResetTime,omitempty"`
+	LastResetVerification []ResetVerification `json:"lastResetVerification,omitempty"`
+	// Deferred tells until when the reset of the system is deferred by a maintenance window
+	Deferred string `json:"deferred,omitempty"`
+	// BiosRegistryChange is the difference between the previous and the current BIOS attribute registry
+	// after the last firmware update which changed the registry
+	BiosRegistryChange *BiosRegistryChange `json:"biosRegistryChange,omitempty"`
 }
 
 // SystemDetail struct defines basic properties of a system
@@ -0,0 +1,82 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// BmcEventLogSpec defines the desired state of BmcEventLog
+type BmcEventLogSpec struct {
+	// Bmc is the name of the Bmc object the events are received for
+	Bmc string `json:"bmc"`
+}
+
+// BmcEvent holds an event received from ODIM for a BMC
+type BmcEvent struct {
+	EventID           string `json:"eventId,omitempty"`
+	EventType         string `json:"eventType,omitempty"`
+	Severity          string `json:"severity,omitempty"`
+	MessageID         string `json:"messageId"`
+	Message           string `json:"message,omitempty"`
+	Resolution        string `json:"resolution,omitempty"`
+	EventTimestamp    string `json:"eventTimestamp,omitempty"`
+	OriginOfCondition string `json:"originOfCondition,omitempty"`
+	// ReceivedTime is the time at which the event is received by the operator
+	ReceivedTime metav1.Time `json:"receivedTime"`
+}
+
+// BmcEventLogStatus defines the observed state of BmcEventLog
+type BmcEventLogStatus struct {
+	// Events holds the recent events, oldest first
+	Events           []BmcEvent   `json:"events,omitempty"`
+	CriticalEvents   int          `json:"criticalEvents"`
+	WarningEvents    int          `json:"warningEvents"`
+	LastEventTime    *metav1.Time `json:"lastEventTime,omitempty"`
+	LastEventMessage string       `json:"lastEventMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// BmcEventLog is the Schema for the bmceventlogs API
+// +kubebuilder:printcolumn:name="Bmc",type="string",JSONPath=".spec.bmc"
+// +kubebuilder:printcolumn:name="Critical",type="integer",JSONPath=".status.criticalEvents"
+// +kubebuilder:printcolumn:name="Warning",type="integer",JSONPath=".status.warningEvents"
+// +kubebuilder:printcolumn:name="LastEvent",type="string",JSONPath=".status.lastEventMessage"
+// +kubebuilder:printcolumn:name="LastEventTime",type="date",JSONPath=".status.lastEventTime"
+type BmcEventLog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BmcEventLogSpec   `json:"spec,omitempty"`
+	Status BmcEventLogStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// BmcEventLogList contains a list of BmcEventLog
+type BmcEventLogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BmcEventLog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&BmcEventLog{}, &BmcEventLogList{})
+}
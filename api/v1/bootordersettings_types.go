@@ -27,11 +27,58 @@ type BootOrderSettingsSpec struct {
 	SystemID string       `json:"systemID,omitempty"`
 	SerialNo string       `json:"serialNumber,omitempty"`
 	Boot     *BootSetting `json:"boot,omitempty"`
+	// Intent is a named boot configuration, it is cleared once applied
+	Intent *BootIntent `json:"intent,omitempty"`
+}
+
+// BootIntent is a named boot configuration, like a one time PXE boot
+type BootIntent struct {
+	// Name is OneTimePxe, OneTimeHttp or PersistentUefiDisk
+	Name string `json:"name"`
+	// HttpBootUri is the URI booted by the OneTimeHttp intent
+	HttpBootUri string `json:"httpBootUri,omitempty"`
+	// Reset approves the reset of the system once the intent is applied, the reset follows the reset policy of the Bmc object
+	Reset bool `json:"reset,omitempty"`
+}
+
+// BootIntentResult is the state of a boot intent
+type BootIntentResult struct {
+	Name        string `json:"name"`
+	HttpBootUri string `json:"httpBootUri,omitempty"`
+	// State is PendingReset, Reset, Completed, Expired or Failed
+	State       string       `json:"state"`
+	Message     string       `json:"message,omitempty"`
+	AppliedTime metav1.Time  `json:"appliedTime"`
+	ResetTime   *metav1.Time `json:"resetTime,omitempty"`
 }
 
 // BootOrderSettingsStatus defines the observed state of BootOrderSettings
 type BootOrderSettingsStatus struct {
 	Boot BootSetting `json:"boot,omitempty"`
+	// LastIntent is the state of the last boot intent
+	LastIntent *BootIntentResult `json:"lastIntent,omitempty"`
+	// BootOptions are the boot options of the system the last boot order rules were resolved against
+	BootOptions []BootOption `json:"bootOptions,omitempty"`
+	// BootOrderRulesMessage is the result of the resolution of the last boot order rules
+	BootOrderRulesMessage string `json:"bootOrderRulesMessage,omitempty"`
+}
+
+// BootOrderRule selects boot options by their attributes instead of their BootOptionReference,
+// at least one of DisplayName and UefiDevicePath is required
+type BootOrderRule struct {
+	// DisplayName is a regular expression matched against the DisplayName of the boot options, case insensitive
+	DisplayName string `json:"displayName,omitempty"`
+	// UefiDevicePath is a regular expression matched against the UefiDevicePath of the boot options, case insensitive
+	UefiDevicePath string `json:"uefiDevicePath,omitempty"`
+	// Limit is the maximum number of boot options selected by the rule, all the matching boot options by default
+	Limit int `json:"limit,omitempty"`
+}
+
+// BootOption is a boot option of a system
+type BootOption struct {
+	BootOptionReference string `json:"bootOptionReference"`
+	DisplayName         string `json:"displayName,omitempty"`
+	UefiDevicePath      string `json:"uefiDevicePath,omitempty"`
 }
 
 // BootSetting defines the different settings for boot
@@ -43,6 +90,10 @@ type BootSetting struct {
 	BootTargetAllowableValues    []string `json:"bootSourceOverrideTarget.AllowableValues,omitempty"`
 	UefiTargetBootSourceOverride string   `json:"uefiTargetBootSourceOverride,omitempty"`
 	UefiTargetAllowableValues    []string `json:"uefiTargetBootSourceOverride.AllowableValues,omitempty"`
+	HttpBootUri                  string   `json:"httpBootUri,omitempty"`
+	// BootOrderRules set the boot order from the attributes of the boot options, the boot options
+	// selected by no rule keep their order after the selected ones
+	BootOrderRules []BootOrderRule `json:"bootOrderRules,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -32,6 +32,12 @@ type EventsubscriptionSpec struct {
 	EventFormatType      string   `json:"eventFormatType,omitempty"`
 	SubordinateResources bool     `json:"subordinateResources,omitempty"`
 	OriginResources      []string `json:"originResources,omitempty"`
+	// OriginSelector selects the Bmc objects whose systems are added to the origin resources of the subscription,
+	// the subscription is updated as matching Bmc objects are added or removed
+	OriginSelector *metav1.LabelSelector `json:"originSelector,omitempty"`
+	// DeliveryRetryPolicy is the policy of ODIM when the delivery of an event fails, RetryForever is used when empty
+	// +kubebuilder:validation:Enum=RetryForever;RetryForeverWithBackoff;SuspendRetries;TerminateAfterRetries
+	DeliveryRetryPolicy string `json:"deliveryRetryPolicy,omitempty"`
 }
 
 // EventsubscriptionStatus defines the observed state of Eventsubscription
@@ -46,6 +52,16 @@ type EventsubscriptionStatus struct {
 	SubscriptionType string   `json:"subscriptionType"`
 	ResourceTypes    []string `json:"resourceTypes,omitempty"`
 	OriginResources  []string `json:"originResources,omitempty"`
+	// RequestedOriginResources are the origin resources of the spec applied to the subscription,
+	// ODIM reports the origin resources of the subscription in a different form
+	RequestedOriginResources []string `json:"requestedOriginResources,omitempty"`
+	// SelectedBmcs are the Bmc objects selected by the origin selector of the spec applied to the subscription
+	SelectedBmcs []string `json:"selectedBmcs,omitempty"`
+	// State is the state of the subscription in ODIM, for example Enabled or Suspended when the deliveries failed
+	State string `json:"state,omitempty"`
+	// Health is the health of the subscription in ODIM
+	Health              string `json:"health,omitempty"`
+	DeliveryRetryPolicy string `json:"deliveryRetryPolicy,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -56,6 +72,7 @@ type EventsubscriptionStatus struct {
 // +kubebuilder:printcolumn:name="Destination",type="string",JSONPath=".status.destination"
 // +kubebuilder:printcolumn:name="Context",type="string",JSONPath=".status.context"
 // +kubebuilder:printcolumn:name="SubscriptionType",type="string",JSONPath=".status.subscriptionType"
+// +kubebuilder:printcolumn:name="State",type="string",JSONPath=".status.state"
 type Eventsubscription struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
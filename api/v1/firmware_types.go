@@ -41,6 +41,8 @@ type FirmwareStatus struct {
 	Status          string `json:"status,omitempty"`
 	FirmwareVersion string `json:"firmwareVersion,omitempty"`
 	ImagePath       string `json:"imagePath,omitempty"`
+	// Deferred tells until when the firmware update is deferred by a maintenance window
+	Deferred string `json:"deferred,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -0,0 +1,74 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// MaintenanceWindowSpec defines the desired state of MaintenanceWindow
+type MaintenanceWindowSpec struct {
+	// Schedule is the cron expression of the start of the window, for example "0 22 * * 6" for saturdays at 22:00
+	Schedule string `json:"schedule"`
+	// Duration of the window, for example 4h
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone of the schedule as an IANA time zone name, UTC by default
+	TimeZone string `json:"timeZone,omitempty"`
+	// BmcSelector selects the Bmc objects whose disruptive operations are deferred to the window, all Bmc objects are selected when empty
+	BmcSelector *metav1.LabelSelector `json:"bmcSelector,omitempty"`
+}
+
+// MaintenanceWindowStatus defines the observed state of MaintenanceWindow
+type MaintenanceWindowStatus struct {
+	Open            bool         `json:"open"`
+	NextWindowStart *metav1.Time `json:"nextWindowStart,omitempty"`
+	// WindowEnd is the end of the window when it is open
+	WindowEnd    *metav1.Time `json:"windowEnd,omitempty"`
+	SelectedBmcs int          `json:"selectedBmcs"`
+	Message      string       `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// MaintenanceWindow is the Schema for the maintenancewindows API
+// +kubebuilder:printcolumn:name="Schedule",type="string",JSONPath=".spec.schedule"
+// +kubebuilder:printcolumn:name="Duration",type="string",JSONPath=".spec.duration"
+// +kubebuilder:printcolumn:name="Open",type="boolean",JSONPath=".status.open"
+// +kubebuilder:printcolumn:name="NextWindowStart",type="string",JSONPath=".status.nextWindowStart"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type MaintenanceWindow struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MaintenanceWindowSpec   `json:"spec,omitempty"`
+	Status MaintenanceWindowStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MaintenanceWindowList contains a list of MaintenanceWindow
+type MaintenanceWindowList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MaintenanceWindow `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MaintenanceWindow{}, &MaintenanceWindowList{})
+}
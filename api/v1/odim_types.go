@@ -31,6 +31,8 @@ type OdimSpec struct {
 type OdimStatus struct {
 	Status           string            `json:"status,omitempty"`
 	ConnMethVariants map[string]string `json:"connectionMethodVariants,omitempty"`
+	// EventSubscriptionID is the ID of the event subscription of the bmc operator event listener in ODIM
+	EventSubscriptionID string `json:"eventSubscriptionID,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,80 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// PasswordRotationPolicySpec defines the desired state of PasswordRotationPolicy
+type PasswordRotationPolicySpec struct {
+	// Interval between two password rotations of a BMC, for example 2160h for 90 days
+	Interval metav1.Duration `json:"interval"`
+	// Length of the generated password, default is 16
+	Length int `json:"length,omitempty"`
+	// CharacterClasses used in the generated password, supported values are
+	// lowercase, uppercase, digits and symbols. All classes are used by default
+	CharacterClasses []string `json:"characterClasses,omitempty"`
+	// BmcSelector selects the Bmc objects whose password is rotated, all Bmc objects are selected when empty
+	BmcSelector *metav1.LabelSelector `json:"bmcSelector,omitempty"`
+}
+
+// BmcPasswordRotation holds the password rotation details of a BMC
+type BmcPasswordRotation struct {
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+	SecretName       string       `json:"secretName,omitempty"`
+	// Status is Success, Failed, Skipped, Desynced or Conflict
+	Status string `json:"status,omitempty"`
+	// OdimUpdatePending is set when the password is rotated on the BMC but ODIM still holds the previous password
+	OdimUpdatePending bool   `json:"odimUpdatePending,omitempty"`
+	Message           string `json:"message,omitempty"`
+}
+
+// PasswordRotationPolicyStatus defines the observed state of PasswordRotationPolicy
+type PasswordRotationPolicyStatus struct {
+	LastRotationTime *metav1.Time                   `json:"lastRotationTime,omitempty"`
+	Bmcs             map[string]BmcPasswordRotation `json:"bmcs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// PasswordRotationPolicy is the Schema for the passwordrotationpolicies API
+// +kubebuilder:printcolumn:name="Interval",type="string",JSONPath=".spec.interval"
+// +kubebuilder:printcolumn:name="LastRotationTime",type="date",JSONPath=".status.lastRotationTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type PasswordRotationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PasswordRotationPolicySpec   `json:"spec,omitempty"`
+	Status PasswordRotationPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// PasswordRotationPolicyList contains a list of PasswordRotationPolicy
+type PasswordRotationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PasswordRotationPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PasswordRotationPolicy{}, &PasswordRotationPolicyList{})
+}
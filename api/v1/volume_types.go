@@ -44,6 +44,8 @@ type VolumeStatus struct {
 	Drives              []int      `json:"drives"`
 	CapacityBytes       string     `json:"capacityBytes"`
 	Identifiers         Identifier `json:"Identifiers"`
+	// Deferred tells until when the creation or deletion of the volume is deferred by a maintenance window
+	Deferred string `json:"deferred,omitempty"`
 }
 
 // +kubebuilder:object:root=true
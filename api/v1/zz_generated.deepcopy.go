@@ -17,6 +17,7 @@
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -77,13 +78,260 @@ func (in *BMC) DeepCopy() *BMC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosAction) DeepCopyInto(out *BiosAction) {
+	*out = *in
+	in.RequestedTime.DeepCopyInto(&out.RequestedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosAction.
+func (in *BiosAction) DeepCopy() *BiosAction {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosAttributeDependency) DeepCopyInto(out *BiosAttributeDependency) {
+	*out = *in
+	in.Dependency.DeepCopyInto(&out.Dependency)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosAttributeDependency.
+func (in *BiosAttributeDependency) DeepCopy() *BiosAttributeDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosAttributeDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosDependencyCondition) DeepCopyInto(out *BiosDependencyCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosDependencyCondition.
+func (in *BiosDependencyCondition) DeepCopy() *BiosDependencyCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosDependencyCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosDependencyMap) DeepCopyInto(out *BiosDependencyMap) {
+	*out = *in
+	if in.MapFrom != nil {
+		in, out := &in.MapFrom, &out.MapFrom
+		*out = make([]BiosDependencyCondition, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosDependencyMap.
+func (in *BiosDependencyMap) DeepCopy() *BiosDependencyMap {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosDependencyMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosPasswordChange) DeepCopyInto(out *BiosPasswordChange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosPasswordChange.
+func (in *BiosPasswordChange) DeepCopy() *BiosPasswordChange {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosPasswordChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosProfile) DeepCopyInto(out *BiosProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosProfile.
+func (in *BiosProfile) DeepCopy() *BiosProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BiosProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosProfileCompliance) DeepCopyInto(out *BiosProfileCompliance) {
+	*out = *in
+	if in.NonCompliantAttributes != nil {
+		in, out := &in.NonCompliantAttributes, &out.NonCompliantAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosProfileCompliance.
+func (in *BiosProfileCompliance) DeepCopy() *BiosProfileCompliance {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosProfileCompliance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosProfileList) DeepCopyInto(out *BiosProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BiosProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosProfileList.
+func (in *BiosProfileList) DeepCopy() *BiosProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BiosProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosProfileSpec) DeepCopyInto(out *BiosProfileSpec) {
+	*out = *in
+	if in.Bios != nil {
+		in, out := &in.Bios, &out.Bios
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.BmcSelector != nil {
+		in, out := &in.BmcSelector, &out.BmcSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosProfileSpec.
+func (in *BiosProfileSpec) DeepCopy() *BiosProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosProfileStatus) DeepCopyInto(out *BiosProfileStatus) {
+	*out = *in
+	if in.Bmcs != nil {
+		in, out := &in.Bmcs, &out.Bmcs
+		*out = make(map[string]BiosProfileCompliance, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosProfileStatus.
+func (in *BiosProfileStatus) DeepCopy() *BiosProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosRegistryChange) DeepCopyInto(out *BiosRegistryChange) {
+	*out = *in
+	if in.AddedAttributes != nil {
+		in, out := &in.AddedAttributes, &out.AddedAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemovedAttributes != nil {
+		in, out := &in.RemovedAttributes, &out.RemovedAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChangedAttributes != nil {
+		in, out := &in.ChangedAttributes, &out.ChangedAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ChangeTime.DeepCopyInto(&out.ChangeTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosRegistryChange.
+func (in *BiosRegistryChange) DeepCopy() *BiosRegistryChange {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosRegistryChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BiosSchemaRegistry) DeepCopyInto(out *BiosSchemaRegistry) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSchemaRegistry.
@@ -157,6 +405,13 @@ func (in *BiosSchemaRegistrySpec) DeepCopyInto(out *BiosSchemaRegistrySpec) {
 		*out = make([]SupportedSystems, len(*in))
 		copy(*out, *in)
 	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]BiosAttributeDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSchemaRegistrySpec.
@@ -172,6 +427,15 @@ func (in *BiosSchemaRegistrySpec) DeepCopy() *BiosSchemaRegistrySpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BiosSchemaRegistryStatus) DeepCopyInto(out *BiosSchemaRegistryStatus) {
 	*out = *in
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnusedSince != nil {
+		in, out := &in.UnusedSince, &out.UnusedSince
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSchemaRegistryStatus.
@@ -253,6 +517,11 @@ func (in *BiosSettingSpec) DeepCopyInto(out *BiosSettingSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.PasswordChanges != nil {
+		in, out := &in.PasswordChanges, &out.PasswordChanges
+		*out = make([]BiosPasswordChange, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSettingSpec.
@@ -275,6 +544,39 @@ func (in *BiosSettingStatus) DeepCopyInto(out *BiosSettingStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.AttributesInSync != nil {
+		in, out := &in.AttributesInSync, &out.AttributesInSync
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RejectedAttributes != nil {
+		in, out := &in.RejectedAttributes, &out.RejectedAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IgnoredAttributes != nil {
+		in, out := &in.IgnoredAttributes, &out.IgnoredAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]BiosAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSnapshotImport != nil {
+		in, out := &in.LastSnapshotImport, &out.LastSnapshotImport
+		*out = new(BiosSnapshotImport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSettingStatus.
@@ -287,8 +589,179 @@ func (in *BiosSettingStatus) DeepCopy() *BiosSettingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosSnapshot) DeepCopyInto(out *BiosSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSnapshot.
+func (in *BiosSnapshot) DeepCopy() *BiosSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BiosSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosSnapshotImport) DeepCopyInto(out *BiosSnapshotImport) {
+	*out = *in
+	if in.SkippedAttributes != nil {
+		in, out := &in.SkippedAttributes, &out.SkippedAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ImportTime.DeepCopyInto(&out.ImportTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSnapshotImport.
+func (in *BiosSnapshotImport) DeepCopy() *BiosSnapshotImport {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosSnapshotImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosSnapshotList) DeepCopyInto(out *BiosSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BiosSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSnapshotList.
+func (in *BiosSnapshotList) DeepCopy() *BiosSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BiosSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosSnapshotSpec) DeepCopyInto(out *BiosSnapshotSpec) {
+	*out = *in
+	if in.ExcludedAttributes != nil {
+		in, out := &in.ExcludedAttributes, &out.ExcludedAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bios != nil {
+		in, out := &in.Bios, &out.Bios
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSnapshotSpec.
+func (in *BiosSnapshotSpec) DeepCopy() *BiosSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BiosSnapshotStatus) DeepCopyInto(out *BiosSnapshotStatus) {
+	*out = *in
+	if in.CaptureTime != nil {
+		in, out := &in.CaptureTime, &out.CaptureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BiosSnapshotStatus.
+func (in *BiosSnapshotStatus) DeepCopy() *BiosSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BiosSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bmc) DeepCopyInto(out *Bmc) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bmc.
+func (in *Bmc) DeepCopy() *Bmc {
+	if in == nil {
+		return nil
+	}
+	out := new(Bmc)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Bmc) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcEvent) DeepCopyInto(out *BmcEvent) {
+	*out = *in
+	in.ReceivedTime.DeepCopyInto(&out.ReceivedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcEvent.
+func (in *BmcEvent) DeepCopy() *BmcEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcEventLog) DeepCopyInto(out *BmcEventLog) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -296,91 +769,278 @@ func (in *Bmc) DeepCopyInto(out *Bmc) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Bmc.
-func (in *Bmc) DeepCopy() *Bmc {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcEventLog.
+func (in *BmcEventLog) DeepCopy() *BmcEventLog {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcEventLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BmcEventLog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcEventLogList) DeepCopyInto(out *BmcEventLogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BmcEventLog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcEventLogList.
+func (in *BmcEventLogList) DeepCopy() *BmcEventLogList {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcEventLogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BmcEventLogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcEventLogSpec) DeepCopyInto(out *BmcEventLogSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcEventLogSpec.
+func (in *BmcEventLogSpec) DeepCopy() *BmcEventLogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcEventLogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcEventLogStatus) DeepCopyInto(out *BmcEventLogStatus) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]BmcEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastEventTime != nil {
+		in, out := &in.LastEventTime, &out.LastEventTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcEventLogStatus.
+func (in *BmcEventLogStatus) DeepCopy() *BmcEventLogStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcEventLogStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcList) DeepCopyInto(out *BmcList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Bmc, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcList.
+func (in *BmcList) DeepCopy() *BmcList {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BmcList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcPasswordRotation) DeepCopyInto(out *BmcPasswordRotation) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcPasswordRotation.
+func (in *BmcPasswordRotation) DeepCopy() *BmcPasswordRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcPasswordRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcSpec) DeepCopyInto(out *BmcSpec) {
+	*out = *in
+	out.BmcDetails = in.BmcDetails
+	out.Credentials = in.Credentials
+	if in.ResetPolicy != nil {
+		in, out := &in.ResetPolicy, &out.ResetPolicy
+		*out = new(ResetPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcSpec.
+func (in *BmcSpec) DeepCopy() *BmcSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BmcStatus) DeepCopyInto(out *BmcStatus) {
+	*out = *in
+	if in.StorageControllers != nil {
+		in, out := &in.StorageControllers, &out.StorageControllers
+		*out = make(map[string]ArrayControllers, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.PendingResets != nil {
+		in, out := &in.PendingResets, &out.PendingResets
+		*out = make([]PendingReset, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResetScheduledTime != nil {
+		in, out := &in.ResetScheduledTime, &out.ResetScheduledTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResetTime != nil {
+		in, out := &in.LastResetTime, &out.LastResetTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastResetVerification != nil {
+		in, out := &in.LastResetVerification, &out.LastResetVerification
+		*out = make([]ResetVerification, len(*in))
+		copy(*out, *in)
+	}
+	if in.BiosRegistryChange != nil {
+		in, out := &in.BiosRegistryChange, &out.BiosRegistryChange
+		*out = new(BiosRegistryChange)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcStatus.
+func (in *BmcStatus) DeepCopy() *BmcStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BmcStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootIntent) DeepCopyInto(out *BootIntent) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootIntent.
+func (in *BootIntent) DeepCopy() *BootIntent {
 	if in == nil {
 		return nil
 	}
-	out := new(Bmc)
+	out := new(BootIntent)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Bmc) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BmcList) DeepCopyInto(out *BmcList) {
+func (in *BootIntentResult) DeepCopyInto(out *BootIntentResult) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Bmc, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	in.AppliedTime.DeepCopyInto(&out.AppliedTime)
+	if in.ResetTime != nil {
+		in, out := &in.ResetTime, &out.ResetTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcList.
-func (in *BmcList) DeepCopy() *BmcList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootIntentResult.
+func (in *BootIntentResult) DeepCopy() *BootIntentResult {
 	if in == nil {
 		return nil
 	}
-	out := new(BmcList)
+	out := new(BootIntentResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BmcList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BmcSpec) DeepCopyInto(out *BmcSpec) {
+func (in *BootOption) DeepCopyInto(out *BootOption) {
 	*out = *in
-	out.BmcDetails = in.BmcDetails
-	out.Credentials = in.Credentials
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcSpec.
-func (in *BmcSpec) DeepCopy() *BmcSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootOption.
+func (in *BootOption) DeepCopy() *BootOption {
 	if in == nil {
 		return nil
 	}
-	out := new(BmcSpec)
+	out := new(BootOption)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BmcStatus) DeepCopyInto(out *BmcStatus) {
+func (in *BootOrderRule) DeepCopyInto(out *BootOrderRule) {
 	*out = *in
-	if in.StorageControllers != nil {
-		in, out := &in.StorageControllers, &out.StorageControllers
-		*out = make(map[string]ArrayControllers, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BmcStatus.
-func (in *BmcStatus) DeepCopy() *BmcStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootOrderRule.
+func (in *BootOrderRule) DeepCopy() *BootOrderRule {
 	if in == nil {
 		return nil
 	}
-	out := new(BmcStatus)
+	out := new(BootOrderRule)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -452,6 +1112,11 @@ func (in *BootOrderSettingsSpec) DeepCopyInto(out *BootOrderSettingsSpec) {
 		*out = new(BootSetting)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Intent != nil {
+		in, out := &in.Intent, &out.Intent
+		*out = new(BootIntent)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootOrderSettingsSpec.
@@ -468,6 +1133,16 @@ func (in *BootOrderSettingsSpec) DeepCopy() *BootOrderSettingsSpec {
 func (in *BootOrderSettingsStatus) DeepCopyInto(out *BootOrderSettingsStatus) {
 	*out = *in
 	in.Boot.DeepCopyInto(&out.Boot)
+	if in.LastIntent != nil {
+		in, out := &in.LastIntent, &out.LastIntent
+		*out = new(BootIntentResult)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootOptions != nil {
+		in, out := &in.BootOptions, &out.BootOptions
+		*out = make([]BootOption, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootOrderSettingsStatus.
@@ -498,6 +1173,11 @@ func (in *BootSetting) DeepCopyInto(out *BootSetting) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.BootOrderRules != nil {
+		in, out := &in.BootOrderRules, &out.BootOrderRules
+		*out = make([]BootOrderRule, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootSetting.
@@ -750,6 +1430,11 @@ func (in *EventsubscriptionSpec) DeepCopyInto(out *EventsubscriptionSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OriginSelector != nil {
+		in, out := &in.OriginSelector, &out.OriginSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventsubscriptionSpec.
@@ -785,6 +1470,16 @@ func (in *EventsubscriptionStatus) DeepCopyInto(out *EventsubscriptionStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RequestedOriginResources != nil {
+		in, out := &in.RequestedOriginResources, &out.RequestedOriginResources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectedBmcs != nil {
+		in, out := &in.SelectedBmcs, &out.SelectedBmcs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventsubscriptionStatus.
@@ -918,6 +1613,109 @@ func (in *ImageDetails) DeepCopy() *ImageDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindow) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowList) DeepCopyInto(out *MaintenanceWindowList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MaintenanceWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowList.
+func (in *MaintenanceWindowList) DeepCopy() *MaintenanceWindowList {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MaintenanceWindowList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowSpec) DeepCopyInto(out *MaintenanceWindowSpec) {
+	*out = *in
+	out.Duration = in.Duration
+	if in.BmcSelector != nil {
+		in, out := &in.BmcSelector, &out.BmcSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowSpec.
+func (in *MaintenanceWindowSpec) DeepCopy() *MaintenanceWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindowStatus) DeepCopyInto(out *MaintenanceWindowStatus) {
+	*out = *in
+	if in.NextWindowStart != nil {
+		in, out := &in.NextWindowStart, &out.NextWindowStart
+		*out = (*in).DeepCopy()
+	}
+	if in.WindowEnd != nil {
+		in, out := &in.WindowEnd, &out.WindowEnd
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindowStatus.
+func (in *MaintenanceWindowStatus) DeepCopy() *MaintenanceWindowStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindowStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Odim) DeepCopyInto(out *Odim) {
 	*out = *in
@@ -1029,6 +1827,170 @@ func (in *Oem) DeepCopy() *Oem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationPolicy) DeepCopyInto(out *PasswordRotationPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationPolicy.
+func (in *PasswordRotationPolicy) DeepCopy() *PasswordRotationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PasswordRotationPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationPolicyList) DeepCopyInto(out *PasswordRotationPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PasswordRotationPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationPolicyList.
+func (in *PasswordRotationPolicyList) DeepCopy() *PasswordRotationPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PasswordRotationPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationPolicySpec) DeepCopyInto(out *PasswordRotationPolicySpec) {
+	*out = *in
+	out.Interval = in.Interval
+	if in.CharacterClasses != nil {
+		in, out := &in.CharacterClasses, &out.CharacterClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BmcSelector != nil {
+		in, out := &in.BmcSelector, &out.BmcSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationPolicySpec.
+func (in *PasswordRotationPolicySpec) DeepCopy() *PasswordRotationPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordRotationPolicyStatus) DeepCopyInto(out *PasswordRotationPolicyStatus) {
+	*out = *in
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Bmcs != nil {
+		in, out := &in.Bmcs, &out.Bmcs
+		*out = make(map[string]BmcPasswordRotation, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordRotationPolicyStatus.
+func (in *PasswordRotationPolicyStatus) DeepCopy() *PasswordRotationPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordRotationPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingReset) DeepCopyInto(out *PendingReset) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.RequestedTime.DeepCopyInto(&out.RequestedTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingReset.
+func (in *PendingReset) DeepCopy() *PendingReset {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingReset)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResetPolicy) DeepCopyInto(out *ResetPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResetPolicy.
+func (in *ResetPolicy) DeepCopy() *ResetPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ResetPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResetVerification) DeepCopyInto(out *ResetVerification) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResetVerification.
+func (in *ResetVerification) DeepCopy() *ResetVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ResetVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *State) DeepCopyInto(out *State) {
 	*out = *in
@@ -50,12 +50,51 @@ const (
 	// systemReset event
 	PendingForResetEvent = "Pending for"
 
+	// kinds of changes pending for a reset of the system
+	PendingResetBios   = "Bios"
+	PendingResetBoot   = "Boot"
+	PendingResetVolume = "Volume"
+	// PendingResetBiosDefaults is a reset of the BIOS attributes to their defaults
+	PendingResetBiosDefaults = "BiosDefaults"
+	// PendingResetBiosPassword is a change of a BIOS password, named after the password
+	PendingResetBiosPassword = "BiosPassword"
+
+	// BIOS actions and their states
+	BiosActionResetBios      = "ResetBios"
+	BiosActionChangePassword = "ChangePassword"
+	BiosActionPendingReset   = "PendingReset"
+	BiosActionApplied        = "Applied"
+	BiosActionNotApplied     = "NotApplied"
+	BiosActionUnverified     = "Unverified"
+	BiosActionFailed         = "Failed"
+
+	// boot intents and their states
+	BootIntentOneTimePxe         = "OneTimePxe"
+	BootIntentOneTimeHttp        = "OneTimeHttp"
+	BootIntentPersistentUefiDisk = "PersistentUefiDisk"
+	BootIntentPendingReset       = "PendingReset"
+	BootIntentReset              = "Reset"
+	BootIntentCompleted          = "Completed"
+	BootIntentExpired            = "Expired"
+	BootIntentFailed             = "Failed"
+
+	// reset policy modes
+	ResetPolicyManual            = "Manual"
+	ResetPolicyImmediate         = "Immediate"
+	ResetPolicyMaintenanceWindow = "MaintenanceWindow"
+
+	// ApproveResetAnnotation approves the reset of a system with pending changes when the reset policy is Manual
+	ApproveResetAnnotation = "infra.io.odimra/approve-reset"
+
 	//Volume request details
 	ApplyTime = "OnReset"
 
 	// EventsubscriptionFinalizer is the finalizer for eventsubscription
 	EventsubscriptionFinalizer = "infra.io.eventsubscription/finalizer"
 
+	// DefaultEventSubscriptionContext is the context of event subscription for bmc operator event listener
+	DefaultEventSubscriptionContext = "Default Bmc-Operator EventSubscription"
+
 	//Logging operation ActionID and ActionName
 	BmcOperator                   = "bmc-operator"
 	BMCSettingActionID            = "001"
@@ -76,4 +115,18 @@ const (
 	EventSubscriptionActionID     = "007"
 	TrackFileConfigActionName     = "TrackConfigChanges"
 	TrackFileConfigActionID       = "008"
+	PasswordRotationActionID      = "009"
+	PasswordRotationActionName    = "PasswordRotation"
+	EncryptionKeysActionID        = "010"
+	EncryptionKeysActionName      = "EncryptionKeyRotation"
+	BiosProfileActionID           = "011"
+	BiosProfileActionName         = "BiosProfile"
+	ResetCoordinatorActionID      = "012"
+	ResetCoordinatorActionName    = "ResetCoordinator"
+	MaintenanceWindowActionID     = "013"
+	MaintenanceWindowActionName   = "MaintenanceWindow"
+	BiosSchemaRegistryActionID    = "014"
+	BiosSchemaRegistryActionName  = "BiosSchemaRegistry"
+	BiosSnapshotActionID          = "015"
+	BiosSnapshotActionName        = "BiosSnapshot"
 )
@@ -0,0 +1,275 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+// biosPasswordChangeBody is the payload of the #Bios.ChangePassword action
+type biosPasswordChangeBody struct {
+	PasswordName string `json:"PasswordName"`
+	OldPassword  string `json:"OldPassword"`
+	NewPassword  string `json:"NewPassword"`
+}
+
+// requestBiosActions requests the reset to defaults and the password changes of the spec and records them
+// as pending for the next reset of the system. The actions are cleared from the spec before they are requested
+// so that a failed update of the object does not request them again
+func (r *BiosSettingReconciler) requestBiosActions(ctx context.Context, biosObj *infraiov1.BiosSetting, bmcObj *infraiov1.Bmc, biosUtil BiosInterface) {
+	resetBios, passwordChanges, err := r.clearBiosActions(ctx, biosObj)
+	if err != nil {
+		// actions are left in the spec and requested on the next reconcile
+		l.LogWithFields(ctx).Errorf("Error: Clearing bios actions of %s BiosSetting, actions are not requested: %s", biosObj.ObjectMeta.Name, err.Error())
+		return
+	}
+	biosPendingReset := fmt.Sprintf("%s Bios", constants.PendingForResetEvent)
+	actions := []infraiov1.BiosAction{}
+	if resetBios {
+		action := infraiov1.BiosAction{Action: constants.BiosActionResetBios, State: constants.BiosActionPendingReset, RequestedTime: metav1.Now()}
+		// the attributes which differ from their default are expected to be reset after the reset of the system
+		defaults := biosUtil.GetChangedDefaultAttributes(bmcObj.Status.BiosAttributeRegistry, biosObj.Status.BiosAttributes)
+		err := biosUtil.ResetBiosToDefaults(bmcObj)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Could not reset bios of %s BMC to defaults: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+			action.State, action.Message = constants.BiosActionFailed, err.Error()
+		} else if err = utils.RecordPendingReset(ctx, r.Client, bmcObj, constants.PendingResetBiosDefaults, biosObj.ObjectMeta.Name, defaults, biosPendingReset); err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Recording pending bios reset to defaults of %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+			action.Message = "bios is reset to defaults but the change could not be recorded for verification: " + err.Error()
+		}
+		actions = append(actions, action)
+	}
+	for _, change := range passwordChanges {
+		action := infraiov1.BiosAction{Action: constants.BiosActionChangePassword, PasswordName: change.PasswordName, State: constants.BiosActionPendingReset, RequestedTime: metav1.Now()}
+		oldPassword, newPassword, err := r.getBiosPasswords(ctx, change.SecretName, biosObj.Namespace)
+		if err == nil {
+			err = biosUtil.ChangeBiosPassword(bmcObj, change.PasswordName, oldPassword, newPassword)
+		}
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Could not change %s bios password of %s BMC: %s", change.PasswordName, bmcObj.Spec.BmcDetails.Address, err.Error())
+			action.State, action.Message = constants.BiosActionFailed, err.Error()
+		} else if err = utils.RecordPendingReset(ctx, r.Client, bmcObj, constants.PendingResetBiosPassword, change.PasswordName, nil, biosPendingReset); err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Recording pending %s bios password change of %s BMC: %s", change.PasswordName, bmcObj.Spec.BmcDetails.Address, err.Error())
+			action.Message = "bios password is changed but the change could not be recorded for verification: " + err.Error()
+		}
+		actions = append(actions, action)
+	}
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestBiosObj := &infraiov1.BiosSetting{}
+		err := r.Get(ctx, types.NamespacedName{Name: biosObj.Name, Namespace: biosObj.Namespace}, latestBiosObj)
+		if err != nil {
+			return err
+		}
+		latestBiosObj.Status.Deferred = ""
+		for _, action := range actions {
+			setBiosAction(latestBiosObj, action)
+		}
+		err = r.Status().Update(ctx, latestBiosObj)
+		if err != nil {
+			return err
+		}
+		*biosObj = *latestBiosObj
+		return nil
+	})
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Updating status of %s BiosSetting: %s", biosObj.ObjectMeta.Name, err.Error())
+	}
+}
+
+// clearBiosActions clears the reset to defaults and the password changes from the latest version of the spec,
+// the update is retried on conflict. The cleared actions are returned and biosObj is refreshed with the updated object
+func (r *BiosSettingReconciler) clearBiosActions(ctx context.Context, biosObj *infraiov1.BiosSetting) (bool, []infraiov1.BiosPasswordChange, error) {
+	var resetBios bool
+	var passwordChanges []infraiov1.BiosPasswordChange
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestBiosObj := &infraiov1.BiosSetting{}
+		err := r.Get(ctx, types.NamespacedName{Name: biosObj.Name, Namespace: biosObj.Namespace}, latestBiosObj)
+		if err != nil {
+			return err
+		}
+		// actions added to the spec since the object was read are requested as well
+		resetBios, passwordChanges = latestBiosObj.Spec.ResetBios, latestBiosObj.Spec.PasswordChanges
+		latestBiosObj.Spec.ResetBios = false
+		latestBiosObj.Spec.PasswordChanges = nil
+		err = r.Update(ctx, latestBiosObj)
+		if err != nil {
+			return err
+		}
+		*biosObj = *latestBiosObj
+		return nil
+	})
+	return resetBios, passwordChanges, err
+}
+
+// getBiosPasswords returns the old and new password of the secret of a bios password change
+func (r *BiosSettingReconciler) getBiosPasswords(ctx context.Context, secretName, namespace string) (string, string, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: namespace}, secret)
+	if err != nil {
+		return "", "", fmt.Errorf("could not get %s secret: %s", secretName, err.Error())
+	}
+	newPassword, ok := secret.Data["newPassword"]
+	if !ok || len(newPassword) == 0 {
+		return "", "", fmt.Errorf("%s secret has no newPassword", secretName)
+	}
+	return string(secret.Data["oldPassword"]), string(newPassword), nil
+}
+
+// setBiosAction records the state of a bios action, replacing the previous state of the same action
+func setBiosAction(biosObj *infraiov1.BiosSetting, action infraiov1.BiosAction) {
+	for i, existing := range biosObj.Status.Actions {
+		if existing.Action == action.Action && existing.PasswordName == action.PasswordName {
+			biosObj.Status.Actions[i] = action
+			return
+		}
+	}
+	biosObj.Status.Actions = append(biosObj.Status.Actions, action)
+}
+
+// ResetBiosToDefaults requests the #Bios.ResetBios action, the attributes are reset on the next reset of the system
+func (bs *biosUtils) ResetBiosToDefaults(bmcObj *infraiov1.Bmc) error {
+	return bs.postBiosAction(bmcObj, "#Bios.ResetBios", common.RESETBIOS, []byte("{}"))
+}
+
+// ChangeBiosPassword requests the #Bios.ChangePassword action, the password is changed on the next reset of the system
+func (bs *biosUtils) ChangeBiosPassword(bmcObj *infraiov1.Bmc, passwordName, oldPassword, newPassword string) error {
+	body, err := json.Marshal(biosPasswordChangeBody{PasswordName: passwordName, OldPassword: oldPassword, NewPassword: newPassword})
+	if err != nil {
+		return err
+	}
+	return bs.postBiosAction(bmcObj, "#Bios.ChangePassword", common.BIOSPASSWORD, body)
+}
+
+// postBiosAction posts an action of the bios resource of the system and waits for its task to complete
+func (bs *biosUtils) postBiosAction(bmcObj *infraiov1.Bmc, action, operation string, body []byte) error {
+	uri := "/redfish/v1/Systems/" + bmcObj.Status.BmcSystemID + "/Bios"
+	biosResp, _, err := bs.biosRestClient.Get(uri, fmt.Sprintf("Fetching Bios Details for %s BMC", bmcObj.Spec.BmcDetails.Address))
+	if err != nil {
+		return err
+	}
+	target := getActionTarget(biosResp, action)
+	if target == "" {
+		return fmt.Errorf("%s action is not supported by the BMC", action)
+	}
+	resp, err := bs.biosRestClient.Post(target, fmt.Sprintf("Posting %s action for %s BMC", action, bmcObj.Spec.BmcDetails.Address), body)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusAccepted:
+		done, _ := bs.commonUtil.MoniteringTaskmon(resp.Header, bs.ctx, operation, bmcObj.ObjectMeta.Name)
+		if !done {
+			return fmt.Errorf("%s action did not complete", action)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s action failed with status %d", action, resp.StatusCode)
+}
+
+// getActionTarget returns the target of an action of a redfish resource
+func getActionTarget(resp map[string]interface{}, action string) string {
+	actions, _ := resp["Actions"].(map[string]interface{})
+	details, _ := actions[action].(map[string]interface{})
+	target, _ := details["target"].(string)
+	return target
+}
+
+// GetChangedDefaultAttributes returns the default value of every attribute of the registry whose current value differs from it
+func (bs *biosUtils) GetChangedDefaultAttributes(biosID string, currentAttributes map[string]string) map[string]string {
+	schemaObj := bs.commonRec.GetBiosSchemaObject(bs.ctx, constants.MetadataName, utils.RemoveSpecialChar(biosID), bs.namespace)
+	if schemaObj == nil {
+		return nil
+	}
+	return getChangedDefaults(&schemaObj.Spec, currentAttributes)
+}
+
+// getChangedDefaults returns the default value of the writable attributes whose current value differs from it,
+// passwords are left out since their value is never exposed
+func getChangedDefaults(registry *infraiov1.BiosSchemaRegistrySpec, current map[string]string) map[string]string {
+	defaults := map[string]string{}
+	for _, entry := range registry.Attributes {
+		attr := entry["AttributeName"]
+		defaultValue, ok := entry["DefaultValue"]
+		if !ok || strings.EqualFold(entry["ReadOnly"], "true") || entry["Type"] == "Password" {
+			continue
+		}
+		if currentValue, ok := current[attr]; ok && currentValue != defaultValue {
+			defaults[attr] = defaultValue
+		}
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+	return defaults
+}
+
+// UpdateBiosActionsOnReset records the result of the bios actions verified after the reset of the system
+func (bs *biosUtils) UpdateBiosActionsOnReset(verifications []infraiov1.ResetVerification) {
+	biosObj := bs.commonRec.GetBiosObject(bs.ctx, constants.MetadataName, bs.biosObj.ObjectMeta.Name, bs.namespace)
+	if biosObj == nil {
+		return
+	}
+	updated := false
+	for _, verification := range verifications {
+		for i, action := range biosObj.Status.Actions {
+			if action.State != constants.BiosActionPendingReset || !isActionVerification(action, verification) {
+				continue
+			}
+			biosObj.Status.Actions[i].State, biosObj.Status.Actions[i].Message = constants.BiosActionNotApplied, verification.Message
+			if verification.Applied {
+				biosObj.Status.Actions[i].State = constants.BiosActionApplied
+			} else if verification.Unverified {
+				biosObj.Status.Actions[i].State = constants.BiosActionUnverified
+			}
+			updated = true
+		}
+	}
+	if !updated {
+		return
+	}
+	bs.biosObj = biosObj
+	err := bs.commonRec.GetCommonReconcilerClient().Status().Update(bs.ctx, biosObj)
+	if err != nil {
+		l.LogWithFields(bs.ctx).Errorf("Error: Updating bios actions of %s BiosSetting: %s", biosObj.ObjectMeta.Name, err.Error())
+	}
+}
+
+// isActionVerification tells whether the verification after a reset is the one of the bios action
+func isActionVerification(action infraiov1.BiosAction, verification infraiov1.ResetVerification) bool {
+	switch verification.Kind {
+	case constants.PendingResetBiosDefaults:
+		return action.Action == constants.BiosActionResetBios
+	case constants.PendingResetBiosPassword:
+		return action.Action == constants.BiosActionChangePassword && action.PasswordName == verification.Name
+	}
+	return false
+}
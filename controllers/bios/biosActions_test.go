@@ -0,0 +1,130 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+)
+
+func TestGetChangedDefaults(t *testing.T) {
+	registry := &infraiov1.BiosSchemaRegistrySpec{
+		Attributes: []map[string]string{
+			{"AttributeName": "BootMode", "Type": "Enumeration", "ReadOnly": "false", "DefaultValue": "Uefi"},
+			{"AttributeName": "ProcTurbo", "Type": "Enumeration", "ReadOnly": "false", "DefaultValue": "Enabled"},
+			{"AttributeName": "AdminPassword", "Type": "Password", "ReadOnly": "false", "DefaultValue": ""},
+			{"AttributeName": "SerialNumber", "Type": "String", "ReadOnly": "true", "DefaultValue": ""},
+			{"AttributeName": "ServerName", "Type": "String", "ReadOnly": "false"},
+		},
+	}
+	tests := []struct {
+		name    string
+		current map[string]string
+		want    map[string]string
+	}{
+		{
+			name:    "attributes with their default value",
+			current: map[string]string{"BootMode": "Uefi", "ProcTurbo": "Enabled"},
+			want:    nil,
+		},
+		{
+			name:    "changed attributes",
+			current: map[string]string{"BootMode": "LegacyBios", "ProcTurbo": "Enabled", "AdminPassword": "secret", "SerialNumber": "CZ123", "ServerName": "host1"},
+			want:    map[string]string{"BootMode": "Uefi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getChangedDefaults(registry, tt.current); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getChangedDefaults() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetBiosAction(t *testing.T) {
+	biosObj := &infraiov1.BiosSetting{}
+	setBiosAction(biosObj, infraiov1.BiosAction{Action: constants.BiosActionResetBios, State: constants.BiosActionFailed})
+	setBiosAction(biosObj, infraiov1.BiosAction{Action: constants.BiosActionChangePassword, PasswordName: "AdministratorPassword", State: constants.BiosActionPendingReset})
+	setBiosAction(biosObj, infraiov1.BiosAction{Action: constants.BiosActionResetBios, State: constants.BiosActionPendingReset})
+	want := []infraiov1.BiosAction{
+		{Action: constants.BiosActionResetBios, State: constants.BiosActionPendingReset},
+		{Action: constants.BiosActionChangePassword, PasswordName: "AdministratorPassword", State: constants.BiosActionPendingReset},
+	}
+	if !reflect.DeepEqual(biosObj.Status.Actions, want) {
+		t.Errorf("setBiosAction() = %v, want %v", biosObj.Status.Actions, want)
+	}
+}
+
+func TestIsActionVerification(t *testing.T) {
+	tests := []struct {
+		name         string
+		action       infraiov1.BiosAction
+		verification infraiov1.ResetVerification
+		want         bool
+	}{
+		{
+			name:         "reset to defaults",
+			action:       infraiov1.BiosAction{Action: constants.BiosActionResetBios},
+			verification: infraiov1.ResetVerification{Kind: constants.PendingResetBiosDefaults, Name: "10.24.0.14"},
+			want:         true,
+		},
+		{
+			name:         "same password",
+			action:       infraiov1.BiosAction{Action: constants.BiosActionChangePassword, PasswordName: "PowerOnPassword"},
+			verification: infraiov1.ResetVerification{Kind: constants.PendingResetBiosPassword, Name: "PowerOnPassword"},
+			want:         true,
+		},
+		{
+			name:         "other password",
+			action:       infraiov1.BiosAction{Action: constants.BiosActionChangePassword, PasswordName: "AdministratorPassword"},
+			verification: infraiov1.ResetVerification{Kind: constants.PendingResetBiosPassword, Name: "PowerOnPassword"},
+			want:         false,
+		},
+		{
+			name:         "bios attributes",
+			action:       infraiov1.BiosAction{Action: constants.BiosActionResetBios},
+			verification: infraiov1.ResetVerification{Kind: constants.PendingResetBios, Name: "10.24.0.14"},
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isActionVerification(tt.action, tt.verification); got != tt.want {
+				t.Errorf("isActionVerification() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetActionTarget(t *testing.T) {
+	resp := map[string]interface{}{
+		"Actions": map[string]interface{}{
+			"#Bios.ResetBios": map[string]interface{}{"target": "/redfish/v1/Systems/uuid.1/Bios/Actions/Bios.ResetBios"},
+		},
+	}
+	if got := getActionTarget(resp, "#Bios.ResetBios"); got != "/redfish/v1/Systems/uuid.1/Bios/Actions/Bios.ResetBios" {
+		t.Errorf("getActionTarget() = %v", got)
+	}
+	if got := getActionTarget(resp, "#Bios.ChangePassword"); got != "" {
+		t.Errorf("getActionTarget() = %v, want empty target", got)
+	}
+	if got := getActionTarget(map[string]interface{}{}, "#Bios.ResetBios"); got != "" {
+		t.Errorf("getActionTarget() = %v, want empty target", got)
+	}
+}
@@ -0,0 +1,177 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+// getSnapshotAttributes returns the attributes to capture in a snapshot, read only attributes, passwords,
+// excluded attributes and attributes missing in the registry can't be applied to another server
+func getSnapshotAttributes(registry *infraiov1.BiosSchemaRegistrySpec, attributes map[string]string, excluded []string) map[string]string {
+	entries := map[string]map[string]string{}
+	for _, entry := range registry.Attributes {
+		entries[entry["AttributeName"]] = entry
+	}
+	excludedAttributes := map[string]bool{}
+	for _, attr := range excluded {
+		excludedAttributes[attr] = true
+	}
+	snapshot := map[string]string{}
+	for attr, value := range attributes {
+		entry, ok := entries[attr]
+		if !ok || excludedAttributes[attr] || strings.EqualFold(entry["ReadOnly"], "true") || entry["Type"] == "Password" {
+			continue
+		}
+		snapshot[attr] = value
+	}
+	return snapshot
+}
+
+// translateSnapshotAttributes translates the attributes of a snapshot captured with the source registry to the
+// target registry and returns the attributes which can be applied and the reason of every skipped attribute.
+// Enumeration values unknown to the target registry are matched by their display name in the source registry,
+// source is nil when the registry of the snapshot is not available
+func translateSnapshotAttributes(source, target *infraiov1.BiosSchemaRegistrySpec, attributes, current map[string]string) (map[string]string, map[string]string) {
+	targetEntries := map[string]map[string]string{}
+	for _, entry := range target.Attributes {
+		targetEntries[entry["AttributeName"]] = entry
+	}
+	sourceEntries := map[string]map[string]string{}
+	if source != nil {
+		for _, entry := range source.Attributes {
+			sourceEntries[entry["AttributeName"]] = entry
+		}
+	}
+	requested := map[string]string{}
+	for attr, value := range attributes {
+		requested[attr] = value
+		if targetEntry, ok := targetEntries[attr]; ok && targetEntry["Type"] == "Enumeration" {
+			if translated, ok := translateEnumValue(sourceEntries[attr], targetEntry, value); ok {
+				requested[attr] = translated
+			}
+		}
+	}
+	validation := validateAttributes(target, requested, current)
+	translated := map[string]string{}
+	for attr := range validation.Attributes {
+		translated[attr] = requested[attr]
+	}
+	skipped := map[string]string{}
+	for attr, reason := range validation.Rejected {
+		skipped[attr] = reason
+	}
+	for attr, reason := range validation.Ignored {
+		skipped[attr] = reason
+	}
+	return translated, skipped
+}
+
+// translateEnumValue returns the value of the target enumeration with the display name of value in the source enumeration
+func translateEnumValue(sourceEntry, targetEntry map[string]string, value string) (string, bool) {
+	var sourceValues, targetValues []AttributeEnumValue
+	json.Unmarshal([]byte(targetEntry["Value"]), &targetValues)
+	for _, targetValue := range targetValues {
+		if targetValue.ValueName == value {
+			return value, true
+		}
+	}
+	json.Unmarshal([]byte(sourceEntry["Value"]), &sourceValues)
+	for _, sourceValue := range sourceValues {
+		if sourceValue.ValueName != value || sourceValue.ValueDisplayName == "" {
+			continue
+		}
+		for _, targetValue := range targetValues {
+			if strings.EqualFold(targetValue.ValueDisplayName, sourceValue.ValueDisplayName) {
+				return targetValue.ValueName, true
+			}
+		}
+	}
+	return "", false
+}
+
+// importBiosSnapshot adds the attributes of the snapshot which apply to the BMC to the spec of the BiosSetting,
+// the attributes already in the spec are kept. The snapshot reference is cleared once imported, false is returned
+// when the import has to be retried
+func (r *BiosSettingReconciler) importBiosSnapshot(ctx context.Context, biosObj *infraiov1.BiosSetting, bmcObj *infraiov1.Bmc, commonRec utils.ReconcilerInterface) bool {
+	snapshotName := biosObj.Spec.SnapshotName
+	result := &infraiov1.BiosSnapshotImport{SnapshotName: snapshotName, ImportTime: metav1.Now()}
+	snapshotObj := &infraiov1.BiosSnapshot{}
+	err := r.Get(ctx, types.NamespacedName{Name: snapshotName, Namespace: biosObj.Namespace}, snapshotObj)
+	switch {
+	case Error.IsNotFound(err):
+		result.Message = fmt.Sprintf("BiosSnapshot %s is not found", snapshotName)
+	case err != nil:
+		l.LogWithFields(ctx).Errorf("Error fetching %s bios snapshot: %s", snapshotName, err.Error())
+		return false
+	case len(snapshotObj.Spec.Bios) == 0:
+		l.LogWithFields(ctx).Infof("Bios attributes of %s bios snapshot are not captured yet", snapshotName)
+		return false
+	default:
+		target := commonRec.GetBiosSchemaObject(ctx, constants.MetadataName, utils.RemoveSpecialChar(bmcObj.Status.BiosAttributeRegistry), bmcObj.Namespace)
+		if target == nil {
+			l.LogWithFields(ctx).Infof("BiosSchemaRegistry of %s BMC is not available, %s bios snapshot is imported later", bmcObj.Spec.BmcDetails.Address, snapshotName)
+			return false
+		}
+		var source *infraiov1.BiosSchemaRegistrySpec
+		if snapshotObj.Spec.Registry != "" {
+			if sourceObj := commonRec.GetBiosSchemaObject(ctx, constants.MetadataName, utils.RemoveSpecialChar(snapshotObj.Spec.Registry), bmcObj.Namespace); sourceObj != nil {
+				source = &sourceObj.Spec
+			}
+		}
+		translated, skipped := translateSnapshotAttributes(source, &target.Spec, snapshotObj.Spec.Bios, biosObj.Status.BiosAttributes)
+		if biosObj.Spec.Bios == nil {
+			biosObj.Spec.Bios = map[string]string{}
+		}
+		for attr, value := range translated {
+			if _, ok := biosObj.Spec.Bios[attr]; !ok {
+				biosObj.Spec.Bios[attr] = value
+				result.ImportedAttributes++
+			}
+		}
+		result.Registry = snapshotObj.Spec.Registry
+		if len(skipped) != 0 {
+			result.SkippedAttributes = skipped
+		}
+		if snapshotObj.Spec.Registry != target.Spec.ID {
+			result.Message = fmt.Sprintf("attributes are translated from BiosSchemaRegistry %s to %s", snapshotObj.Spec.Registry, target.Spec.ID)
+		}
+	}
+	biosObj.Spec.SnapshotName = ""
+	err = r.Update(ctx, biosObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Importing %s bios snapshot into %s BiosSetting: %s", snapshotName, biosObj.Name, err.Error())
+		return false
+	}
+	biosObj.Status.LastSnapshotImport = result
+	err = r.Status().Update(ctx, biosObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Updating status of %s BiosSetting: %s", biosObj.Name, err.Error())
+	}
+	l.LogWithFields(ctx).Infof("Imported %d attributes of %s bios snapshot into %s BiosSetting", result.ImportedAttributes, snapshotName, biosObj.Name)
+	return true
+}
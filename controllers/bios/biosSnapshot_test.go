@@ -0,0 +1,108 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+)
+
+func TestGetSnapshotAttributes(t *testing.T) {
+	registry := &infraiov1.BiosSchemaRegistrySpec{
+		Attributes: []map[string]string{
+			{"AttributeName": "BootMode", "Type": "Enumeration", "ReadOnly": "false"},
+			{"AttributeName": "ServerName", "Type": "String", "ReadOnly": "false"},
+			{"AttributeName": "AdminPassword", "Type": "Password", "ReadOnly": "false"},
+			{"AttributeName": "SerialNumber", "Type": "String", "ReadOnly": "true"},
+		},
+	}
+	attributes := map[string]string{"BootMode": "Uefi", "ServerName": "host1", "AdminPassword": "", "SerialNumber": "CZ123", "Unknown": "1"}
+	tests := []struct {
+		name     string
+		excluded []string
+		want     map[string]string
+	}{
+		{
+			name: "writable attributes of the registry",
+			want: map[string]string{"BootMode": "Uefi", "ServerName": "host1"},
+		},
+		{
+			name:     "excluded attributes",
+			excluded: []string{"ServerName"},
+			want:     map[string]string{"BootMode": "Uefi"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getSnapshotAttributes(registry, attributes, tt.excluded); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSnapshotAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslateSnapshotAttributes(t *testing.T) {
+	source := &infraiov1.BiosSchemaRegistrySpec{
+		Attributes: []map[string]string{
+			{"AttributeName": "PowerProfile", "Type": "Enumeration", "ReadOnly": "false", "Value": `[{"ValueName":"MaxPerf","ValueDisplayName":"Maximum Performance"}]`},
+		},
+	}
+	target := &infraiov1.BiosSchemaRegistrySpec{
+		Attributes: []map[string]string{
+			{"AttributeName": "BootMode", "Type": "Enumeration", "ReadOnly": "false", "Value": `[{"ValueName":"Uefi"},{"ValueName":"LegacyBios"}]`},
+			{"AttributeName": "PowerProfile", "Type": "Enumeration", "ReadOnly": "false", "Value": `[{"ValueName":"MaximumPerformance","ValueDisplayName":"Maximum Performance"}]`},
+			{"AttributeName": "ProcTurbo", "Type": "Enumeration", "ReadOnly": "false", "GrayOut": "true", "Value": `[{"ValueName":"Enabled"}]`},
+			{"AttributeName": "SerialNumber", "Type": "String", "ReadOnly": "true"},
+		},
+	}
+	attributes := map[string]string{"BootMode": "Uefi", "PowerProfile": "MaxPerf", "ProcTurbo": "Enabled", "SerialNumber": "CZ123", "Removed": "1"}
+	tests := []struct {
+		name           string
+		source         *infraiov1.BiosSchemaRegistrySpec
+		wantTranslated map[string]string
+		wantSkipped    []string
+	}{
+		{
+			name:           "enumeration values are translated with the source registry",
+			source:         source,
+			wantTranslated: map[string]string{"BootMode": "Uefi", "PowerProfile": "MaximumPerformance"},
+			wantSkipped:    []string{"ProcTurbo", "Removed", "SerialNumber"},
+		},
+		{
+			name:           "source registry not available",
+			wantTranslated: map[string]string{"BootMode": "Uefi"},
+			wantSkipped:    []string{"PowerProfile", "ProcTurbo", "Removed", "SerialNumber"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			translated, skipped := translateSnapshotAttributes(tt.source, target, attributes, nil)
+			if !reflect.DeepEqual(translated, tt.wantTranslated) {
+				t.Errorf("translateSnapshotAttributes() translated = %v, want %v", translated, tt.wantTranslated)
+			}
+			got := []string{}
+			for attr := range skipped {
+				got = append(got, attr)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.wantSkipped) {
+				t.Errorf("translateSnapshotAttributes() skipped = %v, want %v", got, tt.wantSkipped)
+			}
+		})
+	}
+}
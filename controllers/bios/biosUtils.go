@@ -24,11 +24,15 @@ import (
 )
 
 type BiosInterface interface {
-	ValidateBiosAttributes(biosID string) (bool, map[string]interface{})
+	ValidateBiosAttributes(biosID string, currentAttributes map[string]string) (BiosAttributeValidation, error)
 	getBiosLinkAndBody(resp map[string]interface{}, biosProps map[string]interface{}, biosBmcIP string) (string, []byte)
 	UpdateBiosAttributesOnReset(biosBmcIP string, updatedBiosAttributes map[string]string)
 	GetBiosAttributes(bmcObj *infraiov1.Bmc) map[string]string
 	GetBiosAttributeID(biosVersion string, bmcObj *infraiov1.Bmc) (string, map[string]interface{})
+	ResetBiosToDefaults(bmcObj *infraiov1.Bmc) error
+	ChangeBiosPassword(bmcObj *infraiov1.Bmc, passwordName, oldPassword, newPassword string) error
+	GetChangedDefaultAttributes(biosID string, currentAttributes map[string]string) map[string]string
+	UpdateBiosActionsOnReset(verifications []infraiov1.ResetVerification)
 }
 
 type biosUtils struct {
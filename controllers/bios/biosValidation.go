@@ -0,0 +1,268 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+// BiosAttributeValidation is the result of the validation of BIOS attributes against the attribute registry
+type BiosAttributeValidation struct {
+	// Attributes are the accepted attributes, converted to the type of the registry
+	Attributes map[string]interface{}
+	// Rejected holds the reason of every attribute which is not valid
+	Rejected map[string]string
+	// Ignored holds the reason of every attribute which is valid but not applied
+	Ignored map[string]string
+	// Sensitive holds the accepted attributes whose value must not be exposed, like passwords
+	Sensitive map[string]bool
+}
+
+// IsValid returns true when no attribute is rejected
+func (v BiosAttributeValidation) IsValid() bool {
+	return len(v.Rejected) == 0
+}
+
+// String returns the rejected and ignored attributes with their reason, sorted by attribute
+func (v BiosAttributeValidation) String() string {
+	messages := []string{}
+	for attr, reason := range v.Rejected {
+		messages = append(messages, fmt.Sprintf("%s is rejected: %s", attr, reason))
+	}
+	for attr, reason := range v.Ignored {
+		messages = append(messages, fmt.Sprintf("%s is ignored: %s", attr, reason))
+	}
+	sort.Strings(messages)
+	return strings.Join(messages, "; ")
+}
+
+// ValidateBiosAttributes is used to validate the input data for bios attributes against the attribute registry,
+// the dependencies of the registry are evaluated with the requested values and currentAttributes for the others
+func (bs *biosUtils) ValidateBiosAttributes(biosID string, currentAttributes map[string]string) (BiosAttributeValidation, error) {
+	l.LogWithFields(bs.ctx).Info("Validating BIOS Attributes..")
+	schemaName := utils.RemoveSpecialChar(biosID)
+	biosSchemaObject = bs.commonRec.GetBiosSchemaObject(bs.ctx, constants.MetadataName, schemaName, bs.namespace)
+	if biosSchemaObject == nil || biosSchemaObject.Spec.Attributes == nil {
+		l.LogWithFields(bs.ctx).Errorf("No object with schema name %s present!", schemaName)
+		return BiosAttributeValidation{}, fmt.Errorf("BiosSchemaRegistry %s is not available", schemaName)
+	}
+	validation := validateAttributes(&biosSchemaObject.Spec, bs.biosObj.Spec.Bios, currentAttributes)
+	for attr, reason := range validation.Rejected {
+		l.LogWithFields(bs.ctx).Errorf("BIOS attribute %s is rejected: %s", attr, reason)
+	}
+	for attr, reason := range validation.Ignored {
+		l.LogWithFields(bs.ctx).Infof("BIOS attribute %s is ignored: %s", attr, reason)
+	}
+	return validation, nil
+}
+
+// validateAttributes validates the requested attributes against the registry
+func validateAttributes(registry *infraiov1.BiosSchemaRegistrySpec, requested, current map[string]string) BiosAttributeValidation {
+	validation := BiosAttributeValidation{
+		Attributes: map[string]interface{}{},
+		Rejected:   map[string]string{},
+		Ignored:    map[string]string{},
+		Sensitive:  map[string]bool{},
+	}
+	entries := map[string]map[string]string{}
+	for _, entry := range registry.Attributes {
+		entries[entry["AttributeName"]] = entry
+	}
+	values := map[string]string{}
+	for attr, value := range current {
+		values[attr] = value
+	}
+	for attr, value := range requested {
+		values[attr] = value
+	}
+	for attr, value := range requested {
+		entry, ok := entries[attr]
+		if !ok {
+			validation.Rejected[attr] = "attribute is not present in the attribute registry"
+			continue
+		}
+		properties := map[string]string{}
+		for key, val := range entry {
+			properties[key] = val
+		}
+		forcedValue, forcedBy := "", ""
+		for _, dependency := range registry.Dependencies {
+			if dependency.Dependency.MapToAttribute != attr || !dependencyApplies(dependency.Dependency.MapFrom, entries, values) {
+				continue
+			}
+			if dependency.Dependency.MapToProperty == "CurrentValue" {
+				forcedValue, forcedBy = dependency.Dependency.MapToValue, getMapFromAttributes(dependency.Dependency.MapFrom)
+				continue
+			}
+			properties[dependency.Dependency.MapToProperty] = dependency.Dependency.MapToValue
+		}
+		if strings.EqualFold(properties["ReadOnly"], "true") {
+			validation.Rejected[attr] = "attribute is read only"
+			continue
+		}
+		if forcedBy != "" && !strings.EqualFold(forcedValue, value) {
+			validation.Rejected[attr] = fmt.Sprintf("value is set to %s by the dependency on %s", forcedValue, forcedBy)
+			continue
+		}
+		if strings.EqualFold(properties["GrayOut"], "true") {
+			validation.Ignored[attr] = "attribute is grayed out"
+			continue
+		}
+		typedValue, err := validateAttributeValue(properties, value)
+		if err != nil {
+			validation.Rejected[attr] = err.Error()
+			continue
+		}
+		validation.Attributes[attr] = typedValue
+		if properties["Type"] == "Password" {
+			validation.Sensitive[attr] = true
+		}
+	}
+	return validation
+}
+
+// validateAttributeValue validates the value against the type and the properties of the attribute
+// and returns it converted to the type of the attribute
+func validateAttributeValue(properties map[string]string, value string) (interface{}, error) {
+	switch properties["Type"] {
+	case "String", "Password":
+		if maxLength, ok := getNumberProperty(properties, "MaxLength"); ok && float64(len(value)) > maxLength {
+			return nil, fmt.Errorf("length is more than MaxLength %s", properties["MaxLength"])
+		}
+		if minLength, ok := getNumberProperty(properties, "MinLength"); ok && float64(len(value)) < minLength {
+			return nil, fmt.Errorf("length is less than MinLength %s", properties["MinLength"])
+		}
+		if expression := properties["ValueExpression"]; expression != "" {
+			re, err := regexp.Compile("^(?:" + expression + ")$")
+			if err != nil {
+				return nil, fmt.Errorf("ValueExpression %s of the attribute registry is not a valid regular expression: %s", expression, err.Error())
+			}
+			if !re.MatchString(value) {
+				return nil, fmt.Errorf("value does not match ValueExpression %s", expression)
+			}
+		}
+		return value, nil
+	case "Integer":
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not an integer", value)
+		}
+		lowerBound, hasLowerBound := getNumberProperty(properties, "LowerBound")
+		if hasLowerBound && float64(number) < lowerBound {
+			return nil, fmt.Errorf("value %d is less than LowerBound %s", number, properties["LowerBound"])
+		}
+		if upperBound, ok := getNumberProperty(properties, "UpperBound"); ok && float64(number) > upperBound {
+			return nil, fmt.Errorf("value %d is more than UpperBound %s", number, properties["UpperBound"])
+		}
+		if increment, ok := getNumberProperty(properties, "ScalarIncrement"); ok && increment > 0 {
+			if math.Mod(float64(number)-lowerBound, increment) != 0 {
+				return nil, fmt.Errorf("value %d is not a multiple of ScalarIncrement %s from LowerBound", number, properties["ScalarIncrement"])
+			}
+		}
+		return number, nil
+	case "Enumeration":
+		var enumValues []AttributeEnumValue
+		if err := json.Unmarshal([]byte(properties["Value"]), &enumValues); err != nil {
+			return nil, fmt.Errorf("values of the enumeration in the attribute registry could not be read: %s", err.Error())
+		}
+		for _, enumValue := range enumValues {
+			if value == enumValue.ValueName {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("value %s is not one of the values of the enumeration", value)
+	case "Boolean":
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("value %s is not a boolean", value)
+		}
+		return boolean, nil
+	}
+	return nil, fmt.Errorf("attribute type %s is not supported", properties["Type"])
+}
+
+// dependencyApplies evaluates the MapFrom conditions of a dependency in order, MapTerms combines
+// a condition with the result of the previous ones
+func dependencyApplies(conditions []infraiov1.BiosDependencyCondition, entries map[string]map[string]string, values map[string]string) bool {
+	result := false
+	for i, condition := range conditions {
+		met := conditionMet(condition, entries, values)
+		if i == 0 {
+			result = met
+		} else if strings.EqualFold(condition.MapTerms, "OR") {
+			result = result || met
+		} else {
+			result = result && met
+		}
+	}
+	return result
+}
+
+// conditionMet compares the property of the MapFrom attribute with the MapFrom value
+func conditionMet(condition infraiov1.BiosDependencyCondition, entries map[string]map[string]string, values map[string]string) bool {
+	var actual string
+	var ok bool
+	if condition.MapFromProperty == "" || condition.MapFromProperty == "CurrentValue" {
+		actual, ok = values[condition.MapFromAttribute]
+	} else {
+		actual, ok = entries[condition.MapFromAttribute][condition.MapFromProperty]
+	}
+	if !ok {
+		return false
+	}
+	expected := condition.MapFromValue
+	actualNumber, actualErr := strconv.ParseFloat(actual, 64)
+	expectedNumber, expectedErr := strconv.ParseFloat(expected, 64)
+	isNumber := actualErr == nil && expectedErr == nil
+	switch condition.MapFromCondition {
+	case "EQU":
+		return strings.EqualFold(actual, expected) || (isNumber && actualNumber == expectedNumber)
+	case "NEQ":
+		return !strings.EqualFold(actual, expected) && !(isNumber && actualNumber == expectedNumber)
+	case "GTR":
+		return isNumber && actualNumber > expectedNumber
+	case "GEQ":
+		return isNumber && actualNumber >= expectedNumber
+	case "LSS":
+		return isNumber && actualNumber < expectedNumber
+	case "LEQ":
+		return isNumber && actualNumber <= expectedNumber
+	}
+	return false
+}
+
+func getMapFromAttributes(conditions []infraiov1.BiosDependencyCondition) string {
+	attributes := []string{}
+	for _, condition := range conditions {
+		attributes = append(attributes, condition.MapFromAttribute)
+	}
+	return strings.Join(attributes, ", ")
+}
+
+func getNumberProperty(properties map[string]string, name string) (float64, bool) {
+	number, err := strconv.ParseFloat(properties[name], 64)
+	return number, err == nil
+}
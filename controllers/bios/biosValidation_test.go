@@ -0,0 +1,155 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+)
+
+func TestValidateAttributes(t *testing.T) {
+	registry := &infraiov1.BiosSchemaRegistrySpec{
+		Attributes: []map[string]string{
+			{"AttributeName": "BootMode", "Type": "Enumeration", "ReadOnly": "false", "Value": `[{"ValueName":"Uefi"},{"ValueName":"LegacyBios"}]`},
+			{"AttributeName": "ProcTurbo", "Type": "Enumeration", "ReadOnly": "false", "Value": `[{"ValueName":"Enabled"},{"ValueName":"Disabled"}]`},
+			{"AttributeName": "PowerRegulator", "Type": "Enumeration", "ReadOnly": "false", "GrayOut": "false", "Value": `[{"ValueName":"DynamicPowerSavings"},{"ValueName":"StaticHighPerf"}]`},
+			{"AttributeName": "ServerName", "Type": "String", "ReadOnly": "false", "MaxLength": "10", "ValueExpression": "[A-Za-z0-9-]*"},
+			{"AttributeName": "AdminPassword", "Type": "Password", "ReadOnly": "false", "MinLength": "8"},
+			{"AttributeName": "ThermalShutdownTime", "Type": "Integer", "ReadOnly": "false", "LowerBound": "0", "UpperBound": "100", "ScalarIncrement": "5"},
+			{"AttributeName": "SerialNumber", "Type": "String", "ReadOnly": "true"},
+			{"AttributeName": "SecureBoot", "Type": "Boolean", "ReadOnly": "false"},
+			{"AttributeName": "AssetTag", "Type": "String", "ReadOnly": "false", "ValueExpression": "[A-Z"},
+			{"AttributeName": "WorkloadProfile", "Type": "Enumeration", "ReadOnly": "false", "Value": "GeneralPowerEfficientCompute"},
+		},
+		Dependencies: []infraiov1.BiosAttributeDependency{
+			{
+				DependencyFor: "PowerRegulator",
+				Type:          "Map",
+				Dependency: infraiov1.BiosDependencyMap{
+					MapFrom:        []infraiov1.BiosDependencyCondition{{MapFromAttribute: "ProcTurbo", MapFromCondition: "EQU", MapFromProperty: "CurrentValue", MapFromValue: "Disabled"}},
+					MapToAttribute: "PowerRegulator",
+					MapToProperty:  "GrayOut",
+					MapToValue:     "true",
+				},
+			},
+			{
+				DependencyFor: "SecureBoot",
+				Type:          "Map",
+				Dependency: infraiov1.BiosDependencyMap{
+					MapFrom:        []infraiov1.BiosDependencyCondition{{MapFromAttribute: "BootMode", MapFromCondition: "EQU", MapFromProperty: "CurrentValue", MapFromValue: "LegacyBios"}},
+					MapToAttribute: "SecureBoot",
+					MapToProperty:  "CurrentValue",
+					MapToValue:     "false",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		requested    map[string]string
+		current      map[string]string
+		wantAccepted map[string]interface{}
+		wantRejected []string
+		wantIgnored  []string
+	}{
+		{
+			name:         "valid attributes of every type",
+			requested:    map[string]string{"BootMode": "Uefi", "ServerName": "node-1", "AdminPassword": "secret123", "ThermalShutdownTime": "15", "SecureBoot": "true"},
+			current:      map[string]string{"BootMode": "Uefi"},
+			wantAccepted: map[string]interface{}{"BootMode": "Uefi", "ServerName": "node-1", "AdminPassword": "secret123", "ThermalShutdownTime": 15, "SecureBoot": true},
+		},
+		{
+			name:         "invalid values, unknown and read only attributes are rejected",
+			requested:    map[string]string{"BootMode": "Bios", "ServerName": "node_1", "AdminPassword": "short", "ThermalShutdownTime": "12", "SerialNumber": "X1", "Unknown": "1", "SecureBoot": "yes"},
+			wantAccepted: map[string]interface{}{},
+			wantRejected: []string{"AdminPassword", "BootMode", "SecureBoot", "SerialNumber", "ServerName", "ThermalShutdownTime", "Unknown"},
+		},
+		{
+			name:         "invalid value expression and enumeration values of the registry are rejected",
+			requested:    map[string]string{"AssetTag": "TAG1", "WorkloadProfile": "GeneralPowerEfficientCompute"},
+			wantAccepted: map[string]interface{}{},
+			wantRejected: []string{"AssetTag", "WorkloadProfile"},
+		},
+		{
+			name:         "attribute grayed out by the current value of another attribute is ignored",
+			requested:    map[string]string{"PowerRegulator": "StaticHighPerf"},
+			current:      map[string]string{"ProcTurbo": "Disabled"},
+			wantAccepted: map[string]interface{}{},
+			wantIgnored:  []string{"PowerRegulator"},
+		},
+		{
+			name:         "requested value of another attribute is used for dependencies",
+			requested:    map[string]string{"PowerRegulator": "StaticHighPerf", "ProcTurbo": "Enabled"},
+			current:      map[string]string{"ProcTurbo": "Disabled"},
+			wantAccepted: map[string]interface{}{"PowerRegulator": "StaticHighPerf", "ProcTurbo": "Enabled"},
+		},
+		{
+			name:         "value forced by a dependency is rejected when different",
+			requested:    map[string]string{"BootMode": "LegacyBios", "SecureBoot": "true"},
+			wantAccepted: map[string]interface{}{"BootMode": "LegacyBios"},
+			wantRejected: []string{"SecureBoot"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := validateAttributes(registry, tt.requested, tt.current)
+			if !reflect.DeepEqual(got.Attributes, tt.wantAccepted) {
+				t.Errorf("validateAttributes() accepted = %v, want %v", got.Attributes, tt.wantAccepted)
+			}
+			if rejected := getSortedKeys(got.Rejected); !reflect.DeepEqual(rejected, tt.wantRejected) {
+				t.Errorf("validateAttributes() rejected = %v, want %v", got.Rejected, tt.wantRejected)
+			}
+			if ignored := getSortedKeys(got.Ignored); !reflect.DeepEqual(ignored, tt.wantIgnored) {
+				t.Errorf("validateAttributes() ignored = %v, want %v", got.Ignored, tt.wantIgnored)
+			}
+		})
+	}
+}
+
+func TestConditionMet(t *testing.T) {
+	entries := map[string]map[string]string{"ProcCores": {"AttributeName": "ProcCores", "ReadOnly": "false"}}
+	values := map[string]string{"ProcCores": "8", "BootMode": "Uefi"}
+	tests := []struct {
+		name      string
+		condition infraiov1.BiosDependencyCondition
+		want      bool
+	}{
+		{"equal ignoring case", infraiov1.BiosDependencyCondition{MapFromAttribute: "BootMode", MapFromCondition: "EQU", MapFromValue: "uefi"}, true},
+		{"not equal", infraiov1.BiosDependencyCondition{MapFromAttribute: "BootMode", MapFromCondition: "NEQ", MapFromValue: "Uefi"}, false},
+		{"numeric greater", infraiov1.BiosDependencyCondition{MapFromAttribute: "ProcCores", MapFromCondition: "GTR", MapFromValue: "4"}, true},
+		{"numeric less or equal", infraiov1.BiosDependencyCondition{MapFromAttribute: "ProcCores", MapFromCondition: "LEQ", MapFromValue: "4"}, false},
+		{"registry property", infraiov1.BiosDependencyCondition{MapFromAttribute: "ProcCores", MapFromCondition: "EQU", MapFromProperty: "ReadOnly", MapFromValue: "false"}, true},
+		{"unknown attribute", infraiov1.BiosDependencyCondition{MapFromAttribute: "Unknown", MapFromCondition: "NEQ", MapFromValue: "1"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conditionMet(tt.condition, entries, values); got != tt.want {
+				t.Errorf("conditionMet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func getSortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
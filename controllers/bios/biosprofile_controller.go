@@ -0,0 +1,275 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+)
+
+// BiosProfileReconciler reconciles a BiosProfile object
+type BiosProfileReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+const (
+	profileCompliant    = "Compliant"
+	profilePending      = "Pending"
+	profileNonCompliant = "NonCompliant"
+	profileInvalid      = "Invalid"
+	profileConflict     = "Conflict"
+)
+
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=biosprofiles,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=biosprofiles/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=biosprofiles/finalizers,verbs=update
+
+// Reconcile applies the BIOS attributes of the profile to the BiosSetting of every selected BMC
+// and reports the compliance of each BMC in the profile status
+func (r *BiosProfileReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.BiosProfileActionID, constants.BiosProfileActionName, podName)
+	profileObj := &infraiov1.BiosProfile{}
+	err := r.Get(ctx, req.NamespacedName, profileObj)
+	if err != nil {
+		if Error.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	selector, err := getProfileSelector(profileObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Invalid bmc selector in %s bios profile: %s", profileObj.Name, err.Error())
+		return ctrl.Result{}, nil
+	}
+	bmcList := &infraiov1.BmcList{}
+	if profileObj.Spec.BmcSelector == nil {
+		// an empty label selector in the list request would select every BMC
+		l.LogWithFields(ctx).Infof("%s bios profile has no bmc selector, it is not applied to any BMC", profileObj.Name)
+	} else {
+		err = r.List(ctx, bmcList, client.InNamespace(req.Namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			l.LogWithFields(ctx).Error("Error fetching BMC objects: " + err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+	profileList := &infraiov1.BiosProfileList{}
+	err = r.List(ctx, profileList, client.InNamespace(req.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Error("Error fetching bios profiles: " + err.Error())
+		return ctrl.Result{}, err
+	}
+	commonRec := utils.GetCommonReconciler(r.Client, r.Scheme)
+	status := infraiov1.BiosProfileStatus{Bmcs: map[string]infraiov1.BiosProfileCompliance{}}
+	for i := range bmcList.Items {
+		bmcObj := &bmcList.Items[i]
+		if bmcObj.Status.BmcAddStatus != "yes" || bmcObj.Status.BmcSystemID == "" || bmcObj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		compliance := r.applyBiosProfile(ctx, commonRec, profileObj, bmcObj, profileList.Items)
+		if compliance.LastAppliedTime == nil {
+			compliance.LastAppliedTime = profileObj.Status.Bmcs[bmcObj.Name].LastAppliedTime
+		}
+		status.SelectedBmcs++
+		if compliance.Compliance == profileCompliant {
+			status.CompliantBmcs++
+		}
+		status.Bmcs[bmcObj.Name] = compliance
+	}
+	if !reflect.DeepEqual(profileObj.Status, status) {
+		profileObj.Status = status
+		err = r.Status().Update(ctx, profileObj)
+		if err != nil {
+			l.LogWithFields(ctx).Error(fmt.Sprintf("Error: Updating status of %s bios profile: %s", profileObj.Name, err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// applyBiosProfile adds the attributes of the profile which differ on the BMC to the spec of its BiosSetting,
+// the BiosSetting controller then validates and applies them
+func (r *BiosProfileReconciler) applyBiosProfile(ctx context.Context, commonRec utils.ReconcilerInterface, profileObj *infraiov1.BiosProfile, bmcObj *infraiov1.Bmc, profiles []infraiov1.BiosProfile) infraiov1.BiosProfileCompliance {
+	if conflicts := getConflictingAttributes(profileObj, bmcObj, profiles); len(conflicts) != 0 {
+		return infraiov1.BiosProfileCompliance{
+			Compliance: profileConflict,
+			Message:    "attributes are set to other values by " + strings.Join(conflicts, ", "),
+		}
+	}
+	biosObj := commonRec.GetBiosObject(ctx, constants.MetadataName, bmcObj.Name, bmcObj.Namespace)
+	if biosObj == nil {
+		return infraiov1.BiosProfileCompliance{Compliance: profilePending, Message: "BiosSetting of the BMC is not created yet"}
+	}
+	nonCompliant := getNonCompliantAttributes(profileObj.Spec.Bios, biosObj.Status.BiosAttributes)
+	if len(nonCompliant) == 0 {
+		return infraiov1.BiosProfileCompliance{Compliance: profileCompliant}
+	}
+	compliance := infraiov1.BiosProfileCompliance{Compliance: profileNonCompliant, NonCompliantAttributes: nonCompliant}
+	if err := validateProfileAttributes(ctx, commonRec, profileObj.Spec.Bios, biosObj.Status.BiosAttributes, bmcObj); err != nil {
+		compliance.Compliance = profileInvalid
+		compliance.Message = err.Error()
+		return compliance
+	}
+	if len(getNonCompliantAttributes(profileObj.Spec.Bios, biosObj.Spec.Bios)) == 0 {
+		compliance.Compliance = profilePending
+		compliance.Message = "attributes are applied, waiting for the BMC to be reset"
+		return compliance
+	}
+	if biosObj.Spec.Bios == nil {
+		biosObj.Spec.Bios = map[string]string{}
+	}
+	for attr, value := range profileObj.Spec.Bios {
+		biosObj.Spec.Bios[attr] = value
+	}
+	if biosObj.Spec.BmcName == "" && biosObj.Spec.SystemID == "" && biosObj.Spec.SerialNo == "" {
+		biosObj.Spec.BmcName = bmcObj.Spec.BmcDetails.Address
+	}
+	err := r.Update(ctx, biosObj)
+	if err != nil {
+		l.LogWithFields(ctx).Error(fmt.Sprintf("Error: Updating BiosSetting of %s BMC with %s bios profile: %s", bmcObj.Spec.BmcDetails.Address, profileObj.Name, err.Error()))
+		compliance.Message = "could not update BiosSetting of the BMC: " + err.Error()
+		return compliance
+	}
+	l.LogWithFields(ctx).Infof("Applied %s bios profile to %s BMC", profileObj.Name, bmcObj.Spec.BmcDetails.Address)
+	appliedTime := metav1.Now()
+	compliance.Compliance = profilePending
+	compliance.Message = "attributes are applied, waiting for the BMC to be reset"
+	compliance.LastAppliedTime = &appliedTime
+	return compliance
+}
+
+// validateProfileAttributes validates the attributes of a profile against the BiosSchemaRegistry of the BMC,
+// currentAttributes are the attributes of the BMC used to evaluate the dependencies of the registry
+func validateProfileAttributes(ctx context.Context, commonRec utils.ReconcilerInterface, attributes, currentAttributes map[string]string, bmcObj *infraiov1.Bmc) error {
+	biosID := bmcObj.Status.BiosAttributeRegistry
+	biosUtil := GetBiosUtils(ctx, &infraiov1.BiosSetting{Spec: infraiov1.BiosSettingSpec{Bios: attributes}}, commonRec, nil, bmcObj.Namespace)
+	validation, err := biosUtil.ValidateBiosAttributes(biosID, currentAttributes)
+	if err != nil {
+		return err
+	}
+	if !validation.IsValid() || len(validation.Ignored) != 0 {
+		return fmt.Errorf("attributes are not valid for BiosSchemaRegistry %s: %s", biosID, validation.String())
+	}
+	return nil
+}
+
+// getNonCompliantAttributes returns the sorted attributes whose value in actual differs from desired
+func getNonCompliantAttributes(desired, actual map[string]string) []string {
+	nonCompliant := []string{}
+	for attr, value := range desired {
+		if actualValue, ok := actual[attr]; !ok || actualValue != value {
+			nonCompliant = append(nonCompliant, attr)
+		}
+	}
+	sort.Strings(nonCompliant)
+	return nonCompliant
+}
+
+// getConflictingAttributes returns the other profiles selecting the BMC which set an attribute of the profile to another value
+func getConflictingAttributes(profileObj *infraiov1.BiosProfile, bmcObj *infraiov1.Bmc, profiles []infraiov1.BiosProfile) []string {
+	conflicts := []string{}
+	for i := range profiles {
+		other := &profiles[i]
+		if other.Name == profileObj.Name {
+			continue
+		}
+		selector, err := getProfileSelector(other)
+		if err != nil || !selector.Matches(labels.Set(bmcObj.GetLabels())) {
+			continue
+		}
+		for attr, value := range profileObj.Spec.Bios {
+			if otherValue, ok := other.Spec.Bios[attr]; ok && otherValue != value {
+				conflicts = append(conflicts, fmt.Sprintf("%s in %s bios profile", attr, other.Name))
+			}
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// getProfileSelector returns the selector of the BMCs the profile is applied to, a profile without
+// bmc selector selects no BMC so that a profile is never applied to every BMC by mistake
+func getProfileSelector(profileObj *infraiov1.BiosProfile) (labels.Selector, error) {
+	if profileObj.Spec.BmcSelector == nil {
+		return labels.Nothing(), nil
+	}
+	return metav1.LabelSelectorAsSelector(profileObj.Spec.BmcSelector)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BiosProfileReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infraiov1.BiosProfile{}, builder.WithPredicates(utils.IgnoreStatusUpdate())).
+		Watches(&source.Kind{Type: &infraiov1.Bmc{}}, handler.EnqueueRequestsFromMapFunc(r.getProfilesForObject), builder.WithPredicates(bmcProfileSelectionChanged())).
+		Watches(&source.Kind{Type: &infraiov1.BiosSetting{}}, handler.EnqueueRequestsFromMapFunc(r.getProfilesForObject)).
+		Watches(&source.Kind{Type: &infraiov1.BiosSchemaRegistry{}}, handler.EnqueueRequestsFromMapFunc(r.getProfilesForObject)).
+		Complete(r)
+}
+
+// getProfilesForObject returns the profiles of the object namespace, so that the compliance is updated
+// when BMCs are added or relabelled and when their BIOS attributes change
+func (r *BiosProfileReconciler) getProfilesForObject(obj client.Object) []reconcile.Request {
+	profileList := &infraiov1.BiosProfileList{}
+	err := r.List(context.TODO(), profileList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, profile := range profileList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: profile.Name, Namespace: profile.Namespace}})
+	}
+	return requests
+}
+
+// bmcProfileSelectionChanged filters the updates of Bmc objects which can change the BMCs selected by a profile
+func bmcProfileSelectionChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldBmc, oldOk := e.ObjectOld.(*infraiov1.Bmc)
+			newBmc, newOk := e.ObjectNew.(*infraiov1.Bmc)
+			if !oldOk || !newOk {
+				return true
+			}
+			return !reflect.DeepEqual(oldBmc.GetLabels(), newBmc.GetLabels()) ||
+				utils.IsDeletionTimestampChanged(oldBmc, newBmc) ||
+				oldBmc.Status.BmcAddStatus != newBmc.Status.BmcAddStatus ||
+				oldBmc.Status.BiosAttributeRegistry != newBmc.Status.BiosAttributeRegistry
+		},
+	}
+}
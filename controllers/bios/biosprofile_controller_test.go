@@ -0,0 +1,115 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetNonCompliantAttributes(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string]string
+		actual  map[string]string
+		want    []string
+	}{
+		{
+			name:    "all attributes compliant",
+			desired: map[string]string{"BootMode": "Uefi"},
+			actual:  map[string]string{"BootMode": "Uefi", "ProcVirtualization": "Disabled"},
+			want:    []string{},
+		},
+		{
+			name:    "attributes differing or missing",
+			desired: map[string]string{"BootMode": "Uefi", "ProcVirtualization": "Enabled", "WorkloadProfile": "Virtualization-MaxPerformance"},
+			actual:  map[string]string{"BootMode": "Uefi", "ProcVirtualization": "Disabled"},
+			want:    []string{"ProcVirtualization", "WorkloadProfile"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getNonCompliantAttributes(tt.desired, tt.actual); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getNonCompliantAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetConflictingAttributes(t *testing.T) {
+	bmcObj := &infraiov1.Bmc{ObjectMeta: metav1.ObjectMeta{Name: "bmc1", Labels: map[string]string{"role": "hypervisor"}}}
+	profile := func(name string, selector map[string]string, bios map[string]string) infraiov1.BiosProfile {
+		return infraiov1.BiosProfile{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: infraiov1.BiosProfileSpec{
+				Bios:        bios,
+				BmcSelector: &metav1.LabelSelector{MatchLabels: selector},
+			},
+		}
+	}
+	virtualization := profile("virtualization-host", map[string]string{"role": "hypervisor"}, map[string]string{"ProcVirtualization": "Enabled"})
+	withoutSelector := infraiov1.BiosProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "without-selector"},
+		Spec:       infraiov1.BiosProfileSpec{Bios: map[string]string{"ProcVirtualization": "Disabled"}},
+	}
+	tests := []struct {
+		name     string
+		profiles []infraiov1.BiosProfile
+		want     []string
+	}{
+		{
+			name: "other profile sets the same value",
+			profiles: []infraiov1.BiosProfile{
+				virtualization,
+				profile("base", map[string]string{"role": "hypervisor"}, map[string]string{"ProcVirtualization": "Enabled", "BootMode": "Uefi"}),
+			},
+			want: []string{},
+		},
+		{
+			name: "other profile not selecting the bmc",
+			profiles: []infraiov1.BiosProfile{
+				virtualization,
+				profile("low-latency", map[string]string{"role": "trading"}, map[string]string{"ProcVirtualization": "Disabled"}),
+			},
+			want: []string{},
+		},
+		{
+			name: "other profile without bmc selector",
+			profiles: []infraiov1.BiosProfile{
+				virtualization,
+				withoutSelector,
+			},
+			want: []string{},
+		},
+		{
+			name: "other profile sets another value",
+			profiles: []infraiov1.BiosProfile{
+				virtualization,
+				profile("low-latency", map[string]string{"role": "hypervisor"}, map[string]string{"ProcVirtualization": "Disabled"}),
+			},
+			want: []string{"ProcVirtualization in low-latency bios profile"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getConflictingAttributes(&virtualization, bmcObj, tt.profiles); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getConflictingAttributes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
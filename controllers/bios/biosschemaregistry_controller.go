@@ -16,15 +16,34 @@ package controllers
 
 import (
 	"context"
+	"reflect"
+	"sort"
+	"time"
 
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infraioodimrav1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
 )
 
+// unusedRegistryGracePeriod is how long a registry is kept once no BMC uses it, registries are created
+// while a BMC is added or its firmware is updated, before the BMC status refers to them
+const unusedRegistryGracePeriod = time.Hour
+
 // BiosSchemaRegistryReconciler reconciles a BiosSchemaRegistry object
 type BiosSchemaRegistryReconciler struct {
 	client.Client
@@ -35,26 +54,148 @@ type BiosSchemaRegistryReconciler struct {
 //+kubebuilder:rbac:groups=infra.io.odimra,resources=biosschemaregistries/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infra.io.odimra,resources=biosschemaregistries/finalizers,verbs=update
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-// TODO(user): Modify the Reconcile function to compare the state specified by
-// the BiosSchemaRegistry object against the actual cluster state, and then
-// perform operations to make the cluster state reflect the state specified by
-// the user.
-//
-// For more details, check Reconcile and its Result here:
-// - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.0/pkg/reconcile
+// Reconcile records the BMCs and BIOS snapshots using the registry in its status and deletes the registry
+// once nothing has used it for the grace period
 func (r *BiosSchemaRegistryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
-	_ = log.FromContext(ctx)
-
-	// TODO(user): your logic here
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.BiosSchemaRegistryActionID, constants.BiosSchemaRegistryActionName, podName)
+	registryObj := &infraioodimrav1.BiosSchemaRegistry{}
+	err := r.Get(ctx, req.NamespacedName, registryObj)
+	if err != nil {
+		if Error.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	bmcList := &infraioodimrav1.BmcList{}
+	err = r.List(ctx, bmcList, client.InNamespace(req.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error fetching BMC objects: %s", err.Error())
+		return ctrl.Result{}, err
+	}
+	snapshotList := &infraioodimrav1.BiosSnapshotList{}
+	err = r.List(ctx, snapshotList, client.InNamespace(req.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error fetching BiosSnapshot objects: %s", err.Error())
+		return ctrl.Result{}, err
+	}
+	biosList := &infraioodimrav1.BiosSettingList{}
+	err = r.List(ctx, biosList, client.InNamespace(req.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error fetching BiosSetting objects: %s", err.Error())
+		return ctrl.Result{}, err
+	}
+	usedBy := getRegistryUsers(registryObj.Name, bmcList.Items, snapshotList.Items, biosList.Items)
+	status := infraioodimrav1.BiosSchemaRegistryStatus{}
+	var requeueAfter time.Duration
+	if len(usedBy) != 0 {
+		status.UsedBy = usedBy
+	} else {
+		unusedSince := metav1.Now()
+		if registryObj.Status.UnusedSince != nil {
+			unusedSince = *registryObj.Status.UnusedSince
+		}
+		requeueAfter = time.Until(unusedSince.Add(unusedRegistryGracePeriod))
+		if requeueAfter <= 0 {
+			l.LogWithFields(ctx).Infof("Deleting %s BiosSchemaRegistry, it is not used by any BMC or BiosSnapshot since %s", registryObj.Name, unusedSince.Format(time.RFC3339))
+			err = r.Delete(ctx, registryObj)
+			if err != nil && !Error.IsNotFound(err) {
+				l.LogWithFields(ctx).Errorf("Error: Deleting %s BiosSchemaRegistry: %s", registryObj.Name, err.Error())
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		}
+		status.UnusedSince = &unusedSince
+	}
+	if !reflect.DeepEqual(registryObj.Status, status) {
+		registryObj.Status = status
+		err = r.Status().Update(ctx, registryObj)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Updating status of %s BiosSchemaRegistry: %s", registryObj.Name, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
 
-	return ctrl.Result{}, nil
+// getRegistryUsers returns the Bmc objects whose BIOS attribute registry is the registry, followed by the
+// BiosSnapshot objects captured with the registry and the BiosSetting objects still importing such a snapshot,
+// snapshots need the registry to translate their attributes to the registry of the BMC they are imported on
+func getRegistryUsers(registryName string, bmcs []infraioodimrav1.Bmc, snapshots []infraioodimrav1.BiosSnapshot, biosSettings []infraioodimrav1.BiosSetting) []string {
+	usedBy := []string{}
+	for i := range bmcs {
+		if bmcs[i].GetDeletionTimestamp() == nil && utils.IsBiosSchemaRegistryOfBmc(registryName, &bmcs[i]) {
+			usedBy = append(usedBy, bmcs[i].Name)
+		}
+	}
+	sort.Strings(usedBy)
+	snapshotUsers := []string{}
+	usingSnapshots := map[string]bool{}
+	for i := range snapshots {
+		if snapshots[i].GetDeletionTimestamp() == nil && utils.IsBiosSchemaRegistryOfSnapshot(registryName, &snapshots[i]) {
+			snapshotUsers = append(snapshotUsers, "biossnapshot/"+snapshots[i].Name)
+			usingSnapshots[snapshots[i].Name] = true
+		}
+	}
+	for i := range biosSettings {
+		if biosSettings[i].Spec.SnapshotName != "" && usingSnapshots[biosSettings[i].Spec.SnapshotName] {
+			snapshotUsers = append(snapshotUsers, "biossetting/"+biosSettings[i].Name)
+		}
+	}
+	sort.Strings(snapshotUsers)
+	return append(usedBy, snapshotUsers...)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BiosSchemaRegistryReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&infraioodimrav1.BiosSchemaRegistry{}).
+		For(&infraioodimrav1.BiosSchemaRegistry{}, builder.WithPredicates(utils.IgnoreStatusUpdate())).
+		Watches(&source.Kind{Type: &infraioodimrav1.Bmc{}}, handler.EnqueueRequestsFromMapFunc(r.getRegistriesOfNamespace), builder.WithPredicates(bmcRegistryChanged())).
+		Watches(&source.Kind{Type: &infraioodimrav1.BiosSnapshot{}}, handler.EnqueueRequestsFromMapFunc(r.getRegistriesOfNamespace), builder.WithPredicates(snapshotRegistryChanged())).
 		Complete(r)
 }
+
+// getRegistriesOfNamespace returns the registries of the object namespace, so that the registry a BMC or
+// a snapshot stops using is reconciled too
+func (r *BiosSchemaRegistryReconciler) getRegistriesOfNamespace(obj client.Object) []reconcile.Request {
+	registryList := &infraioodimrav1.BiosSchemaRegistryList{}
+	err := r.List(context.TODO(), registryList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, registry := range registryList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: registry.Name, Namespace: registry.Namespace}})
+	}
+	return requests
+}
+
+// bmcRegistryChanged filters the updates of Bmc objects which do not change their BIOS attribute registry
+func bmcRegistryChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldBmc, oldOk := e.ObjectOld.(*infraioodimrav1.Bmc)
+			newBmc, newOk := e.ObjectNew.(*infraioodimrav1.Bmc)
+			if !oldOk || !newOk {
+				return true
+			}
+			return oldBmc.Status.BiosAttributeRegistry != newBmc.Status.BiosAttributeRegistry ||
+				utils.IsDeletionTimestampChanged(oldBmc, newBmc)
+		},
+	}
+}
+
+// snapshotRegistryChanged filters the updates of BiosSnapshot objects which do not change their BIOS attribute registry
+func snapshotRegistryChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSnapshot, oldOk := e.ObjectOld.(*infraioodimrav1.BiosSnapshot)
+			newSnapshot, newOk := e.ObjectNew.(*infraioodimrav1.BiosSnapshot)
+			if !oldOk || !newOk {
+				return true
+			}
+			return oldSnapshot.Spec.Registry != newSnapshot.Spec.Registry ||
+				utils.IsDeletionTimestampChanged(oldSnapshot, newSnapshot)
+		},
+	}
+}
@@ -0,0 +1,67 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetRegistryUsers(t *testing.T) {
+	bmcs := []infraiov1.Bmc{
+		{ObjectMeta: metav1.ObjectMeta{Name: "bmc2"}, Status: infraiov1.BmcStatus{BiosAttributeRegistry: "BiosAttributeRegistryU30.v1_0_0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bmc1"}, Status: infraiov1.BmcStatus{BiosAttributeRegistry: "BiosAttributeRegistryU30.v1_0_0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bmc3"}, Status: infraiov1.BmcStatus{BiosAttributeRegistry: "BiosAttributeRegistryU32.v1_2_0"}},
+	}
+	snapshots := []infraiov1.BiosSnapshot{
+		{ObjectMeta: metav1.ObjectMeta{Name: "golden"}, Spec: infraiov1.BiosSnapshotSpec{Registry: "BiosAttributeRegistryU32.v1_2_0"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "capturing"}},
+	}
+	biosSettings := []infraiov1.BiosSetting{
+		{ObjectMeta: metav1.ObjectMeta{Name: "bmc1"}, Spec: infraiov1.BiosSettingSpec{SnapshotName: "golden"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "bmc2"}, Spec: infraiov1.BiosSettingSpec{SnapshotName: "capturing"}},
+	}
+	tests := []struct {
+		name     string
+		registry string
+		want     []string
+	}{
+		{
+			name:     "registry used by BMCs",
+			registry: "biosattributeregistryu30v100",
+			want:     []string{"bmc1", "bmc2"},
+		},
+		{
+			name:     "registry used by a BMC and a snapshot being imported",
+			registry: "biosattributeregistryu32v120",
+			want:     []string{"bmc3", "biossetting/bmc1", "biossnapshot/golden"},
+		},
+		{
+			name:     "unused registry",
+			registry: "biosattributeregistryu46v100",
+			want:     []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getRegistryUsers(tt.registry, bmcs, snapshots, biosSettings); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getRegistryUsers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -20,8 +20,9 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"strconv"
+	"reflect"
 	"strings"
+	"time"
 
 	Error "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -47,6 +48,9 @@ var (
 	biosSchemaObject *infraiov1.BiosSchemaRegistry
 )
 
+// biosApplyRetryInterval is the interval to apply the attributes again after a failed attempt
+const biosApplyRetryInterval = 5 * time.Minute
+
 // AttributeEnumValue struct defines list of attribute values
 type AttributeEnumValue struct {
 	ValueDisplayName string `json:"ValueDisplayName"`
@@ -90,7 +94,7 @@ func (r *BiosSettingReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 	// check to skip reconcilation from running while bisosetting object is created when bmc is added
-	if len(biosObj.Spec.Bios) == 0 {
+	if len(biosObj.Spec.Bios) == 0 && !biosObj.Spec.ResetBios && len(biosObj.Spec.PasswordChanges) == 0 && biosObj.Spec.SnapshotName == "" {
 		return ctrl.Result{}, nil
 	}
 	biosUtil := GetBiosUtils(ctx, biosObj, commonRec, biosRestClient, req.Namespace)
@@ -125,16 +129,69 @@ func (r *BiosSettingReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		if systemID == "" {
 			return ctrl.Result{}, nil
 		}
+		// the attributes of the snapshot are added to the spec, the update of the spec applies them
+		if biosObj.Spec.SnapshotName != "" {
+			if !r.importBiosSnapshot(ctx, biosObj, bmcObject, commonRec) {
+				return ctrl.Result{RequeueAfter: snapshotRetryInterval}, nil
+			}
+			return ctrl.Result{}, nil
+		}
+		// the reset to defaults and the password changes are requested before the attributes are applied again
+		if biosObj.Spec.ResetBios || len(biosObj.Spec.PasswordChanges) != 0 {
+			if deferred, deferredUntil := utils.GetMaintenanceWindowDeferral(ctx, r.Client, bmcObject); deferred {
+				if message := utils.GetDeferredMessage(deferredUntil); biosObj.Status.Deferred != message {
+					biosObj.Status.Deferred = message
+					err = r.Status().Update(ctx, biosObj)
+					if err != nil {
+						l.LogWithFields(ctx).Errorf("Error: Updating status of %s BiosSetting: %s", biosObj.ObjectMeta.Name, err.Error())
+					}
+				}
+				l.LogWithFields(ctx).Infof("Bios actions on %s BMC are deferred until %s", biosBmcIP, deferredUntil.Format(time.RFC3339))
+				return ctrl.Result{RequeueAfter: time.Until(deferredUntil)}, nil
+			}
+			biosObj.Status.Deferred = ""
+			r.requestBiosActions(ctx, biosObj, bmcObject, biosUtil)
+			return ctrl.Result{}, nil
+		}
 		systemURI := fmt.Sprintf("/redfish/v1/Systems/%s", systemID)
 		systemsGetResp, _, err := biosRestClient.Get(systemURI, "Getting response on systems")
 		if err != nil {
 			l.LogWithFields(ctx).Errorf("Error on GET: %s, try again : %s", systemURI, err.Error())
 			return ctrl.Result{}, nil
 		}
-		ok, body := biosUtil.ValidateBiosAttributes(biosID)
-		if !ok {
+		validation, err := biosUtil.ValidateBiosAttributes(biosID, biosObj.Status.BiosAttributes)
+		if err != nil {
+			return ctrl.Result{}, nil
+		}
+		// only the attributes whose actual value differs from the spec are applied
+		_, outOfSync := utils.CompareMaps(biosObj.Spec.Bios, biosObj.Status.BiosAttributes)
+		body := map[string]interface{}{}
+		for attr, value := range validation.Attributes {
+			if _, ok := outOfSync[attr]; ok {
+				body[attr] = value
+			}
+		}
+		var deferred bool
+		var deferredUntil time.Time
+		var deferredMessage string
+		if validation.IsValid() && len(body) != 0 {
+			deferred, deferredUntil = utils.GetMaintenanceWindowDeferral(ctx, r.Client, bmcObject)
+			if deferred {
+				deferredMessage = utils.GetDeferredMessage(deferredUntil)
+			}
+		}
+		r.updateSettingStatus(ctx, biosObj, validation, deferredMessage)
+		if !validation.IsValid() {
 			return ctrl.Result{}, nil
 		}
+		if len(body) == 0 {
+			l.LogWithFields(ctx).Infof("Bios attributes of %s BMC are in sync with BiosSetting", biosBmcIP)
+			return ctrl.Result{}, nil
+		}
+		if deferred {
+			l.LogWithFields(ctx).Infof("Applying bios settings on %s BMC is deferred until %s", biosBmcIP, deferredUntil.Format(time.RFC3339))
+			return ctrl.Result{RequeueAfter: time.Until(deferredUntil)}, nil
+		}
 		if systemsGetResp["Bios"] != nil {
 			//get bios url
 			biosLink, biosBody := biosUtil.getBiosLinkAndBody(systemsGetResp, body, biosBmcIP)
@@ -143,30 +200,28 @@ func (r *BiosSettingReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				patchResponse, err := biosRestClient.Patch(biosLink, fmt.Sprintf("Patching bios payload for %s BMC", biosBmcIP), biosBody)
 				if err != nil {
 					l.LogWithFields(ctx).Error(fmt.Sprintf("error while patching bios for %s BMC: ", bmcObject.Spec.BmcDetails.Address), err.Error())
-					return ctrl.Result{}, nil
+					r.setApplyError(ctx, biosObj, "could not patch bios settings: "+err.Error())
+					return ctrl.Result{RequeueAfter: biosApplyRetryInterval}, nil
 				}
-				if patchResponse.StatusCode == http.StatusAccepted {
-					//add taskmon here
-					done, _ := biosUtil.(*biosUtils).commonUtil.MoniteringTaskmon(patchResponse.Header, ctx, common.BIOSSETTING, bmcObject.ObjectMeta.Name)
-					if done {
-						l.LogWithFields(ctx).Info("Bios configured, Please reset system now.")
-						commonRec.UpdateBmcObjectOnReset(ctx, bmcObject, fmt.Sprintf("%s Bios", constants.PendingForResetEvent))
-						return ctrl.Result{}, nil
-					} else {
-						err = commonRec.GetCommonReconcilerClient().Delete(ctx, biosObj)
-						if err != nil {
-							l.LogWithFields(ctx).Error(fmt.Sprintf("error while deleteing bios setting object for %s BMC:", biosBmcIP), err.Error())
-						}
-					}
-				} else {
+				if patchResponse.StatusCode != http.StatusAccepted {
 					l.LogWithFields(ctx).Info(fmt.Sprintf("Could not update bios settings for %s BMC, try again!", bmcObject.Spec.BmcDetails.Address))
-					err = r.Delete(ctx, biosObj)
-					if err != nil {
-						l.LogWithFields(ctx).Error("error while deleting bios object" + err.Error())
-					}
-					return ctrl.Result{}, nil
+					r.setApplyError(ctx, biosObj, fmt.Sprintf("patching bios settings failed with status %d", patchResponse.StatusCode))
+					return ctrl.Result{RequeueAfter: biosApplyRetryInterval}, nil
+				}
+				//add taskmon here
+				done, _ := biosUtil.(*biosUtils).commonUtil.MoniteringTaskmon(patchResponse.Header, ctx, common.BIOSSETTING, bmcObject.ObjectMeta.Name)
+				if !done {
+					l.LogWithFields(ctx).Errorf("Error in patching, bios not configured properly for %s BMC, try again", biosBmcIP)
+					r.setApplyError(ctx, biosObj, "task patching bios settings did not complete")
+					return ctrl.Result{RequeueAfter: biosApplyRetryInterval}, nil
 				}
-				l.LogWithFields(ctx).Errorf("Error in patching, bios not configured properly for %s BMC, try again : %s", biosBmcIP, err.Error())
+				l.LogWithFields(ctx).Info("Bios configured, Please reset system now.")
+				r.setApplyError(ctx, biosObj, "")
+				err = utils.RecordPendingReset(ctx, r.Client, bmcObject, constants.PendingResetBios, biosObj.ObjectMeta.Name, GetExpectedAttributes(validation, body), fmt.Sprintf("%s Bios", constants.PendingForResetEvent))
+				if err != nil {
+					l.LogWithFields(ctx).Errorf("Error: Recording pending bios settings of %s BMC: %s", bmcObject.Spec.BmcDetails.Address, err.Error())
+				}
+				return ctrl.Result{}, nil
 			} else {
 				l.LogWithFields(ctx).Info("Unable to configure bios settings, Please try again.")
 			}
@@ -179,6 +234,55 @@ func (r *BiosSettingReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	return ctrl.Result{}, nil
 }
 
+// GetExpectedAttributes returns the applied attributes expected on the BMC after the reset, sensitive attributes are left out
+func GetExpectedAttributes(validation BiosAttributeValidation, applied map[string]interface{}) map[string]string {
+	expected := map[string]string{}
+	for attr, value := range applied {
+		if !validation.Sensitive[attr] {
+			expected[attr] = fmt.Sprint(value)
+		}
+	}
+	return expected
+}
+
+// updateSettingStatus records the rejected and ignored attributes of the last validation, whether the
+// attributes of the spec are in sync and whether applying them is deferred in the status
+func (r *BiosSettingReconciler) updateSettingStatus(ctx context.Context, biosObj *infraiov1.BiosSetting, validation BiosAttributeValidation, deferred string) {
+	rejected, ignored := validation.Rejected, validation.Ignored
+	if len(rejected) == 0 {
+		rejected = nil
+	}
+	if len(ignored) == 0 {
+		ignored = nil
+	}
+	inSync := utils.GetAttributesInSync(biosObj.Spec.Bios, biosObj.Status.BiosAttributes)
+	if reflect.DeepEqual(biosObj.Status.RejectedAttributes, rejected) && reflect.DeepEqual(biosObj.Status.IgnoredAttributes, ignored) &&
+		reflect.DeepEqual(biosObj.Status.AttributesInSync, inSync) && biosObj.Status.Deferred == deferred {
+		return
+	}
+	biosObj.Status.Deferred = deferred
+	biosObj.Status.RejectedAttributes = rejected
+	biosObj.Status.IgnoredAttributes = ignored
+	biosObj.Status.AttributesInSync = inSync
+	err := r.Status().Update(ctx, biosObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Updating status of %s BiosSetting: %s", biosObj.ObjectMeta.Name, err.Error())
+	}
+}
+
+// setApplyError records in the status why the attributes could not be applied, the spec is kept
+// as the desired attributes and applied again. An empty message clears the error
+func (r *BiosSettingReconciler) setApplyError(ctx context.Context, biosObj *infraiov1.BiosSetting, message string) {
+	if biosObj.Status.ApplyError == message {
+		return
+	}
+	biosObj.Status.ApplyError = message
+	err := r.Status().Update(ctx, biosObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Updating status of %s BiosSetting: %s", biosObj.ObjectMeta.Name, err.Error())
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *BiosSettingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -187,67 +291,6 @@ func (r *BiosSettingReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
-// ValidateBiosAttributes is used to validate the input data for bios attributes.
-func (bs *biosUtils) ValidateBiosAttributes(biosID string) (bool, map[string]interface{}) {
-	l.LogWithFields(bs.ctx).Info("Validating BIOS Attributes..")
-	schemaName := utils.RemoveSpecialChar(biosID)
-	biosSchemaObject = bs.commonRec.GetBiosSchemaObject(bs.ctx, constants.MetadataName, schemaName, bs.namespace)
-	resBody := make(map[string]interface{})
-	if biosSchemaObject.Spec.Attributes == nil {
-		l.LogWithFields(bs.ctx).Errorf("No object with schema name %s present!", schemaName)
-		return false, nil
-	}
-	attributeColl := biosSchemaObject.Spec.Attributes
-	for attr, value := range bs.biosObj.Spec.Bios {
-		for _, val := range attributeColl {
-			if val["AttributeName"] == attr {
-				if val["ReadOnly"] == "true" {
-					continue
-				}
-				if val["Type"] == "String" {
-					i, _ := strconv.Atoi(val["MaxLength"])
-					if len(value) > i {
-						l.LogWithFields(bs.ctx).Error(fmt.Sprintf("Attribute %s max length size exceeded, required is %s", attr, val["MaxLength"]))
-						return false, nil
-					}
-					resBody[attr] = value
-				}
-				if val["Type"] == "Integer" {
-					i, err := strconv.Atoi(value)
-					if err != nil {
-						l.LogWithFields(bs.ctx).Error(fmt.Sprintf("Attribute %s should be of type Integer %s: %s", attr, value, err.Error()))
-						return false, nil
-					}
-					lowerBound, _ := strconv.Atoi(val["LowerBound"])
-					upperBound, _ := strconv.Atoi(val["UpperBound"])
-					if i < lowerBound || i > upperBound {
-						l.LogWithFields(bs.ctx).Error(fmt.Sprintf("Attribute %s should be within the bound limit", attr))
-						return false, nil
-					}
-					resBody[attr] = i
-				}
-				if val["Type"] == "Enumeration" {
-					var mapData []AttributeEnumValue
-					var isPresent bool
-					res := val["Value"]
-					json.Unmarshal([]byte(res), &mapData)
-					for _, mapValue := range mapData {
-						if value == mapValue.ValueName {
-							isPresent = true
-						}
-					}
-					if !isPresent {
-						l.LogWithFields(bs.ctx).Errorf("Invalid attribute value %s", value)
-						return false, nil
-					}
-					resBody[attr] = value
-				}
-			}
-		}
-	}
-	return true, resBody
-}
-
 // getBiosLinkAndBody fetches the bios uri and builds the bios body
 func (bs *biosUtils) getBiosLinkAndBody(resp map[string]interface{}, biosProps map[string]interface{}, biosBmcIP string) (string, []byte) {
 	biosId := resp["Bios"].(map[string]interface{})["@odata.id"].(string)
@@ -271,21 +314,16 @@ func (bs *biosUtils) getBiosLinkAndBody(resp map[string]interface{}, biosProps m
 	return biosLink, biosBody
 }
 
-// updateBiosAttributesOnReset updates the bios attributes with latest bios values
+// updateBiosAttributesOnReset updates the bios attributes with latest bios values, the spec is kept as the desired attributes
 func (bs *biosUtils) UpdateBiosAttributesOnReset(biosBmcIP string, updatedBiosAttributes map[string]string) {
-	// biosObj := bs.commonRec.GetBiosObject(common.MetadataName, bmcName, namespace)
-	bs.biosObj.Spec.Bios = map[string]string{}
-	err := bs.commonRec.(*utils.CommonReconciler).Client.Update(bs.ctx, bs.biosObj)
-	if err != nil {
-		l.LogWithFields(bs.ctx).Errorf("Error: Updating Spec of Bios Setting of %s: %s", biosBmcIP, err.Error())
-	}
-
 	biosObj := bs.commonRec.GetBiosObject(bs.ctx, constants.MetadataName, bs.biosObj.ObjectMeta.Name, bs.namespace)
-	bs.biosObj = biosObj
-	bs.biosObj.Status = infraiov1.BiosSettingStatus{
-		BiosAttributes: updatedBiosAttributes,
+	if biosObj == nil {
+		return
 	}
-	err = bs.commonRec.(*utils.CommonReconciler).Client.Status().Update(bs.ctx, bs.biosObj)
+	bs.biosObj = biosObj
+	bs.biosObj.Status.BiosAttributes = updatedBiosAttributes
+	bs.biosObj.Status.AttributesInSync = utils.GetAttributesInSync(bs.biosObj.Spec.Bios, updatedBiosAttributes)
+	err := bs.commonRec.(*utils.CommonReconciler).Client.Status().Update(bs.ctx, bs.biosObj)
 	if err != nil {
 		l.LogWithFields(bs.ctx).Errorf("Error: Updating Status of Bios Setting of %s: %s", biosBmcIP, err.Error())
 	}
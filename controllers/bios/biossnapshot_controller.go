@@ -0,0 +1,125 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+)
+
+// snapshotRetryInterval is how long a capture or an import of a snapshot waits for the BMC or its registry
+const snapshotRetryInterval = time.Minute
+
+// BiosSnapshotReconciler reconciles a BiosSnapshot object
+type BiosSnapshotReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=biossnapshots,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=biossnapshots/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=biossnapshots/finalizers,verbs=update
+
+// Reconcile captures the BIOS attributes of the source BMC into the spec of the snapshot,
+// a snapshot whose attributes are already set is left as it is
+func (r *BiosSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.BiosSnapshotActionID, constants.BiosSnapshotActionName, podName)
+	snapshotObj := &infraiov1.BiosSnapshot{}
+	err := r.Get(ctx, req.NamespacedName, snapshotObj)
+	if err != nil {
+		if Error.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if len(snapshotObj.Spec.Bios) != 0 || snapshotObj.Spec.SourceBmc == "" {
+		return ctrl.Result{}, nil
+	}
+	commonRec := utils.GetCommonReconciler(r.Client, r.Scheme)
+	bmcObj := commonRec.GetBmcObject(ctx, constants.MetadataName, snapshotObj.Spec.SourceBmc, req.Namespace)
+	if bmcObj == nil || bmcObj.Status.BmcAddStatus != "yes" || bmcObj.Status.BmcSystemID == "" {
+		r.setSnapshotMessage(ctx, snapshotObj, fmt.Sprintf("waiting for %s BMC to be added", snapshotObj.Spec.SourceBmc))
+		return ctrl.Result{RequeueAfter: snapshotRetryInterval}, nil
+	}
+	registryObj := commonRec.GetBiosSchemaObject(ctx, constants.MetadataName, utils.RemoveSpecialChar(bmcObj.Status.BiosAttributeRegistry), req.Namespace)
+	if registryObj == nil {
+		r.setSnapshotMessage(ctx, snapshotObj, fmt.Sprintf("waiting for the BiosSchemaRegistry of %s BMC", snapshotObj.Spec.SourceBmc))
+		return ctrl.Result{RequeueAfter: snapshotRetryInterval}, nil
+	}
+	odimObj := commonRec.GetOdimObject(ctx, constants.MetadataName, "odim", req.Namespace)
+	biosRestClient, err := restclient.NewRestClient(ctx, odimObj, commonRec.(*utils.CommonReconciler), constants.BMCOPERATOR)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Failed to get rest client for Bios: %s", err.Error())
+		return ctrl.Result{}, err
+	}
+	biosUtil := GetBiosUtils(ctx, nil, commonRec, biosRestClient, req.Namespace)
+	attributes := biosUtil.GetBiosAttributes(bmcObj)
+	if attributes == nil {
+		r.setSnapshotMessage(ctx, snapshotObj, fmt.Sprintf("could not fetch the BIOS attributes of %s BMC", snapshotObj.Spec.SourceBmc))
+		return ctrl.Result{RequeueAfter: snapshotRetryInterval}, nil
+	}
+	snapshotObj.Spec.Registry = registryObj.Spec.ID
+	snapshotObj.Spec.RegistryVersion = registryObj.Spec.RegistryVersion
+	snapshotObj.Spec.Bios = getSnapshotAttributes(&registryObj.Spec, attributes, snapshotObj.Spec.ExcludedAttributes)
+	err = r.Update(ctx, snapshotObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Capturing bios attributes into %s bios snapshot: %s", snapshotObj.Name, err.Error())
+		return ctrl.Result{}, err
+	}
+	captureTime := metav1.Now()
+	snapshotObj.Status.CaptureTime = &captureTime
+	snapshotObj.Status.Message = fmt.Sprintf("captured %d attributes of BiosSchemaRegistry %s", len(snapshotObj.Spec.Bios), registryObj.Spec.ID)
+	err = r.Status().Update(ctx, snapshotObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Updating status of %s bios snapshot: %s", snapshotObj.Name, err.Error())
+	}
+	l.LogWithFields(ctx).Infof("Captured bios attributes of %s BMC into %s bios snapshot", bmcObj.Spec.BmcDetails.Address, snapshotObj.Name)
+	return ctrl.Result{}, nil
+}
+
+// setSnapshotMessage updates the status of the snapshot with the message
+func (r *BiosSnapshotReconciler) setSnapshotMessage(ctx context.Context, snapshotObj *infraiov1.BiosSnapshot, message string) {
+	if snapshotObj.Status.Message == message {
+		return
+	}
+	snapshotObj.Status.Message = message
+	err := r.Status().Update(ctx, snapshotObj)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error: Updating status of %s bios snapshot: %s", snapshotObj.Name, err.Error())
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BiosSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infraiov1.BiosSnapshot{}, builder.WithPredicates(utils.IgnoreStatusUpdate())).
+		Complete(r)
+}
@@ -21,6 +21,7 @@ import (
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
 	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	credentials "github.com/ODIM-Project/BMCOperator/controllers/credentials"
 	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -77,6 +78,9 @@ type BmcInterface interface {
 	updateOdimWithNewPassword() (bool, error)
 	updateBmcWithNewPassword() (bool, error)
 	encryptPassword(publicKey *rsa.PublicKey)
+	syncCredentialsFromProvider(provider credentials.CredentialProvider) (bool, error)
+	RotatePassword(newPassword string) error
+	UpdateOdimPassword(password string) error
 	GetConnectionMethod(odimObj *infraiov1.Odim) string
 	UpdateBmcObject(bmcObj *infraiov1.Bmc)
 	deleteBMCObject()
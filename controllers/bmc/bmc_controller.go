@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/rsa"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -32,8 +33,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
 	"github.com/ODIM-Project/BMCOperator/config/constants"
@@ -43,6 +50,7 @@ import (
 
 	common "github.com/ODIM-Project/BMCOperator/controllers/common"
 	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	credentials "github.com/ODIM-Project/BMCOperator/controllers/credentials"
 	eventsubscription "github.com/ODIM-Project/BMCOperator/controllers/eventsubscription"
 	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
@@ -58,9 +66,19 @@ type BmcReconciler struct {
 
 var podName = os.Getenv("POD_NAME")
 
+const (
+	// credentialRefIndex indexes the bmc objects by the secret or vault path their credentials are read from,
+	// the index is added in main
+	credentialRefIndex = "spec.credentials.credentialRef"
+	// credentialSyncInterval is the interval at which credentials are read again from vault,
+	// and at which a password change which failed to apply is retried
+	credentialSyncInterval = 5 * time.Minute
+)
+
 //+kubebuilder:rbac:groups=infra.io.odimra,resources=bmcs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=infra.io.odimra,resources=bmcs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infra.io.odimra,resources=bmcs/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -74,7 +92,7 @@ var podName = os.Getenv("POD_NAME")
 func (r *BmcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	transactionId := uuid.New()
 	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.BMCSettingActionID, constants.BMCSettingActionName, podName)
-	var updateBMCObject bool
+	var updateBMCObject, passwordChangeFailed bool
 	commonRec := utils.GetCommonReconciler(r.Client, r.Scheme)
 	odimObject := commonRec.GetOdimObject(ctx, constants.MetadataName, "odim", req.Namespace) //field name has to be taken from odim object
 	bmcRestClient, err := restclient.NewRestClient(ctx, odimObject, commonRec.(*utils.CommonReconciler), constants.BMCOPERATOR)
@@ -133,20 +151,39 @@ func (r *BmcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		updateBMCObject = true
 	}
 
-	//Len check: to check if user modified the encrypted pass for password change reconcile to run
+	// read credentials from the configured credential provider when the bmc refers to one,
+	// a modified password is applied after the pending bios reset
+	if bmcObj.Spec.Credentials.CredentialRef != "" && bmcObj.Status.SystemReset != fmt.Sprintf("%s Bios", constants.PendingForResetEvent) {
+		provider, err := credentials.GetCredentialProvider(commonRec.(*utils.CommonReconciler))
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Getting the credential provider for %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+			return ctrl.Result{}, err
+		}
+		modified, err := bmcUtil.syncCredentialsFromProvider(provider)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Fetching credentials for %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+			return ctrl.Result{}, err
+		}
+		if modified {
+			updateBMCObject = true
+		}
+	}
+
+	//Encryption check: to check if user modified the encrypted pass for password change reconcile to run
 	// SystemResetStatus check : To stop reconcile flow through add bmc code for bios reset change
-	if len(bmcObj.Spec.Credentials.Password) < 20 && bmcObj.Status.SystemReset != fmt.Sprintf("%s Bios", constants.PendingForResetEvent) {
+	if !utils.IsEncrypted(bmcObj.Spec.Credentials.Password) && bmcObj.Status.SystemReset != fmt.Sprintf("%s Bios", constants.PendingForResetEvent) {
 		updateBMCObject = true
 		if bmcObj.ObjectMeta.Annotations["old_password"] != "" {
 			var updatedInBmc, updatedInOdim bool
 			//decrypt the encrypted old pass
-			decryptedPass := utils.DecryptWithPrivateKey(ctx, bmcObj.ObjectMeta.Annotations["old_password"], *utils.PrivateKey, true) //doBase64Decode = true, because bmc password is encrypted n encoded
+			decryptedPass := utils.DecryptWithPrivateKey(ctx, bmcObj.ObjectMeta.Annotations["old_password"], *utils.GetPrivateKey(), true) //doBase64Decode = true, because bmc password is encrypted n encoded
 			// update password change
 			if decryptedPass != bmcObj.Spec.Credentials.Password {
 				//update bmc with new pass
 				updatedInBmc, err = bmcUtil.updateBmcWithNewPassword()
 				if err != nil || !updatedInBmc {
-					encryptedPassword := utils.EncryptWithPublicKey(ctx, decryptedPass, *utils.PublicKey)
+					passwordChangeFailed = true
+					encryptedPassword := utils.EncryptWithPublicKey(ctx, decryptedPass, *utils.GetPublicKey())
 					if encryptedPassword != "" {
 						bmcObj.Spec.Credentials.Password = encryptedPassword
 					}
@@ -160,7 +197,8 @@ func (r *BmcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 						} else {
 							l.LogWithFields(ctx).Info(fmt.Sprintf("Updating new password in Odim for %s BMC failed, Try again", bmcObj.Spec.BmcDetails.Address))
 						}
-						encryptedPassword := utils.EncryptWithPublicKey(ctx, decryptedPass, *utils.PublicKey)
+						passwordChangeFailed = true
+						encryptedPassword := utils.EncryptWithPublicKey(ctx, decryptedPass, *utils.GetPublicKey())
 						if encryptedPassword != "" {
 							bmcObj.Spec.Credentials.Password = encryptedPassword
 						}
@@ -170,13 +208,13 @@ func (r *BmcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 					//update annotations with old password and encrypt exisiting password
 					if updatedInBmc && updatedInOdim {
 						l.LogWithFields(ctx).Info(fmt.Sprintf("Updating old password and encrypting current password for %s BMC", bmcObj.Spec.BmcDetails.Address))
-						bmcUtil.encryptPassword(utils.PublicKey)
+						bmcUtil.encryptPassword(utils.GetPublicKey())
 						l.LogWithFields(ctx).Info(fmt.Sprintf("Successfully completed updating password for %s BMC!", bmcObj.Spec.BmcDetails.Address))
 					}
 				}
 			} else {
 				l.LogWithFields(ctx).Info(fmt.Sprintf("Password set is the same as before for %s BMC", bmcObj.Spec.BmcDetails.Address))
-				bmcUtil.encryptPassword(utils.PublicKey)
+				bmcUtil.encryptPassword(utils.GetPublicKey())
 			}
 		} else {
 			// retrive conn method for bmc
@@ -216,6 +254,19 @@ func (r *BmcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	// reset code
 	if bmcObj.Spec.BmcDetails.PowerState != "" && bmcObj.Spec.BmcDetails.ResetType != "" {
 		updateBMCObject = true
+		// the reset is kept in spec until a maintenance window selecting the BMC opens
+		if deferred, deferredUntil := utils.GetMaintenanceWindowDeferral(ctx, r.Client, bmcObj); deferred {
+			if message := utils.GetDeferredMessage(deferredUntil); bmcObj.Status.Deferred != message {
+				bmcObj.Status.Deferred = message
+				commonRec.UpdateBmcStatus(ctx, bmcObj)
+			}
+			l.LogWithFields(ctx).Info(fmt.Sprintf("Reset of %s BMC is deferred until %s", bmcObj.Spec.BmcDetails.Address, deferredUntil.Format(time.RFC3339)))
+			return ctrl.Result{RequeueAfter: time.Until(deferredUntil)}, nil
+		}
+		if bmcObj.Status.Deferred != "" {
+			bmcObj.Status.Deferred = ""
+			commonRec.UpdateBmcStatus(ctx, bmcObj)
+		}
 		var currentPowerState string
 		allowableVal := bmcUtil.getAllowableResetValues()
 		if allowableVal == nil {
@@ -250,6 +301,12 @@ func (r *BmcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		bmcObj.SetAnnotations(annotations)
 		updateBMCObject = true
 	}
+	// a password read from the credential provider is never stored in plain text
+	if bmcObj.Spec.Credentials.CredentialRef != "" && !utils.IsEncrypted(bmcObj.Spec.Credentials.Password) {
+		if encryptedPassword := utils.EncryptWithPublicKey(ctx, bmcObj.Spec.Credentials.Password, *utils.GetPublicKey()); encryptedPassword != "" {
+			bmcObj.Spec.Credentials.Password = encryptedPassword
+		}
+	}
 	//flag to update bmc object after all modifications
 	if updateBMCObject {
 		l.LogWithFields(ctx).Info(fmt.Sprintf("Updating %s BMC object...", bmcObj.Spec.BmcDetails.Address))
@@ -261,17 +318,53 @@ func (r *BmcReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		time.Sleep(time.Duration(constants.SleepTime) * time.Second) // NOTE: Do not delete this, this helps in proper update of the object
 		l.LogWithFields(ctx).Info(fmt.Sprintf("%s BMC object is updated.", bmcObj.Spec.BmcDetails.Address))
 	}
+	// kubernetes secrets are watched, vault is polled, and a failed password change is retried
+	if bmcObj.Spec.Credentials.CredentialRef != "" && (passwordChangeFailed || strings.EqualFold(config.Data.CredentialProvider, credentials.VaultProvider)) {
+		return ctrl.Result{RequeueAfter: credentialSyncInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BmcReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&infraiov1.Bmc{}).
-		WithEventFilter(utils.IgnoreStatusUpdate()).
+		For(&infraiov1.Bmc{}, builder.WithPredicates(utils.IgnoreStatusUpdate())).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.getBmcsForSecret), builder.WithPredicates(secretDataChanged())).
 		Complete(r)
 }
 
+// getBmcsForSecret returns the bmc objects whose credentials are read from the secret
+func (r *BmcReconciler) getBmcsForSecret(obj client.Object) []reconcile.Request {
+	if obj.GetNamespace() != config.Data.Namespace {
+		return nil
+	}
+	bmcList := &infraiov1.BmcList{}
+	err := r.List(context.TODO(), bmcList, client.InNamespace(obj.GetNamespace()), client.MatchingFields{credentialRefIndex: obj.GetName()})
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, bmcObj := range bmcList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: bmcObj.Name, Namespace: bmcObj.Namespace}})
+	}
+	return requests
+}
+
+// secretDataChanged filters the updates of secrets which do not change their data,
+// secrets have no generation so the generation predicate can not be used
+func secretDataChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldSecret, oldOk := e.ObjectOld.(*corev1.Secret)
+			newSecret, newOk := e.ObjectNew.(*corev1.Secret)
+			if !oldOk || !newOk {
+				return true
+			}
+			return !reflect.DeepEqual(oldSecret.Data, newSecret.Data)
+		},
+	}
+}
+
 // --------Add BMC------
 // addBmc used to add BMC
 func (bu *bmcUtils) addBmc(body []byte, namespaceName types.NamespacedName, sysID string) bool {
@@ -345,12 +438,7 @@ func updateBmcDetails(sysId string, bu *bmcUtils, biosUtil bios.BiosInterface, b
 		}
 	}
 
-	var registryID []string
-	var messageRegistryResp []map[string]interface{}
-	if systemDetails["Manufacturer"].(string) == constants.ILOManufacturer {
-		registryID, messageRegistryResp = eventSubUtils.GetMessageRegistryDetails(bu.bmcObj, "iLOEvents")
-	}
-
+	registryID, messageRegistryResp := eventSubUtils.GetMessageRegistryDetails(bu.bmcObj, eventsubscription.GetMessageRegistryPrefixes())
 	if len(registryID) > 0 {
 		bu.bmcObj.Status.EventsMessageRegistry = strings.Join(registryID, ",")
 		for _, resp := range messageRegistryResp {
@@ -387,8 +475,12 @@ func (bu *bmcUtils) PrepareAddBmcPayload(connectionMeth string) ([]byte, error)
 	return body, nil
 }
 
+// updateBmcLabels sets the labels derived from the BMC details, labels added by users are kept
+// so that they can be used in selectors along with the derived labels
 func (bu *bmcUtils) updateBmcLabels() {
-	bu.bmcObj.ObjectMeta.Labels = map[string]string{}
+	if bu.bmcObj.ObjectMeta.Labels == nil {
+		bu.bmcObj.ObjectMeta.Labels = map[string]string{}
+	}
 	bu.bmcObj.ObjectMeta.Labels["name"] = bu.bmcObj.Spec.BmcDetails.Address
 	bu.bmcObj.ObjectMeta.Labels["systemId"] = bu.bmcObj.Status.BmcSystemID
 	bu.bmcObj.ObjectMeta.Labels["serialNo"] = bu.bmcObj.Status.SerialNumber
@@ -552,6 +644,12 @@ func (bu *bmcUtils) removeBmcFinalizerAndDeleteDependencies() {
 	bu.loggingBmcDeletionActivity(isBootDeleted, "BootOrderSetting")
 	isFirmwareDeleted := bu.commonRec.GetCommonReconcilerClient().Delete(bu.ctx, firmwareObj)
 	bu.loggingBmcDeletionActivity(isFirmwareDeleted, "Firmware")
+	eventLogObj := &infraiov1.BmcEventLog{}
+	err := bu.commonRec.GetCommonReconcilerClient().Get(bu.ctx, types.NamespacedName{Name: bu.bmcObj.ObjectMeta.Name, Namespace: bu.namespace}, eventLogObj)
+	if err == nil {
+		isEventLogDeleted := bu.commonRec.GetCommonReconcilerClient().Delete(bu.ctx, eventLogObj)
+		bu.loggingBmcDeletionActivity(isEventLogDeleted, "BmcEventLog")
+	}
 	if volObj != nil {
 		controllerutil.RemoveFinalizer(volObj, volFinalizer)
 		err := bu.commonRec.GetCommonReconcilerClient().Update(bu.ctx, volObj)
@@ -657,16 +755,21 @@ func (bu *bmcUtils) ResetSystem(isBiosUpdation bool, updateBmcDependents bool) b
 			biosObj := bu.commonRec.GetBiosObject(bu.ctx, constants.MetadataName, bu.bmcObj.ObjectMeta.Name, bu.namespace)
 			biosUtil := bios.GetBiosUtils(bu.ctx, biosObj, bu.commonRec, bu.bmcRestClient, bu.namespace)
 			biosAttribute := getUpdatedBiosAttributes(bu.ctx, biosObj.Status.BiosAttributes, bu.bmcObj, biosUtil)
+			if biosAttribute == nil {
+				biosAttribute = biosObj.Status.BiosAttributes
+			}
 			biosUtil.UpdateBiosAttributesOnReset(bu.bmcObj.Spec.BmcDetails.Address, biosAttribute)
+			var bootAttribute *infraiov1.BootSetting
 			sysDetails := bu.commonUtil.GetBmcSystemDetails(bu.ctx, bu.bmcObj)
 			if sysDetails != nil {
 				bootUtil := boot.GetBootUtils(bu.ctx, nil, bu.commonRec, bu.bmcRestClient, bu.commonUtil, bu.namespace)
-				bootAttribute := bootUtil.GetBootAttributes(sysDetails)
+				bootAttribute = bootUtil.GetBootAttributes(sysDetails)
 				if bootAttribute != nil {
 					bootUtil.UpdateBootAttributesOnReset(bu.bmcObj.ObjectMeta.Name, bootAttribute)
 				}
 			}
-			if strings.Contains(bu.bmcObj.Status.SystemReset, "Volume") {
+			// volumes pending for the reset are updated while verifying the pending resets
+			if len(bu.bmcObj.Status.PendingResets) == 0 && strings.Contains(bu.bmcObj.Status.SystemReset, "Volume") {
 				strArr := strings.Split(bu.bmcObj.Status.SystemReset, " ")
 				volName := strArr[len(strArr)-2]
 				volumeObj := bu.commonRec.GetVolumeObject(bu.ctx, bu.bmcObj.Spec.BmcDetails.Address, bu.namespace)
@@ -676,7 +779,8 @@ func (bu *bmcUtils) ResetSystem(isBiosUpdation bool, updateBmcDependents bool) b
 					l.LogWithFields(bu.ctx).Info(updateMsg)
 				}
 			}
-
+			bu.verifyPendingResets(biosAttribute, bootAttribute)
+			biosUtil.UpdateBiosActionsOnReset(bu.bmcObj.Status.LastResetVerification)
 		}
 		delete(common.RestartRequired, bu.bmcObj.Status.BmcSystemID)
 		return true
@@ -725,15 +829,18 @@ func (bu *bmcUtils) updateBmcWithNewPassword() (bool, error) {
 	l.LogWithFields(bu.ctx).Info(fmt.Sprintf("Updating password on %s BMC", bu.bmcObj.Spec.BmcDetails.Address))
 	var changePassUri string
 	getResp, _, _ := bu.bmcRestClient.Get(remoteAccUri, "Fetching remote account members..")
-	accounts := getResp["Members"].([]interface{})
+	accounts, _ := getResp["Members"].([]interface{})
 	for _, acc := range accounts {
-		accUri := acc.(map[string]interface{})["@odata.id"].(string)
+		accUri, _ := acc.(map[string]interface{})["@odata.id"].(string)
 		accGet, _, _ := bu.bmcRestClient.Get(accUri, "Fetching remote account details..")
-		if accGet["UserName"].(string) == bu.bmcObj.Spec.Credentials.Username {
+		if userName, ok := accGet["UserName"].(string); ok && userName == bu.bmcObj.Spec.Credentials.Username {
 			changePassUri = accUri
 			break
 		}
 	}
+	if changePassUri == "" {
+		return false, fmt.Errorf("could not find %s account on %s BMC", bu.bmcObj.Spec.Credentials.Username, bu.bmcObj.Spec.BmcDetails.Address)
+	}
 	bdy := modifyPassword{Password: bu.bmcObj.Spec.Credentials.Password}
 	body, err := json.Marshal(bdy)
 	if err != nil {
@@ -751,10 +858,77 @@ func (bu *bmcUtils) updateBmcWithNewPassword() (bool, error) {
 	return false, err
 }
 
+// ErrOdimPasswordNotUpdated is returned by RotatePassword when the password is changed on the BMC but ODIM could not be updated
+var ErrOdimPasswordNotUpdated = errors.New("password is changed on the BMC but could not be updated in ODIM")
+
+// RotatePassword applies newPassword on the BMC and then on ODIM. The BMC is not rolled back when ODIM could not be
+// updated, as ODIM might hold either password, ErrOdimPasswordNotUpdated is returned so that ODIM is updated again.
+// When the BMC is updated, spec of the bmc object holds the encrypted new password
+func (bu *bmcUtils) RotatePassword(newPassword string) error {
+	encryptedOldPassword := bu.bmcObj.Spec.Credentials.Password
+	bu.bmcObj.Spec.Credentials.Password = newPassword
+	updatedInBmc, err := bu.updateBmcWithNewPassword()
+	if err != nil || !updatedInBmc {
+		bu.bmcObj.Spec.Credentials.Password = encryptedOldPassword
+		return fmt.Errorf("updating new password on %s BMC failed", bu.bmcObj.Spec.BmcDetails.Address)
+	}
+	updatedInOdim, err := bu.updateOdimWithNewPassword()
+	bu.encryptPassword(utils.GetPublicKey())
+	if err != nil || !updatedInOdim {
+		l.LogWithFields(bu.ctx).Info(fmt.Sprintf("Updating new password in Odim for %s BMC failed", bu.bmcObj.Spec.BmcDetails.Address))
+		return fmt.Errorf("%w for %s BMC", ErrOdimPasswordNotUpdated, bu.bmcObj.Spec.BmcDetails.Address)
+	}
+	l.LogWithFields(bu.ctx).Info(fmt.Sprintf("Successfully rotated password for %s BMC", bu.bmcObj.Spec.BmcDetails.Address))
+	return nil
+}
+
+// UpdateOdimPassword applies password, which is already set on the BMC, in ODIM.
+// On success spec of the bmc object holds the encrypted password
+func (bu *bmcUtils) UpdateOdimPassword(password string) error {
+	encryptedPassword := bu.bmcObj.Spec.Credentials.Password
+	bu.bmcObj.Spec.Credentials.Password = password
+	updatedInOdim, err := bu.updateOdimWithNewPassword()
+	if err != nil || !updatedInOdim {
+		bu.bmcObj.Spec.Credentials.Password = encryptedPassword
+		return fmt.Errorf("%w for %s BMC", ErrOdimPasswordNotUpdated, bu.bmcObj.Spec.BmcDetails.Address)
+	}
+	bu.encryptPassword(utils.GetPublicKey())
+	return nil
+}
+
+// syncCredentialsFromProvider reads the credentials referred by the bmc object from the credential provider
+// and sets them on the spec if they differ from the current credentials, returns true if spec is modified
+func (bu *bmcUtils) syncCredentialsFromProvider(provider credentials.CredentialProvider) (bool, error) {
+	creds, err := provider.GetCredentials(bu.ctx, bu.bmcObj.Spec.Credentials.CredentialRef)
+	if err != nil {
+		return false, err
+	}
+	if creds.Password == "" {
+		return false, fmt.Errorf("password not found in %s", bu.bmcObj.Spec.Credentials.CredentialRef)
+	}
+	var modified bool
+	if creds.Username != "" && creds.Username != bu.bmcObj.Spec.Credentials.Username {
+		bu.bmcObj.Spec.Credentials.Username = creds.Username
+		modified = true
+	}
+	currentPassword := bu.bmcObj.Spec.Credentials.Password
+	if utils.IsEncrypted(currentPassword) {
+		currentPassword = utils.DecryptWithPrivateKey(bu.ctx, currentPassword, *utils.GetPrivateKey(), true) //doBase64Decode = true, because bmc password is encrypted n encoded
+	}
+	if currentPassword != creds.Password {
+		// the plain text password is only kept in memory, the password change flow applies it on the BMC and ODIM
+		// and encrypts it before the bmc object is updated
+		l.LogWithFields(bu.ctx).Info(fmt.Sprintf("Password for %s BMC is modified in %s", bu.bmcObj.Spec.BmcDetails.Address, bu.bmcObj.Spec.Credentials.CredentialRef))
+		bu.bmcObj.Spec.Credentials.Password = creds.Password
+		modified = true
+	}
+	return modified, nil
+}
+
 // -------------Utils-------------------
 // encryptPassword will encrypt the current password of the bmc
 func (bu *bmcUtils) encryptPassword(publicKey *rsa.PublicKey) {
-	encryptedPassword := utils.EncryptWithPublicKey(bu.ctx, bu.bmcObj.Spec.Credentials.Password, *utils.PublicKey)
+	encryptedPassword := utils.EncryptWithPublicKey(bu.ctx, bu.bmcObj.Spec.Credentials.Password, *utils.GetPublicKey())
 	if encryptedPassword != "" {
 		bu.bmcObj.Spec.Credentials.Password = encryptedPassword
 		bu.bmcObj.ObjectMeta.Annotations["old_password"] = encryptedPassword
@@ -0,0 +1,140 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+)
+
+// MaintenanceWindowReconciler reconciles a MaintenanceWindow object
+type MaintenanceWindowReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=maintenancewindows,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=maintenancewindows/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=maintenancewindows/finalizers,verbs=update
+
+// Reconcile reports whether the maintenance window is open, when it opens or closes next and
+// how many BMCs it selects, and requeues itself for the next opening or closing of the window
+func (r *MaintenanceWindowReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.MaintenanceWindowActionID, constants.MaintenanceWindowActionName, podName)
+	windowObj := &infraiov1.MaintenanceWindow{}
+	err := r.Get(ctx, req.NamespacedName, windowObj)
+	if err != nil {
+		if Error.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	status := infraiov1.MaintenanceWindowStatus{}
+	var requeueAfter time.Duration
+	open, transition, err := utils.GetMaintenanceWindowState(windowObj, time.Now())
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Invalid %s maintenance window: %s", windowObj.Name, err.Error())
+		status.Message = err.Error()
+	} else {
+		status.Open = open
+		if open {
+			status.WindowEnd = &metav1.Time{Time: transition.UTC()}
+		} else {
+			status.NextWindowStart = &metav1.Time{Time: transition.UTC()}
+		}
+		requeueAfter = time.Until(transition)
+	}
+	bmcList := &infraiov1.BmcList{}
+	err = r.List(ctx, bmcList, client.InNamespace(windowObj.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error fetching BMC objects: %s", err.Error())
+		return ctrl.Result{}, err
+	}
+	for i := range bmcList.Items {
+		selected, err := utils.IsBmcSelectedByMaintenanceWindow(windowObj, &bmcList.Items[i])
+		if err != nil {
+			status.Message = err.Error()
+			break
+		}
+		if selected {
+			status.SelectedBmcs++
+		}
+	}
+	if !reflect.DeepEqual(windowObj.Status, status) {
+		windowObj.Status = status
+		err = r.Status().Update(ctx, windowObj)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Updating status of %s maintenance window: %s", windowObj.Name, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+	if requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MaintenanceWindowReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infraiov1.MaintenanceWindow{}, builder.WithPredicates(utils.IgnoreStatusUpdate())).
+		Watches(&source.Kind{Type: &infraiov1.Bmc{}}, handler.EnqueueRequestsFromMapFunc(r.getWindowsForBmc), builder.WithPredicates(bmcLabelsChanged())).
+		Complete(r)
+}
+
+// getWindowsForBmc returns the maintenance windows of the bmc namespace, so that the selected BMCs
+// are counted again when BMCs are added, removed or relabelled
+func (r *MaintenanceWindowReconciler) getWindowsForBmc(obj client.Object) []reconcile.Request {
+	windowList := &infraiov1.MaintenanceWindowList{}
+	err := r.List(context.TODO(), windowList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, window := range windowList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: window.Name, Namespace: window.Namespace}})
+	}
+	return requests
+}
+
+// bmcLabelsChanged filters the updates of Bmc objects which do not change their labels
+func bmcLabelsChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+		},
+	}
+}
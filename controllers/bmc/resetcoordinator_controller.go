@@ -0,0 +1,301 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	volume "github.com/ODIM-Project/BMCOperator/controllers/volume"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultCoordinatedResetType = "ForceRestart"
+	resetRetryInterval          = 5 * time.Minute
+)
+
+// ResetCoordinatorReconciler applies the changes pending for a reset of the system with one reset,
+// according to the reset policy of the Bmc object
+type ResetCoordinatorReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// Reconcile resets the system of a BMC with pending changes when its reset policy allows it
+func (r *ResetCoordinatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.ResetCoordinatorActionID, constants.ResetCoordinatorActionName, podName)
+	bmcObj := &infraiov1.Bmc{}
+	err := r.Get(ctx, req.NamespacedName, bmcObj)
+	if err != nil {
+		if Error.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if len(bmcObj.Status.PendingResets) == 0 || bmcObj.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+	policy := getResetPolicy(bmcObj)
+	switch policy.Mode {
+	case constants.ResetPolicyManual:
+		if bmcObj.GetAnnotations()[constants.ApproveResetAnnotation] != "true" {
+			l.LogWithFields(ctx).Infof("Changes of %s BMC are pending for a reset, set the %s annotation to reset the system", bmcObj.Spec.BmcDetails.Address, constants.ApproveResetAnnotation)
+			return ctrl.Result{}, nil
+		}
+	case constants.ResetPolicyMaintenanceWindow:
+		// the system is reset in the windows of the maintenance windows selecting the BMC, which are applied below
+		if !utils.IsBmcSelectedByAnyMaintenanceWindow(ctx, r.Client, bmcObj) {
+			l.LogWithFields(ctx).Infof("No maintenance window selects %s BMC, its changes stay pending until one does", bmcObj.Spec.BmcDetails.Address)
+			return ctrl.Result{}, nil
+		}
+	case constants.ResetPolicyImmediate:
+	default:
+		l.LogWithFields(ctx).Errorf("Invalid reset policy mode %s for %s BMC", policy.Mode, bmcObj.Spec.BmcDetails.Address)
+		return ctrl.Result{}, nil
+	}
+	// maintenance windows selecting the BMC apply on top of the reset policy
+	deferred, deferredUntil := utils.GetMaintenanceWindowDeferral(ctx, r.Client, bmcObj)
+	deferredMessage := ""
+	if deferred {
+		deferredMessage = utils.GetDeferredMessage(deferredUntil)
+	}
+	if bmcObj.Status.Deferred != deferredMessage {
+		bmcObj.Status.Deferred = deferredMessage
+		if deferred {
+			bmcObj.Status.ResetScheduledTime = &metav1.Time{Time: deferredUntil}
+		}
+		err = r.Status().Update(ctx, bmcObj)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Updating status of %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+	if deferred {
+		l.LogWithFields(ctx).Infof("Reset of %s BMC is deferred until %s", bmcObj.Spec.BmcDetails.Address, deferredUntil.Format(time.RFC3339))
+		return ctrl.Result{RequeueAfter: time.Until(deferredUntil)}, nil
+	}
+	commonRec := utils.GetCommonReconciler(r.Client, r.Scheme)
+	odimObject := commonRec.GetOdimObject(ctx, constants.MetadataName, "odim", req.Namespace)
+	bmcRestClient, err := restclient.NewRestClient(ctx, odimObject, commonRec.(*utils.CommonReconciler), constants.BMCOPERATOR)
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Failed to get rest client for BMC: %s", err.Error())
+		return ctrl.Result{}, err
+	}
+	commonUtil := common.GetCommonUtils(bmcRestClient)
+	// the reset type is only set on a copy, the spec of the Bmc object is not updated
+	resetObj := bmcObj.DeepCopy()
+	resetObj.Spec.BmcDetails.ResetType = policy.ResetType
+	if sysDetails := commonUtil.GetBmcSystemDetails(ctx, resetObj); sysDetails != nil && strings.EqualFold(fmt.Sprint(sysDetails["PowerState"]), "Off") {
+		resetObj.Spec.BmcDetails.ResetType = "On"
+	}
+	l.LogWithFields(ctx).Infof("Resetting %s BMC to apply %d pending changes", bmcObj.Spec.BmcDetails.Address, len(bmcObj.Status.PendingResets))
+	bmcUtil := GetBmcUtils(ctx, resetObj, req.Namespace, &commonRec, &bmcRestClient, commonUtil, false)
+	if !bmcUtil.ResetSystem(false, true) {
+		l.LogWithFields(ctx).Infof("Could not reset %s BMC, retrying in %s", bmcObj.Spec.BmcDetails.Address, resetRetryInterval)
+		return ctrl.Result{RequeueAfter: resetRetryInterval}, nil
+	}
+	if _, ok := bmcObj.GetAnnotations()[constants.ApproveResetAnnotation]; ok {
+		patch := client.MergeFrom(bmcObj.DeepCopy())
+		delete(bmcObj.Annotations, constants.ApproveResetAnnotation)
+		err = r.Patch(ctx, bmcObj, patch)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Removing %s annotation of %s BMC: %s", constants.ApproveResetAnnotation, bmcObj.Spec.BmcDetails.Address, err.Error())
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
+// getResetPolicy returns the reset policy of the bmc object with the defaults applied
+func getResetPolicy(bmcObj *infraiov1.Bmc) infraiov1.ResetPolicy {
+	policy := infraiov1.ResetPolicy{}
+	if bmcObj.Spec.ResetPolicy != nil {
+		policy = *bmcObj.Spec.ResetPolicy
+	}
+	if policy.Mode == "" {
+		policy.Mode = constants.ResetPolicyManual
+	}
+	if policy.ResetType == "" {
+		policy.ResetType = defaultCoordinatedResetType
+	}
+	return policy
+}
+
+// verifyPendingResets verifies that the changes pending for the reset took effect,
+// records the result in the status of the bmc object and clears the pending changes
+func (bu *bmcUtils) verifyPendingResets(biosAttributes map[string]string, bootSetting *infraiov1.BootSetting) {
+	bmcObj := bu.commonRec.GetBmcObject(bu.ctx, constants.MetadataName, bu.bmcObj.ObjectMeta.Name, bu.namespace)
+	if bmcObj == nil {
+		return
+	}
+	verifications := []infraiov1.ResetVerification{}
+	for _, pending := range bmcObj.Status.PendingResets {
+		var verification infraiov1.ResetVerification
+		switch pending.Kind {
+		case constants.PendingResetBios:
+			verification = verifyPendingValues(pending, biosAttributes)
+		case constants.PendingResetBoot:
+			verification = verifyPendingValues(pending, getBootSettingValues(bootSetting))
+		case constants.PendingResetVolume:
+			verification = bu.verifyPendingVolume(pending)
+		case constants.PendingResetBiosDefaults:
+			verification = verifyPendingValues(pending, biosAttributes)
+		case constants.PendingResetBiosPassword:
+			// passwords can not be read back from the BMC
+			verification = infraiov1.ResetVerification{Kind: pending.Kind, Name: pending.Name, Unverified: true, Message: "password is not readable, the change is not verified"}
+		default:
+			verification = infraiov1.ResetVerification{Kind: pending.Kind, Name: pending.Name, Message: "unknown kind of change"}
+		}
+		if verification.Applied {
+			l.LogWithFields(bu.ctx).Infof("%s change %s of %s BMC is applied after reset", pending.Kind, pending.Name, bmcObj.Spec.BmcDetails.Address)
+		} else if verification.Unverified {
+			l.LogWithFields(bu.ctx).Infof("%s change %s of %s BMC can not be verified after reset: %s", pending.Kind, pending.Name, bmcObj.Spec.BmcDetails.Address, verification.Message)
+		} else {
+			l.LogWithFields(bu.ctx).Errorf("%s change %s of %s BMC is not applied after reset: %s", pending.Kind, pending.Name, bmcObj.Spec.BmcDetails.Address, verification.Message)
+		}
+		verifications = append(verifications, verification)
+	}
+	now := metav1.Now()
+	bmcObj.Status.PendingResets = nil
+	bmcObj.Status.ResetScheduledTime = nil
+	bmcObj.Status.LastResetTime = &now
+	bmcObj.Status.LastResetVerification = verifications
+	bu.commonRec.UpdateBmcStatus(bu.ctx, bmcObj)
+	// keep the object of the caller up to date so that it can still be updated
+	bu.bmcObj.ObjectMeta.ResourceVersion = bmcObj.ObjectMeta.ResourceVersion
+	bu.bmcObj.Status.PendingResets = bmcObj.Status.PendingResets
+	bu.bmcObj.Status.ResetScheduledTime = bmcObj.Status.ResetScheduledTime
+	bu.bmcObj.Status.LastResetTime = bmcObj.Status.LastResetTime
+	bu.bmcObj.Status.LastResetVerification = bmcObj.Status.LastResetVerification
+}
+
+// verifyPendingVolume updates the volume object of a volume created on reset and tells whether it is present
+func (bu *bmcUtils) verifyPendingVolume(pending infraiov1.PendingReset) infraiov1.ResetVerification {
+	verification := infraiov1.ResetVerification{Kind: pending.Kind, Name: pending.Name}
+	volObj := &infraiov1.Volume{}
+	err := bu.commonRec.GetCommonReconcilerClient().Get(bu.ctx, types.NamespacedName{Name: pending.Name, Namespace: bu.namespace}, volObj)
+	if err != nil {
+		verification.Message = "could not get volume object: " + err.Error()
+		return verification
+	}
+	// volume object name is the bmc name followed by the volume name, ex: 10.24.0.14.volume1
+	strArr := strings.Split(pending.Name, ".")
+	volUtil := volume.GetVolumeUtils(bu.ctx, bu.bmcRestClient, bu.commonRec, volObj, bu.namespace)
+	verification.Applied, verification.Message = volUtil.UpdateVolumeStatusAndClearSpec(bu.bmcObj, strArr[len(strArr)-1])
+	return verification
+}
+
+// verifyPendingValues compares the values expected by a pending change with the actual values
+func verifyPendingValues(pending infraiov1.PendingReset, actual map[string]string) infraiov1.ResetVerification {
+	verification := infraiov1.ResetVerification{Kind: pending.Kind, Name: pending.Name, Applied: true}
+	notApplied := []string{}
+	for key, value := range pending.Values {
+		if actualValue, ok := actual[key]; !ok || actualValue != value {
+			notApplied = append(notApplied, key)
+		}
+	}
+	if len(notApplied) != 0 {
+		sort.Strings(notApplied)
+		verification.Applied = false
+		verification.Message = "values are not applied: " + strings.Join(notApplied, ", ")
+	}
+	return verification
+}
+
+// getBootSettingValues returns the boot settings in the format of the values of a pending change
+func getBootSettingValues(bootSetting *infraiov1.BootSetting) map[string]string {
+	if bootSetting == nil {
+		return nil
+	}
+	return map[string]string{
+		"BootOrder":                    strings.Join(bootSetting.BootOrder, ","),
+		"BootSourceOverrideEnabled":    bootSetting.BootSourceOverrideEnabled,
+		"BootSourceOverrideTarget":     bootSetting.BootSourceOverrideTarget,
+		"UefiTargetBootSourceOverride": bootSetting.UefiTargetBootSourceOverride,
+	}
+}
+
+// resetCoordinationChanged filters the Bmc updates which can change the coordination of the resets
+func resetCoordinationChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldBmc, okOld := e.ObjectOld.(*infraiov1.Bmc)
+			newBmc, okNew := e.ObjectNew.(*infraiov1.Bmc)
+			if !okOld || !okNew {
+				return false
+			}
+			return !reflect.DeepEqual(oldBmc.Status.PendingResets, newBmc.Status.PendingResets) ||
+				!reflect.DeepEqual(oldBmc.Spec.ResetPolicy, newBmc.Spec.ResetPolicy) ||
+				oldBmc.GetAnnotations()[constants.ApproveResetAnnotation] != newBmc.GetAnnotations()[constants.ApproveResetAnnotation]
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ResetCoordinatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("resetcoordinator").
+		For(&infraiov1.Bmc{}, builder.WithPredicates(resetCoordinationChanged())).
+		Watches(&source.Kind{Type: &infraiov1.MaintenanceWindow{}}, handler.EnqueueRequestsFromMapFunc(r.getBmcsForWindow)).
+		Complete(r)
+}
+
+// getBmcsForWindow returns the Bmc objects with pending changes selected by the maintenance window
+func (r *ResetCoordinatorReconciler) getBmcsForWindow(obj client.Object) []reconcile.Request {
+	window, ok := obj.(*infraiov1.MaintenanceWindow)
+	if !ok {
+		return nil
+	}
+	bmcList := &infraiov1.BmcList{}
+	err := r.List(context.TODO(), bmcList, client.InNamespace(window.Namespace))
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for i := range bmcList.Items {
+		bmcObj := &bmcList.Items[i]
+		if len(bmcObj.Status.PendingResets) == 0 {
+			continue
+		}
+		if selected, err := utils.IsBmcSelectedByMaintenanceWindow(window, bmcObj); err == nil && selected {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: bmcObj.Name, Namespace: bmcObj.Namespace}})
+		}
+	}
+	return requests
+}
@@ -0,0 +1,50 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+)
+
+func TestVerifyPendingValues(t *testing.T) {
+	pending := infraiov1.PendingReset{Kind: "Bios", Name: "bmc1", Values: map[string]string{"BootMode": "Uefi", "ProcTurbo": "Enabled"}}
+	tests := []struct {
+		name        string
+		actual      map[string]string
+		wantApplied bool
+		wantMessage string
+	}{
+		{
+			name:        "all values applied",
+			actual:      map[string]string{"BootMode": "Uefi", "ProcTurbo": "Enabled", "WorkloadProfile": "Custom"},
+			wantApplied: true,
+		},
+		{
+			name:        "values differing or missing",
+			actual:      map[string]string{"BootMode": "LegacyBios"},
+			wantMessage: "values are not applied: BootMode, ProcTurbo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := verifyPendingValues(pending, tt.actual)
+			if got.Applied != tt.wantApplied || got.Message != tt.wantMessage {
+				t.Errorf("verifyPendingValues() = %+v, want applied %v, message %q", got, tt.wantApplied, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+const (
+	// bootIntentCheckInterval is how often the BMC is checked for the use of a one time boot override after the reset
+	bootIntentCheckInterval = time.Minute
+	// bootIntentExpiry is how long a one time boot override may stay unused after the reset before it is cleared
+	bootIntentExpiry = 30 * time.Minute
+)
+
+// getIntentBootSetting returns the boot source override set by a boot intent
+func getIntentBootSetting(intent *infraiov1.BootIntent) (*infraiov1.BootSetting, error) {
+	switch intent.Name {
+	case constants.BootIntentOneTimePxe:
+		return &infraiov1.BootSetting{BootSourceOverrideEnabled: "Once", BootSourceOverrideTarget: "Pxe"}, nil
+	case constants.BootIntentOneTimeHttp:
+		if intent.HttpBootUri == "" {
+			return nil, fmt.Errorf("httpBootUri is required by the %s intent", intent.Name)
+		}
+		return &infraiov1.BootSetting{BootSourceOverrideEnabled: "Once", BootSourceOverrideTarget: "UefiHttp", HttpBootUri: intent.HttpBootUri}, nil
+	case constants.BootIntentPersistentUefiDisk:
+		return &infraiov1.BootSetting{BootSourceOverrideEnabled: "Continuous", BootSourceOverrideTarget: "Hdd", BootSourceOverrideMode: "UEFI"}, nil
+	}
+	return nil, fmt.Errorf("boot intent %s is not one of %s, %s and %s", intent.Name,
+		constants.BootIntentOneTimePxe, constants.BootIntentOneTimeHttp, constants.BootIntentPersistentUefiDisk)
+}
+
+// validateIntentBootSetting validates the boot source override of an intent against the allowable values of the BMC
+func validateIntentBootSetting(setting *infraiov1.BootSetting, current infraiov1.BootSetting) error {
+	if len(current.BootTargetAllowableValues) != 0 && !utils.ContainsValue(current.BootTargetAllowableValues, setting.BootSourceOverrideTarget) {
+		return fmt.Errorf("BootSourceOverrideTarget %s is not allowed by the BMC", setting.BootSourceOverrideTarget)
+	}
+	return nil
+}
+
+// applyBootIntent sets the boot source override of the intent on the BMC, records it as pending for a reset
+// and approves the reset when requested. The intent is cleared from the spec and its state kept in the status
+func (bo *bootUtils) applyBootIntent() {
+	intent := bo.bootObj.Spec.Intent
+	result := &infraiov1.BootIntentResult{Name: intent.Name, HttpBootUri: intent.HttpBootUri, AppliedTime: metav1.Now()}
+	bmcObj := bo.getBootBmcObject()
+	if bmcObj == nil || bmcObj.Status.BmcSystemID == "" {
+		l.LogWithFields(bo.ctx).Info("Check if BMC is registered..")
+		return
+	}
+	setting, err := getIntentBootSetting(intent)
+	if err == nil {
+		err = validateIntentBootSetting(setting, bo.bootObj.Status.Boot)
+	}
+	if err == nil && !bo.patchBootOverride(bmcObj, Boot{
+		BootSourceOverrideEnabled: setting.BootSourceOverrideEnabled,
+		BootSourceOverrideMode:    setting.BootSourceOverrideMode,
+		BootSourceOverrideTarget:  setting.BootSourceOverrideTarget,
+		HttpBootUri:               setting.HttpBootUri,
+	}) {
+		err = fmt.Errorf("could not set the boot source override on the BMC")
+	}
+	if err == nil {
+		err = utils.RecordPendingReset(bo.ctx, bo.commonRec.GetCommonReconcilerClient(), bmcObj, constants.PendingResetBoot, bo.bootObj.ObjectMeta.Name, getExpectedBootSettings(setting), "")
+		if err != nil {
+			err = fmt.Errorf("boot source override is set but could not be recorded for the reset: %s", err.Error())
+		}
+	}
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Boot intent %s of %s BMC is not applied: %s", intent.Name, bmcObj.Spec.BmcDetails.Address, err.Error())
+		result.State = constants.BootIntentFailed
+		result.Message = err.Error()
+	} else {
+		result.State = constants.BootIntentPendingReset
+		result.Message = "waiting for the reset of the system"
+		if intent.Reset {
+			result.Message = bo.approveReset(bmcObj)
+		}
+		l.LogWithFields(bo.ctx).Infof("Boot intent %s applied on %s BMC", intent.Name, bmcObj.Spec.BmcDetails.Address)
+	}
+	bo.bootObj.Spec.Intent = nil
+	err = bo.commonRec.GetCommonReconcilerClient().Update(bo.ctx, bo.bootObj)
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Error: Clearing boot intent of %s boot order setting: %s", bo.bootObj.Name, err.Error())
+		return
+	}
+	bo.bootObj.Status.LastIntent = result
+	err = bo.commonRec.GetCommonReconcilerClient().Status().Update(bo.ctx, bo.bootObj)
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Error: Updating status of %s boot order setting: %s", bo.bootObj.Name, err.Error())
+	}
+}
+
+// approveReset sets the approve-reset annotation on the Bmc object, the reset coordinator then resets
+// the system according to the reset policy of the BMC. It returns the message of the intent state
+func (bo *bootUtils) approveReset(bmcObj *infraiov1.Bmc) string {
+	patch := client.MergeFrom(bmcObj.DeepCopy())
+	if bmcObj.Annotations == nil {
+		bmcObj.Annotations = map[string]string{}
+	}
+	bmcObj.Annotations[constants.ApproveResetAnnotation] = "true"
+	err := bo.commonRec.GetCommonReconcilerClient().Patch(bo.ctx, bmcObj, patch)
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Error: Approving reset of %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+		return "could not approve the reset of the system: " + err.Error()
+	}
+	return "reset of the system is approved"
+}
+
+// completeBootIntent checks whether the BMC used the one time boot override of the last intent after the reset,
+// an override still unused after bootIntentExpiry is cleared. It returns when to check again
+func (bo *bootUtils) completeBootIntent() time.Duration {
+	result := bo.bootObj.Status.LastIntent
+	bmcObj := bo.getBootBmcObject()
+	if bmcObj == nil {
+		return 0
+	}
+	sysDetails := bo.commonUtil.GetBmcSystemDetails(bo.ctx, bmcObj)
+	if sysDetails == nil {
+		return bootIntentCheckInterval
+	}
+	setting := bo.GetBootAttributes(sysDetails)
+	if setting.BootSourceOverrideEnabled == "Once" {
+		if result.ResetTime != nil && time.Since(result.ResetTime.Time) < bootIntentExpiry {
+			return bootIntentCheckInterval
+		}
+		if !bo.patchBootOverride(bmcObj, Boot{BootSourceOverrideEnabled: "Disabled"}) {
+			return bootIntentCheckInterval
+		}
+		result.State = constants.BootIntentExpired
+		result.Message = fmt.Sprintf("one time boot override was not used within %s and is cleared", bootIntentExpiry)
+		setting.BootSourceOverrideEnabled = "Disabled"
+	} else {
+		result.State = constants.BootIntentCompleted
+		result.Message = "one time boot override is used"
+	}
+	l.LogWithFields(bo.ctx).Infof("Boot intent %s of %s BMC is %s", result.Name, bmcObj.Spec.BmcDetails.Address, result.State)
+	bo.bootObj.Status.Boot = *setting
+	err := bo.commonRec.GetCommonReconcilerClient().Status().Update(bo.ctx, bo.bootObj)
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Error: Updating status of %s boot order setting: %s", bo.bootObj.Name, err.Error())
+		return bootIntentCheckInterval
+	}
+	return 0
+}
+
+// updateBootIntentOnReset moves the last intent pending for the reset to its next state,
+// a one time boot override is then waited for and a persistent one is completed
+func updateBootIntentOnReset(bootObj *infraiov1.BootOrderSetting) {
+	result := bootObj.Status.LastIntent
+	if result == nil || result.State != constants.BootIntentPendingReset {
+		return
+	}
+	now := metav1.Now()
+	result.ResetTime = &now
+	if result.Name == constants.BootIntentPersistentUefiDisk {
+		result.State = constants.BootIntentCompleted
+		result.Message = "system is reset"
+		return
+	}
+	result.State = constants.BootIntentReset
+	result.Message = "system is reset, waiting for the one time boot"
+}
+
+// patchBootOverride patches the boot properties of the system of the BMC
+func (bo *bootUtils) patchBootOverride(bmcObj *infraiov1.Bmc, boot Boot) bool {
+	body, err := json.Marshal(BootOrderSetting{Boot: boot})
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Error marshalling boot request body for %s BMC: %s", bmcObj.Name, err.Error())
+		return false
+	}
+	response, err := bo.bootRestClient.Patch("/redfish/v1/Systems/"+bmcObj.Status.BmcSystemID, fmt.Sprintf("Patching boot override of %s BMC", bmcObj.Spec.BmcDetails.Address), body)
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Error patching boot override of %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+		return false
+	}
+	switch response.StatusCode {
+	case http.StatusAccepted:
+		done, _ := bo.commonUtil.MoniteringTaskmon(response.Header, bo.ctx, common.BOOTSETTING, bmcObj.Name)
+		return done
+	case http.StatusOK, http.StatusNoContent:
+		return true
+	}
+	l.LogWithFields(bo.ctx).Errorf("Error patching boot override of %s BMC: got status %d", bmcObj.Spec.BmcDetails.Address, response.StatusCode)
+	return false
+}
+
+// getBootBmcObject returns the Bmc object of the boot order setting
+func (bo *bootUtils) getBootBmcObject() *infraiov1.Bmc {
+	switch {
+	case bo.bootObj.Spec.BmcName != "":
+		return bo.commonRec.GetBmcObject(bo.ctx, constants.SpecBmcAddress, bo.bootObj.Spec.BmcName, bo.bootObj.Namespace)
+	case bo.bootObj.Spec.SystemID != "":
+		return bo.commonRec.GetBmcObject(bo.ctx, constants.StatusBmcSystemID, bo.bootObj.Spec.SystemID, bo.bootObj.Namespace)
+	case bo.bootObj.Spec.SerialNo != "":
+		return bo.commonRec.GetBmcObject(bo.ctx, constants.StatusSerialNumber, bo.bootObj.Spec.SerialNo, bo.bootObj.Namespace)
+	}
+	return bo.commonRec.GetBmcObject(bo.ctx, constants.MetadataName, bo.bootObj.ObjectMeta.Name, bo.bootObj.Namespace)
+}
@@ -0,0 +1,112 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+)
+
+func TestGetIntentBootSetting(t *testing.T) {
+	tests := []struct {
+		name    string
+		intent  infraiov1.BootIntent
+		want    *infraiov1.BootSetting
+		wantErr bool
+	}{
+		{
+			name:   "one time PXE boot",
+			intent: infraiov1.BootIntent{Name: constants.BootIntentOneTimePxe},
+			want:   &infraiov1.BootSetting{BootSourceOverrideEnabled: "Once", BootSourceOverrideTarget: "Pxe"},
+		},
+		{
+			name:   "one time HTTP boot",
+			intent: infraiov1.BootIntent{Name: constants.BootIntentOneTimeHttp, HttpBootUri: "http://10.0.0.1/boot.iso"},
+			want:   &infraiov1.BootSetting{BootSourceOverrideEnabled: "Once", BootSourceOverrideTarget: "UefiHttp", HttpBootUri: "http://10.0.0.1/boot.iso"},
+		},
+		{
+			name:    "one time HTTP boot without URI",
+			intent:  infraiov1.BootIntent{Name: constants.BootIntentOneTimeHttp},
+			wantErr: true,
+		},
+		{
+			name:   "persistent UEFI disk",
+			intent: infraiov1.BootIntent{Name: constants.BootIntentPersistentUefiDisk},
+			want:   &infraiov1.BootSetting{BootSourceOverrideEnabled: "Continuous", BootSourceOverrideTarget: "Hdd", BootSourceOverrideMode: "UEFI"},
+		},
+		{
+			name:    "unknown intent",
+			intent:  infraiov1.BootIntent{Name: "Floppy"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getIntentBootSetting(&tt.intent)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getIntentBootSetting() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getIntentBootSetting() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateIntentBootSetting(t *testing.T) {
+	setting := &infraiov1.BootSetting{BootSourceOverrideTarget: "UefiHttp"}
+	if err := validateIntentBootSetting(setting, infraiov1.BootSetting{}); err != nil {
+		t.Errorf("validateIntentBootSetting() without allowable values error = %v", err)
+	}
+	if err := validateIntentBootSetting(setting, infraiov1.BootSetting{BootTargetAllowableValues: []string{"Pxe", "Hdd"}}); err == nil {
+		t.Errorf("validateIntentBootSetting() with target not allowed returned no error")
+	}
+}
+
+func TestUpdateBootIntentOnReset(t *testing.T) {
+	tests := []struct {
+		name      string
+		intent    *infraiov1.BootIntentResult
+		wantState string
+	}{
+		{
+			name:      "one time intent waits for the boot",
+			intent:    &infraiov1.BootIntentResult{Name: constants.BootIntentOneTimePxe, State: constants.BootIntentPendingReset},
+			wantState: constants.BootIntentReset,
+		},
+		{
+			name:      "persistent intent is completed",
+			intent:    &infraiov1.BootIntentResult{Name: constants.BootIntentPersistentUefiDisk, State: constants.BootIntentPendingReset},
+			wantState: constants.BootIntentCompleted,
+		},
+		{
+			name:      "failed intent is kept",
+			intent:    &infraiov1.BootIntentResult{Name: constants.BootIntentOneTimePxe, State: constants.BootIntentFailed},
+			wantState: constants.BootIntentFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bootObj := &infraiov1.BootOrderSetting{Status: infraiov1.BootOrderSettingsStatus{LastIntent: tt.intent}}
+			updateBootIntentOnReset(bootObj)
+			if bootObj.Status.LastIntent.State != tt.wantState {
+				t.Errorf("updateBootIntentOnReset() state = %s, want %s", bootObj.Status.LastIntent.State, tt.wantState)
+			}
+		})
+	}
+}
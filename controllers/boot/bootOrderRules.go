@@ -0,0 +1,134 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+// GetBootOptions returns the boot options of the system
+func (bo *bootUtils) GetBootOptions(systemID string) ([]infraiov1.BootOption, error) {
+	uri := "/redfish/v1/Systems/" + systemID + "/BootOptions"
+	resp, sCode, err := bo.bootRestClient.Get(uri, "Fetching boot options")
+	if err != nil {
+		return nil, err
+	}
+	if sCode != http.StatusOK {
+		return nil, fmt.Errorf("got status %d on GET %s", sCode, uri)
+	}
+	members, _ := resp["Members"].([]interface{})
+	options := []infraiov1.BootOption{}
+	for _, member := range members {
+		memberObj, _ := member.(map[string]interface{})
+		memberURI, _ := memberObj["@odata.id"].(string)
+		if memberURI == "" {
+			l.LogWithFields(bo.ctx).Errorf("Ignoring boot option member without @odata.id on %s: %v", uri, member)
+			continue
+		}
+		optionResp, sCode, err := bo.bootRestClient.Get(memberURI, "Fetching boot option")
+		if err != nil {
+			l.LogWithFields(bo.ctx).Errorf("Could not fetch boot option %s: %s", memberURI, err.Error())
+			continue
+		}
+		if sCode != http.StatusOK {
+			l.LogWithFields(bo.ctx).Errorf("Could not fetch boot option %s: got status %d", memberURI, sCode)
+			continue
+		}
+		option := infraiov1.BootOption{}
+		option.BootOptionReference, _ = optionResp["BootOptionReference"].(string)
+		option.DisplayName, _ = optionResp["DisplayName"].(string)
+		option.UefiDevicePath, _ = optionResp["UefiDevicePath"].(string)
+		if option.BootOptionReference != "" {
+			options = append(options, option)
+		}
+	}
+	return options, nil
+}
+
+// resolveBootOrderRules resolves the boot order rules of the boot order setting against the boot options
+// of the system, the boot options and the result are recorded in the status
+func (bo *bootUtils) resolveBootOrderRules(systemID string, bootObj *infraiov1.BootOrderSetting) ([]string, bool) {
+	var bootOrder []string
+	options, err := bo.GetBootOptions(systemID)
+	if err == nil {
+		bootObj.Status.BootOptions = options
+		bootOrder, err = resolveBootOrder(bootObj.Spec.Boot.BootOrderRules, options, bootObj.Status.Boot.BootOrder)
+	}
+	if err != nil {
+		l.LogWithFields(bo.ctx).Errorf("Could not resolve boot order rules of %s boot order setting: %s", bootObj.Name, err.Error())
+		bootObj.Status.BootOrderRulesMessage = err.Error()
+		err = bo.commonRec.GetCommonReconcilerClient().Status().Update(bo.ctx, bootObj)
+		if err != nil {
+			l.LogWithFields(bo.ctx).Errorf("Error: Updating status of %s boot order setting: %s", bootObj.Name, err.Error())
+		}
+		return nil, false
+	}
+	bootObj.Status.BootOrderRulesMessage = "boot order rules are resolved to " + strings.Join(bootOrder, ", ")
+	l.LogWithFields(bo.ctx).Infof("Boot order rules of %s boot order setting are resolved to %s", bootObj.Name, strings.Join(bootOrder, ", "))
+	return bootOrder, true
+}
+
+// resolveBootOrder returns the boot order selected by the rules, every rule selects the boot options of
+// currentOrder matching it and not selected by a previous rule, in their current order. The boot options
+// selected by no rule follow in their current order, so the result is a permutation of currentOrder
+func resolveBootOrder(rules []infraiov1.BootOrderRule, options []infraiov1.BootOption, currentOrder []string) ([]string, error) {
+	optionsByReference := map[string]infraiov1.BootOption{}
+	for _, option := range options {
+		optionsByReference[option.BootOptionReference] = option
+	}
+	selected := map[string]bool{}
+	bootOrder := []string{}
+	for i, rule := range rules {
+		if rule.DisplayName == "" && rule.UefiDevicePath == "" {
+			return nil, fmt.Errorf("boot order rule %d has neither displayName nor uefiDevicePath", i+1)
+		}
+		displayName, err := regexp.Compile("(?i)" + rule.DisplayName)
+		if err != nil {
+			return nil, fmt.Errorf("displayName of boot order rule %d is not a valid regular expression: %s", i+1, err.Error())
+		}
+		uefiDevicePath, err := regexp.Compile("(?i)" + rule.UefiDevicePath)
+		if err != nil {
+			return nil, fmt.Errorf("uefiDevicePath of boot order rule %d is not a valid regular expression: %s", i+1, err.Error())
+		}
+		matched := 0
+		for _, reference := range currentOrder {
+			option, ok := optionsByReference[reference]
+			if !ok || selected[reference] || !displayName.MatchString(option.DisplayName) || !uefiDevicePath.MatchString(option.UefiDevicePath) {
+				continue
+			}
+			selected[reference] = true
+			bootOrder = append(bootOrder, reference)
+			matched++
+			if rule.Limit > 0 && matched == rule.Limit {
+				break
+			}
+		}
+		if matched == 0 {
+			return nil, fmt.Errorf("boot order rule %d matches no boot option of the boot order", i+1)
+		}
+	}
+	for _, reference := range currentOrder {
+		if !selected[reference] {
+			bootOrder = append(bootOrder, reference)
+		}
+	}
+	return bootOrder, nil
+}
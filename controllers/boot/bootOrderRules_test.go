@@ -0,0 +1,81 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+)
+
+func TestResolveBootOrder(t *testing.T) {
+	options := []infraiov1.BootOption{
+		{BootOptionReference: "Boot0001", DisplayName: "Embedded SATA Port 1 HDD", UefiDevicePath: "PciRoot(0x0)/Pci(0x17,0x0)/Sata(0x0,0x0,0x0)"},
+		{BootOptionReference: "Boot0002", DisplayName: "NIC Port 1 - PXE IPv4", UefiDevicePath: "PciRoot(0x0)/Pci(0x1C,0x0)/MAC(A0369F000001,0x1)/IPv4(0.0.0.0)"},
+		{BootOptionReference: "Boot0003", DisplayName: "NIC Port 1 - PXE IPv6", UefiDevicePath: "PciRoot(0x0)/Pci(0x1C,0x0)/MAC(A0369F000001,0x1)/IPv6(0000:0000:0000:0000:0000:0000:0000:0000)"},
+		{BootOptionReference: "Boot0004", DisplayName: "NVMe Drive 1", UefiDevicePath: "PciRoot(0x1)/Pci(0x3,0x0)/NVMe(0x1,00-00-00-00-00-00-00-01)"},
+		{BootOptionReference: "Boot0005", DisplayName: "NVMe Drive 2", UefiDevicePath: "PciRoot(0x1)/Pci(0x3,0x1)/NVMe(0x1,00-00-00-00-00-00-00-02)"},
+	}
+	currentOrder := []string{"Boot0001", "Boot0003", "Boot0002", "Boot0005", "Boot0004"}
+	tests := []struct {
+		name    string
+		rules   []infraiov1.BootOrderRule
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "PXE IPv4 of NIC port 1 first",
+			rules: []infraiov1.BootOrderRule{{DisplayName: "port 1.*pxe ipv4"}},
+			want:  []string{"Boot0002", "Boot0001", "Boot0003", "Boot0005", "Boot0004"},
+		},
+		{
+			name:  "first NVMe drive then PXE",
+			rules: []infraiov1.BootOrderRule{{UefiDevicePath: "/NVMe\\(", Limit: 1}, {DisplayName: "PXE"}},
+			want:  []string{"Boot0005", "Boot0003", "Boot0002", "Boot0001", "Boot0004"},
+		},
+		{
+			name:  "display name and device path",
+			rules: []infraiov1.BootOrderRule{{DisplayName: "NVMe", UefiDevicePath: "Pci\\(0x3,0x0\\)"}},
+			want:  []string{"Boot0004", "Boot0001", "Boot0003", "Boot0002", "Boot0005"},
+		},
+		{
+			name:    "rule matching no boot option",
+			rules:   []infraiov1.BootOrderRule{{DisplayName: "HTTP"}},
+			wantErr: true,
+		},
+		{
+			name:    "empty rule",
+			rules:   []infraiov1.BootOrderRule{{Limit: 1}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regular expression",
+			rules:   []infraiov1.BootOrderRule{{DisplayName: "NVMe("}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveBootOrder(tt.rules, options, currentOrder)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveBootOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveBootOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
 	common "github.com/ODIM-Project/BMCOperator/controllers/common"
@@ -32,15 +33,20 @@ type BootOrderSetting struct {
 type Boot struct {
 	BootOrder                    []string `json:"BootOrder,omitempty"`
 	BootSourceOverrideEnabled    string   `json:"BootSourceOverrideEnabled,omitempty"`
+	BootSourceOverrideMode       string   `json:"BootSourceOverrideMode,omitempty"`
 	BootSourceOverrideTarget     string   `json:"BootSourceOverrideTarget,omitempty"`
 	UefiTargetBootSourceOverride string   `json:"UefiTargetBootSourceOverride,omitempty"`
+	HttpBootUri                  string   `json:"HttpBootUri,omitempty"`
 }
 
 type BootInterface interface {
 	GetBootAttributes(sysDetails map[string]interface{}) *infraiov1.BootSetting
+	GetBootOptions(systemID string) ([]infraiov1.BootOption, error)
 	UpdateBootAttributesOnReset(bmcName string, bootSetting *infraiov1.BootSetting)
 	updateBootSettings() bool
 	UpdateBootDetails(ctx context.Context, systemID, bootBmcIP string, bootObj *infraiov1.BootOrderSetting, bootBmcObj *infraiov1.Bmc) bool
+	applyBootIntent()
+	completeBootIntent() time.Duration
 }
 
 type bootUtils struct {
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 
 	Error "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -75,6 +76,10 @@ func (r *BootOrderSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 	bootUtil := GetBootUtils(ctx, bootObj, commonRec, bootRestClient, common.GetCommonUtils(bootRestClient), req.Namespace)
+	if bootObj.Spec.Intent != nil {
+		bootUtil.applyBootIntent()
+		return ctrl.Result{}, nil
+	}
 	if bootObj.Spec.Boot != nil && (len(bootObj.Spec.Boot.BootTargetAllowableValues) > 0 || len(bootObj.Spec.Boot.UefiTargetAllowableValues) > 0 ||
 		bootObj.Spec.Boot.BootSourceOverrideMode != "") {
 		l.LogWithFields(ctx).Info("Unmodify values passed in input")
@@ -83,13 +88,18 @@ func (r *BootOrderSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 	if bootObj.Spec.BmcName != "" || bootObj.Spec.SerialNo != "" || bootObj.Spec.SystemID != "" {
 		if bootObj.Spec.Boot != nil && (len(bootObj.Spec.Boot.BootOrder) > 0 || bootObj.Spec.Boot.BootSourceOverrideEnabled != "" ||
-			bootObj.Spec.Boot.BootSourceOverrideTarget != "" || bootObj.Spec.Boot.UefiTargetBootSourceOverride != "") {
+			bootObj.Spec.Boot.BootSourceOverrideTarget != "" || bootObj.Spec.Boot.UefiTargetBootSourceOverride != "" || bootObj.Spec.Boot.HttpBootUri != "" ||
+			len(bootObj.Spec.Boot.BootOrderRules) > 0) {
 			res := bootUtil.updateBootSettings()
 			if !res {
 				return ctrl.Result{}, nil
 			}
 		}
 	}
+	// a one time boot override of an intent is followed until the BMC has used it
+	if bootObj.Status.LastIntent != nil && bootObj.Status.LastIntent.State == constants.BootIntentReset {
+		return ctrl.Result{RequeueAfter: bootUtil.completeBootIntent()}, nil
+	}
 
 	return ctrl.Result{}, nil
 }
@@ -120,6 +130,8 @@ func (bo *bootUtils) updateBootSettings() bool {
 
 	ok := bo.UpdateBootDetails(bo.ctx, systemID, bootBmcIP, bo.bootObj, bootBmcObj)
 	if ok {
+		// updating the object reads back its status, the resolution of the boot order rules is kept
+		bootOptions, rulesMessage := bo.bootObj.Status.BootOptions, bo.bootObj.Status.BootOrderRulesMessage
 		sysDetails := bo.commonUtil.GetBmcSystemDetails(bo.ctx, bootBmcObj)
 		bo.bootObj.ObjectMeta.Annotations["odata.id"] = getBootOID(sysDetails)
 		err := bo.commonRec.GetCommonReconcilerClient().Update(bo.ctx, bo.bootObj)
@@ -127,9 +139,16 @@ func (bo *bootUtils) updateBootSettings() bool {
 			l.LogWithFields(bo.ctx).Error(fmt.Sprintf("Error: Updating %s boot order setting object annotations: %s of bmc", bootBmcObj.Name, err.Error()))
 		}
 		bootSetting := bo.GetBootAttributes(sysDetails)
+		if bootBmcObj != nil {
+			err = utils.RecordPendingReset(bo.ctx, bo.commonRec.GetCommonReconcilerClient(), bootBmcObj, constants.PendingResetBoot, bo.bootObj.ObjectMeta.Name, getExpectedBootSettings(bo.bootObj.Spec.Boot), "")
+			if err != nil {
+				l.LogWithFields(bo.ctx).Errorf("Error: Recording pending boot settings of %s BMC: %s", bootBmcObj.Spec.BmcDetails.Address, err.Error())
+			}
+		}
 		bo.bootObj.Spec = infraiov1.BootOrderSettingsSpec{}
 		bo.commonRec.GetCommonReconcilerClient().Update(bo.ctx, bo.bootObj)
 		bo.bootObj.Status.Boot = *bootSetting
+		bo.bootObj.Status.BootOptions, bo.bootObj.Status.BootOrderRulesMessage = bootOptions, rulesMessage
 		err = bo.commonRec.GetCommonReconcilerClient().Status().Update(bo.ctx, bo.bootObj)
 		if err != nil {
 			l.LogWithFields(bo.ctx).Error(fmt.Sprintf("Error while updating boot order setting object for %s BMC: %s", bootBmcObj.Name, err.Error()))
@@ -141,6 +160,28 @@ func (bo *bootUtils) updateBootSettings() bool {
 	return false
 }
 
+// getExpectedBootSettings returns the boot settings expected on the BMC after the reset, a one time
+// boot source override is not expected to last after the reset
+func getExpectedBootSettings(boot *infraiov1.BootSetting) map[string]string {
+	expected := map[string]string{}
+	if boot == nil {
+		return expected
+	}
+	if len(boot.BootOrder) > 0 {
+		expected["BootOrder"] = strings.Join(boot.BootOrder, ",")
+	}
+	if boot.BootSourceOverrideEnabled == "Continuous" {
+		expected["BootSourceOverrideEnabled"] = boot.BootSourceOverrideEnabled
+		if boot.BootSourceOverrideTarget != "" {
+			expected["BootSourceOverrideTarget"] = boot.BootSourceOverrideTarget
+		}
+		if boot.UefiTargetBootSourceOverride != "" {
+			expected["UefiTargetBootSourceOverride"] = boot.UefiTargetBootSourceOverride
+		}
+	}
+	return expected
+}
+
 func getBootOID(sysDetails map[string]interface{}) string {
 	boot := sysDetails["Boot"].(map[string]interface{})
 	bootOptions := boot["BootOptions"].(map[string]interface{})
@@ -150,6 +191,17 @@ func getBootOID(sysDetails map[string]interface{}) string {
 // UpdateBootDetails function is used to update the boot details in server
 func (bo *bootUtils) UpdateBootDetails(ctx context.Context, systemID, bootBmcIP string, bootObj *infraiov1.BootOrderSetting, bootBmcObj *infraiov1.Bmc) bool {
 	var boot = Boot{}
+	if len(bootObj.Spec.Boot.BootOrderRules) > 0 {
+		if len(bootObj.Spec.Boot.BootOrder) > 0 {
+			l.LogWithFields(bo.ctx).Error("BootOrder and BootOrderRules can not be used together")
+			return false
+		}
+		bootOrder, ok := bo.resolveBootOrderRules(systemID, bootObj)
+		if !ok {
+			return false
+		}
+		bootObj.Spec.Boot.BootOrder = bootOrder
+	}
 	if len(bootObj.Spec.Boot.BootOrder) > 0 {
 		if !utils.CompareArray(bootObj.Status.Boot.BootOrder, bootObj.Spec.Boot.BootOrder) {
 			l.LogWithFields(bo.ctx).Error("Invalid value passed for BootOrder")
@@ -174,6 +226,9 @@ func (bo *bootUtils) UpdateBootDetails(ctx context.Context, systemID, bootBmcIP
 		}
 		boot.UefiTargetBootSourceOverride = bootObj.Spec.Boot.UefiTargetBootSourceOverride
 	}
+	if bootObj.Spec.Boot.HttpBootUri != "" {
+		boot.HttpBootUri = bootObj.Spec.Boot.HttpBootUri
+	}
 	bootSetting := BootOrderSetting{
 		Boot: boot,
 	}
@@ -204,6 +259,7 @@ func (bo *bootUtils) UpdateBootAttributesOnReset(bmcName string, bootSetting *in
 	bootObj := bo.commonRec.GetBootObject(bo.ctx, constants.MetadataName, bmcName, bo.namespace)
 	if bootObj != nil {
 		bootObj.Status.Boot = *bootSetting
+		updateBootIntentOnReset(bootObj)
 	}
 	err := bo.commonRec.GetCommonReconcilerClient().Status().Update(bo.ctx, bootObj)
 	if err != nil {
@@ -251,6 +307,11 @@ func (bo *bootUtils) GetBootAttributes(sysDetails map[string]interface{}) *infra
 				bootDetails.BootSourceOverrideTarget = s
 			}
 		}
+		if val, ok := boot["HttpBootUri"]; ok {
+			if s, ok := val.(string); ok {
+				bootDetails.HttpBootUri = s
+			}
+		}
 	}
 	return &bootDetails
 }
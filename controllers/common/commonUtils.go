@@ -31,6 +31,8 @@ const (
 	RESETBMC          = "ResetBMC"
 	BOOTSETTING       = "BootSetting"
 	BIOSSETTING       = "BiosSetting"
+	RESETBIOS         = "ResetBios"
+	BIOSPASSWORD      = "ChangeBiosPassword"
 	CREATEVOLUME      = "CreateVolume"
 	DELETEVOLUME      = "DeleteVolume"
 	FIRMWARE          = "Firmware"
@@ -121,6 +123,14 @@ var TaskmonLogsTable = map[string]string{
 	"404:BiosSetting":             "Cannot configure bios setting for %s BMC, since %s BMC is not found!",
 	"400:BiosSetting":             "Invalid request passed while configuring bios setting %s BMC!",
 	"Err:BiosSetting":             "Error in patching, bios not configured properly for %s BMC, try again",
+	"200:ResetBios":               "Bios reset to defaults requested for %s BMC, Please reset system now.",
+	"202:ResetBios":               "Requesting bios reset to defaults for %s BMC...",
+	"400:ResetBios":               "Invalid request passed while resetting bios of %s BMC to defaults!",
+	"Err:ResetBios":               "error while resetting bios of %s BMC to defaults",
+	"200:ChangeBiosPassword":      "Bios password change requested for %s BMC, Please reset system now.",
+	"202:ChangeBiosPassword":      "Requesting bios password change for %s BMC...",
+	"400:ChangeBiosPassword":      "Invalid request passed while changing bios password of %s BMC!",
+	"Err:ChangeBiosPassword":      "error while changing bios password of %s BMC",
 	"200:CreateVolume":            "Volume %s successfully created, Please reset the system now",
 	"202:CreateVolume":            "%s volume creation in progress... ",
 	"Err:CreateVolume":            "error while creating %s volume",
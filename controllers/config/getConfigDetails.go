@@ -42,20 +42,75 @@ var confMutex = &sync.RWMutex{}
 
 // ConfigModel contains config values (Mandatory values)
 type ConfigModel struct {
-	Reconciliation                         string      `yaml:"reconciliation"`
-	ReconcileInterval                      string      `yaml:"reconcileInterval"`
-	SecretName                             string      `yaml:"secretName"`
-	MetricPort                             string      `yaml:"metricsBindPort"`
-	HealthPort                             string      `yaml:"healthProbeBindPort"`
-	EventClientPort                        string      `yaml:"eventClientPort"`
-	LogLevel                               log.Level   `yaml:"logLevel"`
-	LogFormat                              l.LogFormat `yaml:"logFormat"`
-	KubeConfigPath                         string      `yaml:"kubeConfigPath"`
-	EventSubReconciliation                 string      `yaml:"eventSubReconciliation"`
-	Namespace                              string      `yaml:"namespace"`
-	OperatorEventSubscriptionMeesageIds    []string    `yaml:"operatorEventSubsciptionMessageIds"`
-	OperatorEventSubscriptionEventTypes    []string    `yaml:"operatorEventSubscriptionEventTypes"`
-	OperatorEventSubscriptionResourceTypes []string    `yaml:" operatorEventSubsciptionResourceTypes"`
+	Reconciliation                         string              `yaml:"reconciliation"`
+	ReconcileInterval                      string              `yaml:"reconcileInterval"`
+	SecretName                             string              `yaml:"secretName"`
+	MetricPort                             string              `yaml:"metricsBindPort"`
+	HealthPort                             string              `yaml:"healthProbeBindPort"`
+	EventClientPort                        string              `yaml:"eventClientPort"`
+	LogLevel                               log.Level           `yaml:"logLevel"`
+	LogFormat                              l.LogFormat         `yaml:"logFormat"`
+	KubeConfigPath                         string              `yaml:"kubeConfigPath"`
+	EventSubReconciliation                 string              `yaml:"eventSubReconciliation"`
+	Namespace                              string              `yaml:"namespace"`
+	OperatorEventSubscriptionMeesageIds    []string            `yaml:"operatorEventSubsciptionMessageIds"`
+	OperatorEventSubscriptionEventTypes    []string            `yaml:"operatorEventSubscriptionEventTypes"`
+	OperatorEventSubscriptionResourceTypes []string            `yaml:"operatorEventSubsciptionResourceTypes"`
+	CredentialProvider                     string              `yaml:"credentialProvider"`
+	Vault                                  VaultConfig         `yaml:"vault"`
+	EventListener                          EventListenerConfig `yaml:"eventListener"`
+	EventRouteMessages                     map[string][]string `yaml:"eventRouteMessages"`
+	EventLog                               EventLogConfig      `yaml:"eventLog"`
+	EventSinks                             []EventSinkConfig   `yaml:"eventSinks"`
+	MessageRegistryPrefixes                []string            `yaml:"messageRegistryPrefixes"`
+	EventSubscriptionStatusInterval        string              `yaml:"eventSubscriptionStatusInterval"`
+}
+
+// EventSinkConfig contains the destination, filters and retry policy of a sink to which received events are forwarded
+type EventSinkConfig struct {
+	Name           string            `yaml:"name"`
+	Type           string            `yaml:"type"`
+	URL            string            `yaml:"url"`
+	Mode           string            `yaml:"mode"`
+	Topic          string            `yaml:"topic"`
+	Path           string            `yaml:"path"`
+	CACertPath     string            `yaml:"caCertPath"`
+	Headers        map[string]string `yaml:"headers"`
+	Severities     []string          `yaml:"severities"`
+	MessageIDs     []string          `yaml:"messageIds"`
+	MaxRetries     string            `yaml:"maxRetries"`
+	RetryInterval  string            `yaml:"retryInterval"`
+	Timeout        string            `yaml:"timeout"`
+	DeadLetterPath string            `yaml:"deadLetterPath"`
+}
+
+// EventLogConfig contains the retention of the events recorded in BmcEventLog objects
+type EventLogConfig struct {
+	MaxEvents string `yaml:"maxEvents"`
+	MaxAge    string `yaml:"maxAge"`
+}
+
+// EventListenerConfig contains the settings used to verify the senders of events to the event listener
+type EventListenerConfig struct {
+	ClientAuth            string   `yaml:"clientAuth"`
+	AllowedClientSubjects []string `yaml:"allowedClientSubjects"`
+	VerifyContext         bool     `yaml:"verifyContext"`
+	QueueSize             string   `yaml:"queueSize"`
+	Workers               string   `yaml:"workers"`
+	Mode                  string   `yaml:"mode"`
+	SSEFilter             string   `yaml:"sseFilter"`
+}
+
+// VaultConfig contains the details required to read credentials from HashiCorp Vault
+type VaultConfig struct {
+	Address       string `yaml:"address"`
+	CACertPath    string `yaml:"caCertPath"`
+	AuthMethod    string `yaml:"authMethod"`
+	TokenPath     string `yaml:"tokenPath"`
+	Role          string `yaml:"role"`
+	AuthMountPath string `yaml:"authMountPath"`
+	KVMountPath   string `yaml:"kvMountPath"`
+	CacheTTL      string `yaml:"cacheTTL"`
 }
 
 var (
@@ -79,17 +134,33 @@ func SetConfiguration() error {
 	return nil
 }
 
+// ConfigListener runs TrackConfigListener as a runnable of the manager, configuration changes are tracked in every replica
+type ConfigListener struct{}
+
+// Start tracks the config file changes until the manager is stopped
+func (cl *ConfigListener) Start(ctx context.Context) error {
+	TrackConfigListener(ctx, make(chan error))
+	return nil
+}
+
+// NeedLeaderElection makes the manager track the config file changes on replicas which are not the leader too
+func (cl *ConfigListener) NeedLeaderElection() bool {
+	return false
+}
+
 // TrackConfigListener listes to the chanel on the config file changes
-func TrackConfigListener(errChan chan error) {
+func TrackConfigListener(ctx context.Context, errChan chan error) {
 	eventChan := make(chan interface{})
 	format := Data.LogFormat
 	reconciliation := Data.Reconciliation
 	reconciliationInterval := Data.ReconcileInterval
 	transactionID := uuid.New()
-	ctx := l.CreateContextForLogging(context.Background(), transactionID.String(), constants.BmcOperator, constants.TrackFileConfigActionID, constants.TrackFileConfigActionName, podName)
+	ctx = l.CreateContextForLogging(ctx, transactionID.String(), constants.BmcOperator, constants.TrackFileConfigActionID, constants.TrackFileConfigActionName, podName)
 	go TrackConfigFileChanges(eventChan, errChan)
 	for {
 		select {
+		case <-ctx.Done():
+			return
 		case info := <-eventChan:
 			l.LogWithFields(ctx).Info(info) // new data arrives through eventChan channel
 			if l.Log.Level != Data.LogLevel {
@@ -0,0 +1,70 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package controllers ...
+package controllers
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+)
+
+const (
+	// KubernetesProvider reads credentials from kubernetes secrets
+	KubernetesProvider = "kubernetes"
+	// VaultProvider reads credentials from HashiCorp Vault KV version 2 secrets engine
+	VaultProvider = "vault"
+)
+
+// Credentials holds the plain text credentials returned by a provider
+type Credentials struct {
+	Username string
+	Password string
+	AuthType string
+}
+
+// CredentialProvider declares the method to be implemented by every credential backend
+type CredentialProvider interface {
+	// GetCredentials returns the credentials stored under ref, ref is a secret name
+	// for kubernetes and a path relative to the KV mount for vault
+	GetCredentials(ctx context.Context, ref string) (*Credentials, error)
+}
+
+var (
+	vaultClient *vaultProvider
+	vaultMutex  = &sync.Mutex{}
+)
+
+// GetCredentialProvider returns the credential provider configured in credentialProvider
+func GetCredentialProvider(commonRec *utils.CommonReconciler) (CredentialProvider, error) {
+	if strings.EqualFold(config.Data.CredentialProvider, VaultProvider) {
+		vaultMutex.Lock()
+		defer vaultMutex.Unlock()
+		// vault client is shared so that the token and secret leases are cached across reconciles,
+		// it is rebuilt only when vault configuration is modified
+		if vaultClient == nil || vaultClient.conf != config.Data.Vault {
+			provider, err := newVaultProvider(config.Data.Vault)
+			if err != nil {
+				return nil, err
+			}
+			vaultClient = provider
+		}
+		return vaultClient, nil
+	}
+	return &kubernetesProvider{commonRec: commonRec}, nil
+}
@@ -0,0 +1,42 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"errors"
+
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// kubernetesProvider reads credentials from secrets in the operator namespace,
+// password stored in the secret is encrypted with the operator public key
+type kubernetesProvider struct {
+	commonRec *utils.CommonReconciler
+}
+
+// GetCredentials returns the username,decrypted password and the secret type as authentication type
+func (kp *kubernetesProvider) GetCredentials(ctx context.Context, ref string) (*Credentials, error) {
+	secret := corev1.Secret{}
+	username, password, authType := kp.commonRec.GetObjectSecret(ctx, &secret, ref, "")
+	if password != "" {
+		if utils.GetPrivateKey() == nil {
+			return nil, errors.New("private key is not loaded, cannot decrypt password")
+		}
+		password = utils.DecryptWithPrivateKey(ctx, password, *utils.GetPrivateKey(), false) //doBase64Decode = false, because password fetched from secret is already decoded
+	}
+	return &Credentials{Username: username, Password: password, AuthType: authType}, nil
+}
@@ -0,0 +1,259 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+const (
+	// vault authentication methods
+	vaultTokenAuth      = "token"
+	vaultKubernetesAuth = "kubernetes"
+
+	defaultVaultTokenPath     = "/var/run/secrets/vault/token"
+	defaultServiceAccountPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	defaultVaultAuthMount     = "kubernetes"
+	defaultVaultKVMount       = "secret"
+	defaultVaultCacheTTL      = 300 // seconds
+	// tokenRenewBuffer is subtracted from the token lease so that an expiring token is not used
+	tokenRenewBuffer = 10 * time.Second
+)
+
+// vaultProvider reads credentials from a vault KV version 2 secrets engine
+type vaultProvider struct {
+	conf           config.VaultConfig
+	httpClient     *http.Client
+	serviceAccount string
+	mutex          sync.Mutex
+	token          string
+	tokenExpiry    time.Time // zero value means the token does not expire
+	cache          map[string]cachedCredentials
+}
+
+type cachedCredentials struct {
+	creds  Credentials
+	expiry time.Time
+}
+
+// vaultLoginResponse is the response of vault login api
+type vaultLoginResponse struct {
+	Auth struct {
+		ClientToken   string `json:"client_token"`
+		LeaseDuration int    `json:"lease_duration"`
+	} `json:"auth"`
+}
+
+// vaultKVResponse is the response of vault KV version 2 read api
+type vaultKVResponse struct {
+	LeaseDuration int `json:"lease_duration"`
+	Data          struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func newVaultProvider(conf config.VaultConfig) (*vaultProvider, error) {
+	transport := &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12}}
+	if conf.CACertPath != "" {
+		caCert, err := ioutil.ReadFile(conf.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading vault CA certificate: %s", err.Error())
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificate found in vault CA certificate %s", conf.CACertPath)
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return &vaultProvider{
+		conf:           conf,
+		httpClient:     &http.Client{Transport: transport, Timeout: 30 * time.Second},
+		serviceAccount: defaultServiceAccountPath,
+		cache:          map[string]cachedCredentials{},
+	}, nil
+}
+
+// GetCredentials reads the credentials from vault, the mutex only guards the token and the cache
+// so that reconciles do not wait for each other's vault requests
+func (vp *vaultProvider) GetCredentials(ctx context.Context, ref string) (*Credentials, error) {
+	vp.mutex.Lock()
+	cached, ok := vp.cache[ref]
+	vp.mutex.Unlock()
+	if ok && time.Now().Before(cached.expiry) {
+		creds := cached.creds
+		return &creds, nil
+	}
+	token, err := vp.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mount := vp.conf.KVMountPath
+	if mount == "" {
+		mount = defaultVaultKVMount
+	}
+	uri := fmt.Sprintf("%s/v1/%s/data/%s", strings.TrimSuffix(vp.conf.Address, "/"), strings.Trim(mount, "/"), strings.TrimPrefix(ref, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	resp, err := vp.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s secret from vault: %s", ref, err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusForbidden {
+		// token might have been revoked or rotated, fetch it again on next call
+		vp.mutex.Lock()
+		if vp.token == token {
+			vp.token = ""
+		}
+		vp.mutex.Unlock()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error reading %s secret from vault, status code %d", ref, resp.StatusCode)
+	}
+	kvResp := vaultKVResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&kvResp); err != nil {
+		return nil, fmt.Errorf("error decoding %s secret from vault: %s", ref, err.Error())
+	}
+	creds := Credentials{
+		Username: getString(kvResp.Data.Data, "username"),
+		Password: getString(kvResp.Data.Data, "password"),
+		AuthType: getString(kvResp.Data.Data, "authType"),
+	}
+	if creds.AuthType == "" {
+		creds.AuthType = "BasicAuth"
+	}
+	ttl := kvResp.LeaseDuration
+	if ttl <= 0 {
+		ttl = vp.getCacheTTL()
+	}
+	vp.mutex.Lock()
+	vp.cache[ref] = cachedCredentials{creds: creds, expiry: time.Now().Add(time.Duration(ttl) * time.Second)}
+	vp.mutex.Unlock()
+	l.LogWithFields(ctx).Debugf("Fetched %s secret from vault", ref)
+	return &creds, nil
+}
+
+func (vp *vaultProvider) getToken(ctx context.Context) (string, error) {
+	vp.mutex.Lock()
+	token, tokenExpiry := vp.token, vp.tokenExpiry
+	vp.mutex.Unlock()
+	if token != "" && (tokenExpiry.IsZero() || time.Now().Before(tokenExpiry)) {
+		return token, nil
+	}
+	switch vp.conf.AuthMethod {
+	case vaultKubernetesAuth:
+		return vp.kubernetesLogin(ctx)
+	case vaultTokenAuth, "":
+		token = os.Getenv("VAULT_TOKEN")
+		if token == "" {
+			tokenPath := vp.conf.TokenPath
+			if tokenPath == "" {
+				tokenPath = defaultVaultTokenPath
+			}
+			tokenFile, err := ioutil.ReadFile(tokenPath)
+			if err != nil {
+				return "", fmt.Errorf("error reading vault token: %s", err.Error())
+			}
+			token = strings.TrimSpace(string(tokenFile))
+		}
+		vp.setToken(token, time.Time{})
+		return token, nil
+	}
+	return "", fmt.Errorf("unsupported vault authentication method %s", vp.conf.AuthMethod)
+}
+
+func (vp *vaultProvider) setToken(token string, expiry time.Time) {
+	vp.mutex.Lock()
+	defer vp.mutex.Unlock()
+	vp.token = token
+	vp.tokenExpiry = expiry
+}
+
+func (vp *vaultProvider) kubernetesLogin(ctx context.Context) (string, error) {
+	jwt, err := ioutil.ReadFile(vp.serviceAccount)
+	if err != nil {
+		return "", fmt.Errorf("error reading service account token: %s", err.Error())
+	}
+	mount := vp.conf.AuthMountPath
+	if mount == "" {
+		mount = defaultVaultAuthMount
+	}
+	body, err := json.Marshal(map[string]string{"role": vp.conf.Role, "jwt": strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return "", err
+	}
+	uri := fmt.Sprintf("%s/v1/auth/%s/login", strings.TrimSuffix(vp.conf.Address, "/"), strings.Trim(mount, "/"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewBuffer(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := vp.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error logging in to vault: %s", err.Error())
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error logging in to vault, status code %d", resp.StatusCode)
+	}
+	loginResp := vaultLoginResponse{}
+	if err = json.NewDecoder(resp.Body).Decode(&loginResp); err != nil {
+		return "", fmt.Errorf("error decoding vault login response: %s", err.Error())
+	}
+	if loginResp.Auth.ClientToken == "" {
+		return "", errors.New("vault login response does not contain a token")
+	}
+	var tokenExpiry time.Time
+	if loginResp.Auth.LeaseDuration > 0 {
+		tokenExpiry = time.Now().Add(time.Duration(loginResp.Auth.LeaseDuration)*time.Second - tokenRenewBuffer)
+	}
+	vp.setToken(loginResp.Auth.ClientToken, tokenExpiry)
+	l.LogWithFields(ctx).Debug("Logged in to vault using kubernetes auth")
+	return loginResp.Auth.ClientToken, nil
+}
+
+func (vp *vaultProvider) getCacheTTL() int {
+	ttl, err := strconv.Atoi(vp.conf.CacheTTL)
+	if err != nil || ttl < 0 {
+		return defaultVaultCacheTTL
+	}
+	return ttl
+}
+
+func getString(data map[string]interface{}, key string) string {
+	if val, ok := data[key].(string); ok {
+		return val
+	}
+	return ""
+}
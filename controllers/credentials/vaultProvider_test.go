@@ -0,0 +1,175 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+)
+
+const (
+	fakeVaultToken = "s.faketoken"
+	fakeJWT        = "fake.service.account.jwt"
+)
+
+// fakeVault is a stand-in for the vault login and KV version 2 read apis
+type fakeVault struct {
+	logins int
+	reads  int
+}
+
+func (fv *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/v1/auth/kubernetes/login":
+		body := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["jwt"] != fakeJWT || body["role"] != "bmc-operator" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fv.logins++
+		json.NewEncoder(w).Encode(map[string]interface{}{"auth": map[string]interface{}{"client_token": fakeVaultToken, "lease_duration": 3600}})
+	case "/v1/secret/data/bmc/10.10.10.10":
+		if r.Header.Get("X-Vault-Token") != fakeVaultToken {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fv.reads++
+		json.NewEncoder(w).Encode(map[string]interface{}{"lease_duration": 0, "data": map[string]interface{}{"data": map[string]string{"username": "admin", "password": "Passw0rd"}}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestVaultProvider_GetCredentials(t *testing.T) {
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "token")
+	jwtPath := filepath.Join(dir, "jwt")
+	ioutil.WriteFile(tokenPath, []byte(fakeVaultToken+"\n"), 0600)
+	ioutil.WriteFile(jwtPath, []byte(fakeJWT), 0600)
+	os.Unsetenv("VAULT_TOKEN")
+	tests := []struct {
+		name       string
+		conf       config.VaultConfig
+		ref        string
+		calls      int
+		wantLogins int
+		wantReads  int
+		wantErr    bool
+	}{
+		{
+			name:      "token auth, credentials served from cache",
+			conf:      config.VaultConfig{AuthMethod: "token", TokenPath: tokenPath},
+			ref:       "bmc/10.10.10.10",
+			calls:     3,
+			wantReads: 1,
+		},
+		{
+			name:       "kubernetes auth, token and credentials cached",
+			conf:       config.VaultConfig{AuthMethod: "kubernetes", Role: "bmc-operator"},
+			ref:        "bmc/10.10.10.10",
+			calls:      2,
+			wantLogins: 1,
+			wantReads:  1,
+		},
+		{
+			name:      "cache disabled",
+			conf:      config.VaultConfig{AuthMethod: "token", TokenPath: tokenPath, CacheTTL: "0"},
+			ref:       "bmc/10.10.10.10",
+			calls:     2,
+			wantReads: 2,
+		},
+		{
+			name:    "wrong role",
+			conf:    config.VaultConfig{AuthMethod: "kubernetes", Role: "other"},
+			ref:     "bmc/10.10.10.10",
+			calls:   1,
+			wantErr: true,
+		},
+		{
+			name:    "secret not found",
+			conf:    config.VaultConfig{AuthMethod: "token", TokenPath: tokenPath},
+			ref:     "bmc/unknown",
+			calls:   1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fv := &fakeVault{}
+			server := httptest.NewServer(fv)
+			defer server.Close()
+			tt.conf.Address = server.URL
+			vp, err := newVaultProvider(tt.conf)
+			if err != nil {
+				t.Fatalf("newVaultProvider() error = %v", err)
+			}
+			vp.serviceAccount = jwtPath
+			for i := 0; i < tt.calls; i++ {
+				creds, err := vp.GetCredentials(context.TODO(), tt.ref)
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("GetCredentials() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if !tt.wantErr && (creds.Username != "admin" || creds.Password != "Passw0rd" || creds.AuthType != "BasicAuth") {
+					t.Errorf("GetCredentials() got = %+v", creds)
+				}
+			}
+			if fv.logins != tt.wantLogins || fv.reads != tt.wantReads {
+				t.Errorf("GetCredentials() logins = %d, reads = %d, want %d, %d", fv.logins, fv.reads, tt.wantLogins, tt.wantReads)
+			}
+		})
+	}
+}
+
+func TestNewVaultProvider_CACert(t *testing.T) {
+	dir := t.TempDir()
+	invalidCAPath := filepath.Join(dir, "invalid-ca.crt")
+	ioutil.WriteFile(invalidCAPath, []byte("not a certificate"), 0600)
+	tests := []struct {
+		name    string
+		conf    config.VaultConfig
+		wantErr bool
+	}{
+		{
+			name: "no CA certificate",
+			conf: config.VaultConfig{Address: "https://vault:8200"},
+		},
+		{
+			name:    "missing CA certificate",
+			conf:    config.VaultConfig{Address: "https://vault:8200", CACertPath: filepath.Join(dir, "missing.crt")},
+			wantErr: true,
+		},
+		{
+			name:    "invalid CA certificate",
+			conf:    config.VaultConfig{Address: "https://vault:8200", CACertPath: invalidCAPath},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newVaultProvider(tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("newVaultProvider() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
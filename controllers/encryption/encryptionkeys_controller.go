@@ -0,0 +1,306 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package controllers ...
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+)
+
+// reEncryptRetryInterval is the time after which re-encryption of failed bmc objects and secrets is retried
+const reEncryptRetryInterval = time.Minute
+
+// EncryptionKeysReconciler re-encrypts the passwords of all bmc objects and the passwords stored in the
+// ODIM and BMC credential secrets with the active key when the keys secret is modified
+type EncryptionKeysReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// KeysLoader reloads the encryption keys and the TLS material when the keys secret is modified,
+// it runs on every replica so that replicas which are not the leader do not keep stale keys
+type KeysLoader struct {
+	Cache cache.Cache
+}
+
+var podName = os.Getenv("POD_NAME")
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=bmcs,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=odims,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=passwordrotationpolicies,verbs=get;list;watch
+
+// Reconcile loads the keys from the keys secret and re-encrypts bmc passwords and secret passwords
+// which are not encrypted with the active key
+func (r *EncryptionKeysReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.EncryptionKeysActionID, constants.EncryptionKeysActionName, podName)
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, req.NamespacedName, secret)
+	if err != nil {
+		if Error.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	// keys are loaded by the KeysLoader as well, loading them here makes sure
+	// re-encryption does not run before the loader has seen the new keys
+	if !loadKeys(ctx, secret) {
+		return ctrl.Result{}, nil
+	}
+	failed := r.reEncryptBmcPasswords(ctx) + r.reEncryptSecretPasswords(ctx)
+	if failed > 0 {
+		l.LogWithFields(ctx).Info(fmt.Sprintf("Could not re-encrypt %d passwords, retrying in %v", failed, reEncryptRetryInterval))
+		return ctrl.Result{RequeueAfter: reEncryptRetryInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EncryptionKeysReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}, builder.WithPredicates(predicate.NewPredicateFuncs(isKeysSecret))).
+		Complete(r)
+}
+
+// Start registers a handler on the secret informer which loads the keys whenever the keys secret is added or updated
+func (kl *KeysLoader) Start(ctx context.Context) error {
+	informer, err := kl.Cache.GetInformer(ctx, &corev1.Secret{})
+	if err != nil {
+		return err
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    func(obj interface{}) { kl.onSecret(ctx, obj) },
+		UpdateFunc: func(_, obj interface{}) { kl.onSecret(ctx, obj) },
+	})
+	<-ctx.Done()
+	return nil
+}
+
+// NeedLeaderElection returns false, keys are needed on every replica to decrypt passwords
+func (kl *KeysLoader) NeedLeaderElection() bool {
+	return false
+}
+
+func (kl *KeysLoader) onSecret(ctx context.Context, obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok || !isKeysSecret(secret) {
+		return
+	}
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.EncryptionKeysActionID, constants.EncryptionKeysActionName, podName)
+	loadKeys(ctx, secret)
+}
+
+// isKeysSecret returns true for the secret holding the keys and certificates of the operator
+func isKeysSecret(obj client.Object) bool {
+	return obj.GetName() == config.Data.SecretName && obj.GetNamespace() == config.Data.Namespace
+}
+
+// loadKeys loads the root CA, the event listener certificate, the key pair and the previous private keys
+// from the keys secret, returns false when the keys could not be loaded and the keys loaded earlier are kept
+func loadKeys(ctx context.Context, secret *corev1.Secret) bool {
+	// TLS material is reloaded first, it is independent of the encryption keys
+	err := utils.LoadTLSMaterialFromSecret(secret.Data)
+	if err != nil {
+		// keep using the certificates loaded earlier
+		l.LogWithFields(ctx).Errorf("Error loading TLS material from %s secret: %s", secret.Name, err.Error())
+	}
+	privateKey, publicKey, previousKeys, err := utils.ParseKeysFromSecret(secret.Data)
+	if err != nil {
+		// keep using the keys loaded earlier
+		l.LogWithFields(ctx).Errorf("Error loading keys from %s secret: %s", secret.Name, err.Error())
+		return false
+	}
+	if utils.GetActiveKeyID() != utils.GetKeyID(publicKey) {
+		l.LogWithFields(ctx).Info(fmt.Sprintf("Encryption key is changed, new key ID is %s", utils.GetKeyID(publicKey)))
+	}
+	utils.SetEncryptionKeys(privateKey, publicKey, previousKeys)
+	return true
+}
+
+// reEncryptBmcPasswords re-encrypts the password and old password of bmc objects with the active key,
+// returns the number of bmc objects which could not be re-encrypted
+func (r *EncryptionKeysReconciler) reEncryptBmcPasswords(ctx context.Context) int {
+	bmcList := &infraiov1.BmcList{}
+	err := r.List(ctx, bmcList)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Error fetching BMC objects: " + err.Error())
+		return 1
+	}
+	var failed int
+	activeKeyID := utils.GetActiveKeyID()
+	for i := range bmcList.Items {
+		bmcObj := &bmcList.Items[i]
+		reEncrypted := map[string]string{}
+		password, ok := reEncrypt(ctx, bmcObj.Spec.Credentials.Password, activeKeyID, reEncrypted)
+		if !ok {
+			failed++
+			continue
+		}
+		oldPassword, ok := reEncrypt(ctx, bmcObj.ObjectMeta.Annotations["old_password"], activeKeyID, reEncrypted)
+		if !ok {
+			failed++
+			continue
+		}
+		if len(reEncrypted) == 0 {
+			continue
+		}
+		bmcObj.Spec.Credentials.Password = password
+		if oldPassword != "" {
+			bmcObj.ObjectMeta.Annotations["old_password"] = oldPassword
+		}
+		err = r.Update(ctx, bmcObj)
+		if err != nil {
+			l.LogWithFields(ctx).Error(fmt.Sprintf("Error: Updating %s BMC object: %s", bmcObj.Spec.BmcDetails.Address, err.Error()))
+			failed++
+			continue
+		}
+		l.LogWithFields(ctx).Info(fmt.Sprintf("Password of %s BMC is re-encrypted with key %s", bmcObj.Spec.BmcDetails.Address, activeKeyID))
+	}
+	return failed
+}
+
+// reEncrypt returns the cipher text encrypted with the active key, cipher texts already re-encrypted
+// are reused so that password and old password stay identical when they were identical
+func reEncrypt(ctx context.Context, cipherText, activeKeyID string, reEncrypted map[string]string) (string, bool) {
+	// plain text passwords are not yet encrypted by the bmc controller
+	if !utils.IsEncrypted(cipherText) || utils.GetCipherTextKeyID(cipherText) == activeKeyID {
+		return cipherText, true
+	}
+	if newCipherText, ok := reEncrypted[cipherText]; ok {
+		return newCipherText, true
+	}
+	plainText := utils.DecryptWithPrivateKey(ctx, cipherText, *utils.GetPrivateKey(), true) //doBase64Decode = true, because bmc password is encrypted n encoded
+	if plainText == "" {
+		return cipherText, false
+	}
+	newCipherText := utils.EncryptWithPublicKey(ctx, plainText, *utils.GetPublicKey())
+	if newCipherText == "" {
+		return cipherText, false
+	}
+	reEncrypted[cipherText] = newCipherText
+	return newCipherText, true
+}
+
+// reEncryptSecretPasswords re-encrypts the password of the ODIM secret, the credential secrets of bmc objects
+// and the secrets of rotated passwords with the active key, returns the number of secrets which could not be re-encrypted
+func (r *EncryptionKeysReconciler) reEncryptSecretPasswords(ctx context.Context) int {
+	secretNames, failed := r.getPasswordSecretNames(ctx)
+	for _, secretName := range secretNames {
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Name: secretName, Namespace: config.Data.Namespace}, secret)
+		if err != nil {
+			if Error.IsNotFound(err) {
+				// credentials are read from a provider other than kubernetes secrets
+				continue
+			}
+			l.LogWithFields(ctx).Errorf("Error getting %s secret: %s", secretName, err.Error())
+			failed++
+			continue
+		}
+		password := secret.Data["password"]
+		if len(password) == 0 || utils.IsEncryptedWithActiveKey(password) {
+			continue
+		}
+		plainText := utils.DecryptWithPrivateKey(ctx, string(password), *utils.GetPrivateKey(), false) //doBase64Decode = false, because password fetched from secret is already decoded
+		if plainText == "" {
+			failed++
+			continue
+		}
+		cipherText := utils.EncryptToBytes(ctx, plainText, *utils.GetPublicKey())
+		if len(cipherText) == 0 {
+			failed++
+			continue
+		}
+		secret.Data["password"] = cipherText
+		err = r.Update(ctx, secret)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error updating %s secret: %s", secretName, err.Error())
+			failed++
+			continue
+		}
+		l.LogWithFields(ctx).Info(fmt.Sprintf("Password of %s secret is re-encrypted with key %s", secretName, utils.GetActiveKeyID()))
+	}
+	return failed
+}
+
+// getPasswordSecretNames returns the names of the secrets holding passwords encrypted by the operator,
+// and the number of objects which could not be listed
+func (r *EncryptionKeysReconciler) getPasswordSecretNames(ctx context.Context) ([]string, int) {
+	var failed int
+	names := map[string]bool{}
+	odimList := &infraiov1.OdimList{}
+	if err := r.List(ctx, odimList, client.InNamespace(config.Data.Namespace)); err != nil {
+		l.LogWithFields(ctx).Error("Error fetching ODIM objects: " + err.Error())
+		failed++
+	}
+	for _, odimObj := range odimList.Items {
+		for field, val := range odimObj.Annotations {
+			if strings.Contains(field, "auth") {
+				names[val] = true
+			}
+		}
+	}
+	bmcList := &infraiov1.BmcList{}
+	if err := r.List(ctx, bmcList, client.InNamespace(config.Data.Namespace)); err != nil {
+		l.LogWithFields(ctx).Error("Error fetching BMC objects: " + err.Error())
+		failed++
+	}
+	for _, bmcObj := range bmcList.Items {
+		if bmcObj.Spec.Credentials.CredentialRef != "" {
+			names[bmcObj.Spec.Credentials.CredentialRef] = true
+		}
+	}
+	policyList := &infraiov1.PasswordRotationPolicyList{}
+	if err := r.List(ctx, policyList, client.InNamespace(config.Data.Namespace)); err != nil {
+		l.LogWithFields(ctx).Error("Error fetching password rotation policies: " + err.Error())
+		failed++
+	}
+	for _, policy := range policyList.Items {
+		for _, rotation := range policy.Status.Bmcs {
+			if rotation.SecretName != "" {
+				names[rotation.SecretName] = true
+			}
+		}
+	}
+	secretNames := make([]string, 0, len(names))
+	for name := range names {
+		secretNames = append(secretNames, name)
+	}
+	return secretNames, failed
+}
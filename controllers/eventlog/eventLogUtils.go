@@ -0,0 +1,207 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package controllers ...
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	pollData "github.com/ODIM-Project/BMCOperator/controllers/pollData"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+const (
+	// defaultMaxEvents is the number of events kept per BMC when eventLog.maxEvents is not configured
+	defaultMaxEvents = 50
+
+	severityCritical = "Critical"
+	severityWarning  = "Warning"
+)
+
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=bmceventlogs,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=bmceventlogs/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=bmceventlogs/finalizers,verbs=update
+
+// EventLogInterface declares method signatures to be defined by event log utils
+type EventLogInterface interface {
+	RecordEvents(events []pollData.OdimEvent)
+}
+
+type eventLogUtils struct {
+	ctx       context.Context
+	commonRec utils.ReconcilerInterface
+	namespace string
+}
+
+// GetEventLogUtils will return eventLogUtils struct
+func GetEventLogUtils(ctx context.Context, commonRec utils.ReconcilerInterface, ns string) EventLogInterface {
+	return &eventLogUtils{
+		ctx:       ctx,
+		commonRec: commonRec,
+		namespace: ns,
+	}
+}
+
+// RecordEvents appends the events to the BmcEventLog object of the BMC which originated them,
+// events are expanded with the resolution of the message found in the EventsMessageRegistry objects
+func (eu *eventLogUtils) RecordEvents(events []pollData.OdimEvent) {
+	maxEvents, maxAge := getRetention()
+	if maxEvents == 0 {
+		return
+	}
+	bmcEvents := map[string][]infraiov1.BmcEvent{}
+	bmcNames := []string{}
+	for _, event := range events {
+		if event.OriginOfCondition == nil {
+			continue
+		}
+		bmcObj := utils.GetBmcObjectForOrigin(eu.ctx, eu.commonRec, event.OriginOfCondition.Oid, eu.namespace)
+		if bmcObj == nil {
+			continue
+		}
+		if _, ok := bmcEvents[bmcObj.Name]; !ok {
+			bmcNames = append(bmcNames, bmcObj.Name)
+		}
+		bmcEvents[bmcObj.Name] = append(bmcEvents[bmcObj.Name], eu.expandEvent(event))
+	}
+	for _, bmcName := range bmcNames {
+		err := eu.appendEvents(bmcName, bmcEvents[bmcName], maxEvents, maxAge)
+		if err != nil {
+			l.LogWithFields(eu.ctx).Error(fmt.Sprintf("Error recording events of %s BMC: %s", bmcName, err.Error()))
+		}
+	}
+}
+
+// expandEvent converts the event to BmcEvent, the severity, message and resolution missing in the event
+// are taken from the message registry
+func (eu *eventLogUtils) expandEvent(event pollData.OdimEvent) infraiov1.BmcEvent {
+	bmcEvent := infraiov1.BmcEvent{
+		EventID:           event.EventID,
+		EventType:         event.EventType,
+		Severity:          event.Severity,
+		MessageID:         event.MessageID,
+		Message:           event.Message,
+		EventTimestamp:    event.EventTimestamp,
+		OriginOfCondition: event.OriginOfCondition.Oid,
+		ReceivedTime:      metav1.Now(),
+	}
+	message := eu.getRegistryMessage(event.MessageID)
+	if message == nil {
+		return bmcEvent
+	}
+	bmcEvent.Resolution = message.Resolution
+	if bmcEvent.Severity == "" {
+		bmcEvent.Severity = message.Severity
+	}
+	if bmcEvent.Message == "" {
+		bmcEvent.Message = message.Message
+	}
+	return bmcEvent
+}
+
+// getRegistryMessage returns the message of the message ID from the EventsMessageRegistry object
+// of the registry prefix and version of the message ID
+func (eu *eventLogUtils) getRegistryMessage(messageID string) *infraiov1.EventMessage {
+	registryObj, err := utils.GetEventMessageRegistryForMessage(eu.ctx, eu.commonRec, messageID, eu.namespace)
+	if err != nil || registryObj == nil {
+		return nil
+	}
+	if message, ok := registryObj.Spec.Messages[messageID[strings.LastIndex(messageID, ".")+1:]]; ok {
+		return &message
+	}
+	return nil
+}
+
+// appendEvents adds the events to the BmcEventLog object of the BMC, the object is created when not present
+func (eu *eventLogUtils) appendEvents(bmcName string, events []infraiov1.BmcEvent, maxEvents int, maxAge time.Duration) error {
+	k8sClient := eu.commonRec.GetCommonReconcilerClient()
+	key := types.NamespacedName{Name: bmcName, Namespace: eu.namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		eventLogObj := &infraiov1.BmcEventLog{}
+		err := k8sClient.Get(eu.ctx, key, eventLogObj)
+		if Error.IsNotFound(err) {
+			eventLogObj = &infraiov1.BmcEventLog{
+				ObjectMeta: metav1.ObjectMeta{Name: bmcName, Namespace: eu.namespace},
+				Spec:       infraiov1.BmcEventLogSpec{Bmc: bmcName},
+			}
+			err = k8sClient.Create(eu.ctx, eventLogObj)
+		}
+		if err != nil {
+			return err
+		}
+		updateEventLogStatus(&eventLogObj.Status, events, maxEvents, maxAge, time.Now())
+		return k8sClient.Status().Update(eu.ctx, eventLogObj)
+	})
+}
+
+// updateEventLogStatus appends the events and drops the events older than maxAge and the oldest events beyond maxEvents
+func updateEventLogStatus(status *infraiov1.BmcEventLogStatus, events []infraiov1.BmcEvent, maxEvents int, maxAge time.Duration, now time.Time) {
+	allEvents := append(status.Events, events...)
+	retained := []infraiov1.BmcEvent{}
+	for _, event := range allEvents {
+		if maxAge > 0 && now.Sub(event.ReceivedTime.Time) > maxAge {
+			continue
+		}
+		retained = append(retained, event)
+	}
+	if len(retained) > maxEvents {
+		retained = retained[len(retained)-maxEvents:]
+	}
+	status.Events = retained
+	status.CriticalEvents, status.WarningEvents = 0, 0
+	for _, event := range retained {
+		switch {
+		case strings.EqualFold(event.Severity, severityCritical):
+			status.CriticalEvents++
+		case strings.EqualFold(event.Severity, severityWarning):
+			status.WarningEvents++
+		}
+	}
+	status.LastEventTime, status.LastEventMessage = nil, ""
+	if len(retained) > 0 {
+		lastEvent := retained[len(retained)-1]
+		status.LastEventTime = lastEvent.ReceivedTime.DeepCopy()
+		status.LastEventMessage = lastEvent.Message
+	}
+}
+
+// getRetention returns the number of events and the duration for which events are kept per BMC,
+// events are not recorded when maxEvents is 0 and are kept regardless of their age when maxAge is not configured
+func getRetention() (int, time.Duration) {
+	maxEvents := defaultMaxEvents
+	if config.Data.EventLog.MaxEvents != "" {
+		val, err := strconv.Atoi(config.Data.EventLog.MaxEvents)
+		if err == nil && val >= 0 {
+			maxEvents = val
+		}
+	}
+	maxAge, err := time.ParseDuration(config.Data.EventLog.MaxAge)
+	if err != nil {
+		maxAge = 0
+	}
+	return maxEvents, maxAge
+}
@@ -0,0 +1,117 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_updateEventLogStatus(t *testing.T) {
+	now := time.Now()
+	event := func(messageID, severity string, age time.Duration) infraiov1.BmcEvent {
+		return infraiov1.BmcEvent{MessageID: messageID, Severity: severity, Message: messageID, ReceivedTime: metav1.NewTime(now.Add(-age))}
+	}
+	tests := []struct {
+		name         string
+		existing     []infraiov1.BmcEvent
+		events       []infraiov1.BmcEvent
+		maxEvents    int
+		maxAge       time.Duration
+		wantIDs      []string
+		wantCritical int
+		wantWarning  int
+	}{
+		{
+			name:         "append to empty log",
+			events:       []infraiov1.BmcEvent{event("A", "Critical", 0), event("B", "Warning", 0)},
+			maxEvents:    10,
+			wantIDs:      []string{"A", "B"},
+			wantCritical: 1,
+			wantWarning:  1,
+		},
+		{
+			name:         "oldest events dropped beyond maxEvents",
+			existing:     []infraiov1.BmcEvent{event("A", "Critical", 2*time.Minute), event("B", "OK", time.Minute)},
+			events:       []infraiov1.BmcEvent{event("C", "Warning", 0)},
+			maxEvents:    2,
+			wantIDs:      []string{"B", "C"},
+			wantCritical: 0,
+			wantWarning:  1,
+		},
+		{
+			name:         "events older than maxAge dropped",
+			existing:     []infraiov1.BmcEvent{event("A", "Critical", 48*time.Hour), event("B", "critical", time.Hour)},
+			events:       []infraiov1.BmcEvent{event("C", "OK", 0)},
+			maxEvents:    10,
+			maxAge:       24 * time.Hour,
+			wantIDs:      []string{"B", "C"},
+			wantCritical: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := &infraiov1.BmcEventLogStatus{Events: tt.existing}
+			updateEventLogStatus(status, tt.events, tt.maxEvents, tt.maxAge, now)
+			gotIDs := []string{}
+			for _, e := range status.Events {
+				gotIDs = append(gotIDs, e.MessageID)
+			}
+			if len(gotIDs) != len(tt.wantIDs) {
+				t.Fatalf("updateEventLogStatus() events = %v, want %v", gotIDs, tt.wantIDs)
+			}
+			for i := range gotIDs {
+				if gotIDs[i] != tt.wantIDs[i] {
+					t.Fatalf("updateEventLogStatus() events = %v, want %v", gotIDs, tt.wantIDs)
+				}
+			}
+			if status.CriticalEvents != tt.wantCritical || status.WarningEvents != tt.wantWarning {
+				t.Errorf("updateEventLogStatus() critical = %d, warning = %d, want %d, %d", status.CriticalEvents, status.WarningEvents, tt.wantCritical, tt.wantWarning)
+			}
+			if status.LastEventMessage != tt.wantIDs[len(tt.wantIDs)-1] {
+				t.Errorf("updateEventLogStatus() last event = %s, want %s", status.LastEventMessage, tt.wantIDs[len(tt.wantIDs)-1])
+			}
+		})
+	}
+}
+
+func Test_getRetention(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxEvents     string
+		maxAge        string
+		wantMaxEvents int
+		wantMaxAge    time.Duration
+	}{
+		{name: "not configured", wantMaxEvents: defaultMaxEvents},
+		{name: "configured", maxEvents: "20", maxAge: "168h", wantMaxEvents: 20, wantMaxAge: 168 * time.Hour},
+		{name: "disabled", maxEvents: "0", wantMaxEvents: 0},
+		{name: "invalid", maxEvents: "many", maxAge: "week", wantMaxEvents: defaultMaxEvents},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Data.EventLog = config.EventLogConfig{MaxEvents: tt.maxEvents, MaxAge: tt.maxAge}
+			gotMaxEvents, gotMaxAge := getRetention()
+			if gotMaxEvents != tt.wantMaxEvents || gotMaxAge != tt.wantMaxAge {
+				t.Errorf("getRetention() = %d, %v, want %d, %v", gotMaxEvents, gotMaxAge, tt.wantMaxEvents, tt.wantMaxAge)
+			}
+		})
+	}
+	config.Data.EventLog = config.EventLogConfig{}
+}
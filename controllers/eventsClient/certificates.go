@@ -17,12 +17,12 @@ package controllers
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net"
 	"net/http"
 
 	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
 	l "github.com/ODIM-Project/BMCOperator/logs"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,24 +43,33 @@ func GetHTTPServerObj(ecr *EventsClientReconciler) (*http.Server, error) {
 	}, nil
 }
 
-// LoadCertificates is for including passed certificates in tls.Config
+// LoadCertificates is for including passed certificates in tls.Config, the certificate, the CA pool and the
+// client authentication policy are resolved on every handshake so that changes apply without restarting the listener
 func LoadCertificates(tlsConfig *tls.Config, ecr *EventsClientReconciler) error {
 
 	rootCA, eventClientCert, eventClientKey := GetSignedCertificatesForEventClient(context.TODO(), ecr)
 
-	cert, err := tls.X509KeyPair(eventClientCert, eventClientKey)
-	if err != nil {
-		return fmt.Errorf("error: failed to load key pair: %v", err)
+	if err := utils.SetEventListenerCertificate(eventClientCert, eventClientKey); err != nil {
+		return fmt.Errorf("error: %v", err)
 	}
-	tlsConfig.Certificates = []tls.Certificate{cert}
-
-	capool := x509.NewCertPool()
-	if !capool.AppendCertsFromPEM(rootCA) {
-		return fmt.Errorf("error: failed to load CA certificate")
+	if err := utils.SetRootCA(rootCA); err != nil {
+		return fmt.Errorf("error: %v", err)
 	}
 
-	tlsConfig.RootCAs = capool
-	tlsConfig.ClientCAs = capool
+	tlsConfig.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return utils.GetEventListenerCertificate()
+	}
+	tlsConfig.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		clientConfig := tlsConfig.Clone()
+		clientConfig.GetConfigForClient = nil
+		clientConfig.RootCAs = utils.GetRootCAPool()
+		clientConfig.ClientCAs = utils.GetRootCAPool()
+		clientConfig.ClientAuth, clientConfig.VerifyConnection = getClientVerification(config.Data.EventListener.ClientAuth)
+		return clientConfig, nil
+	}
+	tlsConfig.RootCAs = utils.GetRootCAPool()
+	tlsConfig.ClientCAs = utils.GetRootCAPool()
+	tlsConfig.ClientAuth, tlsConfig.VerifyConnection = getClientVerification(config.Data.EventListener.ClientAuth)
 	return nil
 }
 
@@ -73,7 +82,5 @@ func GetSignedCertificatesForEventClient(ctx context.Context, ecr *EventsClientR
 		l.LogWithFields(ctx).Error(fmt.Sprintf("Error fetching %s secret", config.Data.SecretName), err.Error())
 		return
 	}
-	return secret.Data["rootCACert"],
-		secret.Data["eventClientCert"],
-		secret.Data["eventClientKey"]
+	return utils.GetTLSMaterial(secret.Data)
 }
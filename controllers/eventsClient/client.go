@@ -17,16 +17,22 @@ package controllers
 import (
 	"context"
 	"encoding/json"
-	"flag"
+	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/ODIM-Project/BMCOperator/config/constants"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
 	sync "github.com/ODIM-Project/BMCOperator/controllers/pollData"
 	l "github.com/ODIM-Project/BMCOperator/logs"
 	"github.com/google/uuid"
 	"github.com/kataras/iris/v12"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,42 +42,142 @@ var podName = os.Getenv("POD_NAME")
 type EventsClientReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+	// APIReader reads pods from the API server, pods are not cached by the manager
+	APIReader client.Reader
 }
 
 var ecr *EventsClientReconciler
 
-// Start initialize the event client listener
-func Start(client client.Client, scheme *runtime.Scheme) {
+const (
+	// ListenerPodLabel is the label of the pod running the event client listener, the event listener service selects it
+	ListenerPodLabel = "infra.io.odimra/event-listener"
+	shutdownTimeout  = 10 * time.Second
+)
 
-	podName := os.Getenv("POD_NAME")
-	ecr = &EventsClientReconciler{
-		Client: client,
-		Scheme: scheme,
-	}
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;patch
 
+// Start initialize the event client listener, or consumes the event stream of ODIM in SSE mode, it is run by
+// the manager on the elected leader only and returns once the manager is stopped, an error stops the manager
+func (r *EventsClientReconciler) Start(ctx context.Context) error {
+	ecr = r
 	transactionID := uuid.New().String()
-	ctx := context.Background()
 	ctx = l.CreateContextForLogging(ctx, transactionID, constants.BMCOPERATOR,
 		constants.EventClientActionID, constants.EventClientActionName, podName)
 
-	flag.Parse()
-	app := newApp()
-
+	startEventWorkers(ecr)
+	// events are read from the event stream of ODIM, the event listener is not started
+	if isSSEMode() {
+		l.LogWithFields(ctx).Info("Event listener is in SSE mode, events are read from the event stream of ODIM")
+		consumeEventStream(ctx, ecr)
+		return nil
+	}
 	apiServer, err := GetHTTPServerObj(ecr)
 	if err != nil {
-		l.LogWithFields(ctx).Fatal("service initialization failed: " + err.Error())
+		l.LogWithFields(ctx).Error("service initialization failed: " + err.Error())
+		return err
 	}
+	// the mode can be changed to SSE at runtime, the stream is consumed once the mode is changed
+	go consumeEventStream(ctx, ecr)
 
-	err = app.Run(iris.Server(apiServer))
+	app := newApp()
+	if err = r.labelListenerPod(ctx); err != nil {
+		l.LogWithFields(ctx).Errorf("Error while labelling pod %s of the event listener: %s", podName, err.Error())
+		return err
+	}
+	defer r.unlabelListenerPod(ctx)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := app.Shutdown(shutdownCtx); err != nil {
+			l.LogWithFields(ctx).Error("Error while stopping the server", err.Error())
+		}
+	}()
+
+	err = app.Run(iris.Server(apiServer), iris.WithoutInterruptHandler, iris.WithoutServerError(iris.ErrServerClosed))
 	if err != nil {
 		l.LogWithFields(ctx).Error("Error while starting the server", err.Error())
 	}
+	return err
+}
+
+// NeedLeaderElection makes the manager start the event client listener only on the elected leader,
+// so that events are processed once when several replicas are running
+func (r *EventsClientReconciler) NeedLeaderElection() bool {
+	return true
+}
+
+// labelListenerPod adds ListenerPodLabel to the pod running the event client listener and removes it from
+// the pods of former leaders, so that the events sent to the event listener service are routed to the leader only.
+// The patches are retried, the event listener is not started when the pod could not be labelled
+func (r *EventsClientReconciler) labelListenerPod(ctx context.Context) error {
+	if podName == "" {
+		return nil
+	}
+	namespace := getPodNamespace()
+	err := retry.OnError(retry.DefaultBackoff, func(error) bool { return true }, func() error {
+		pods := &corev1.PodList{}
+		err := r.APIReader.List(ctx, pods, client.InNamespace(namespace), client.HasLabels{ListenerPodLabel})
+		if err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			// former leaders which stopped without removing the label
+			if pods.Items[i].Name != podName {
+				if err = r.patchListenerPodLabel(ctx, pods.Items[i].Name, namespace, false); err != nil {
+					return err
+				}
+				l.LogWithFields(ctx).Infof("label of the event listener is removed from pod %s", pods.Items[i].Name)
+			}
+		}
+		return r.patchListenerPodLabel(ctx, podName, namespace, true)
+	})
+	if err != nil {
+		return err
+	}
+	l.LogWithFields(ctx).Infof("pod %s is labelled as the event listener", podName)
+	return nil
+}
+
+// unlabelListenerPod removes ListenerPodLabel from the pod once the event listener is stopped, a label which
+// could not be removed is removed by the next leader
+func (r *EventsClientReconciler) unlabelListenerPod(ctx context.Context) {
+	if podName == "" {
+		return
+	}
+	// ctx is done when the event listener is stopped
+	unlabelCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := r.patchListenerPodLabel(unlabelCtx, podName, getPodNamespace(), false); err != nil {
+		l.LogWithFields(ctx).Errorf("Error while removing the event listener label of pod %s: %s", podName, err.Error())
+		return
+	}
+	l.LogWithFields(ctx).Infof("label of the event listener is removed from pod %s", podName)
+}
+
+// patchListenerPodLabel adds or removes ListenerPodLabel of the pod
+func (r *EventsClientReconciler) patchListenerPodLabel(ctx context.Context, name, namespace string, add bool) error {
+	value := "null"
+	if add {
+		value = `"true"`
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	patch := []byte(fmt.Sprintf(`{"metadata":{"labels":{%q:%s}}}`, ListenerPodLabel, value))
+	return r.Patch(ctx, pod, client.RawPatch(types.MergePatchType, patch))
+}
+
+// getPodNamespace returns the namespace of the pod of the operator
+func getPodNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	return config.Data.Namespace
 }
 
 // newApp creates new iris instance for REST API
 func newApp() *iris.Application {
 	app := iris.New()
-	app.Post("/OdimEvents", triggerReconciler)
+	app.Post("/OdimEvents", verifySender, triggerReconciler)
 
 	return app
 }
@@ -106,9 +212,38 @@ func triggerReconciler(ctx iris.Context) {
 		return
 	}
 
-	for _, event := range messageData.Events {
-		sync.ProcessOdimEvent(ctxt, ecr.Client, ecr.Scheme,
-			messageData.Name, event)
+	if !isContextAllowed(messageData.SubscriptionContext) {
+		l.LogWithFields(ctxt).Warn("Rejecting event with unexpected subscription context " + messageData.SubscriptionContext)
+		eventRequestsRejected.WithLabelValues(rejectUnexpectedContext).Inc()
+		ctx.StatusCode(http.StatusForbidden)
+		return
+	}
+
+	// events are processed asynchronously, so that ODIM is not blocked by long running reconciliations
+	if !queueEvents(ctxt, messageData) {
+		ctx.StatusCode(http.StatusServiceUnavailable)
+		return
 	}
 	ctx.StatusCode(http.StatusOK)
 }
+
+// queueEvents adds the events of the message to the event queue, the events are queued all or none so that
+// ODIM can retry the message, false is returned when the queue is full
+func queueEvents(ctxt context.Context, messageData sync.OdimEventMessage) bool {
+	events := []sync.OdimEvent{}
+	for _, event := range messageData.Events {
+		if event.OriginOfCondition == nil {
+			l.LogWithFields(ctxt).Debug("Ignoring event without OriginOfCondition with messageID " + event.MessageID)
+			continue
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return true
+	}
+	if !odimEventQueue.addEvents(messageData.Name, events) {
+		l.LogWithFields(ctxt).Warn(fmt.Sprintf("Event queue is full, dropping %d events of %s", len(events), messageData.Name))
+		return false
+	}
+	return true
+}
@@ -0,0 +1,198 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	eventlog "github.com/ODIM-Project/BMCOperator/controllers/eventlog"
+	eventsink "github.com/ODIM-Project/BMCOperator/controllers/eventsink"
+	pollData "github.com/ODIM-Project/BMCOperator/controllers/pollData"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+	"k8s.io/client-go/util/workqueue"
+)
+
+const (
+	defaultEventQueueSize    = 1000
+	defaultEventQueueWorkers = 4
+	// maxCoalescedEvents is the number of coalesced events of a key kept for the event log
+	maxCoalescedEvents = 100
+	// systemsURIPrefix is the prefix of the origin of condition of the events of a system
+	systemsURIPrefix = "/redfish/v1/Systems/"
+)
+
+// eventQueue holds the events received from ODIM until they are processed by the workers,
+// events of a system waiting in the queue are coalesced so that the system is reconciled once
+type eventQueue struct {
+	queue   workqueue.Interface
+	mutex   *sync.Mutex
+	pending map[string]queuedEvent
+	size    int
+}
+
+// queuedEvent holds the events received for a coalescing key, the latest events of every resource are used
+// for reconciliation and all the events are recorded in the event log
+type queuedEvent struct {
+	name   string
+	events []pollData.OdimEvent
+}
+
+var (
+	odimEventQueue   *eventQueue
+	startWorkersOnce = &sync.Once{}
+)
+
+// newEventQueue returns a queue which holds at most size coalesced events,
+// the queue depth and latency are exposed as workqueue metrics under the name odim_events
+func newEventQueue(size int) *eventQueue {
+	return &eventQueue{
+		queue:   workqueue.NewNamed("odim_events"),
+		mutex:   &sync.Mutex{},
+		pending: map[string]queuedEvent{},
+		size:    size,
+	}
+}
+
+// startEventWorkers creates the event queue and starts the workers processing it, only once per operator
+func startEventWorkers(ecr *EventsClientReconciler) {
+	startWorkersOnce.Do(func() {
+		odimEventQueue = newEventQueue(getConfigInt(config.Data.EventListener.QueueSize, defaultEventQueueSize))
+		process := func(name string, events []pollData.OdimEvent) {
+			ctx := l.CreateContextForLogging(context.Background(), uuid.New().String(), constants.BMCOPERATOR,
+				constants.EventClientActionID, constants.EventClientActionName, podName)
+			commonRec := utils.GetCommonReconciler(ecr.Client, ecr.Scheme)
+			eventlog.GetEventLogUtils(ctx, commonRec, config.Data.Namespace).RecordEvents(events)
+			eventsink.ForwardEvents(ctx, commonRec, config.Data.Namespace, events)
+			for _, event := range getLatestEvents(events) {
+				pollData.ProcessOdimEvent(ctx, ecr.Client, ecr.Scheme, name, event)
+			}
+		}
+		for i := 0; i < getConfigInt(config.Data.EventListener.Workers, defaultEventQueueWorkers); i++ {
+			go func() {
+				for odimEventQueue.processNextEvent(process) {
+				}
+			}()
+		}
+	})
+}
+
+// addEvents queues the events, events are coalesced with the events of the same system waiting in the queue.
+// The events are queued all or none, false is returned when the queue can not hold all of them
+func (q *eventQueue) addEvents(name string, events []pollData.OdimEvent) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	newKeys := map[string]bool{}
+	for _, event := range events {
+		key := getCoalescingKey(event)
+		if _, ok := q.pending[key]; !ok {
+			newKeys[key] = true
+		}
+	}
+	if len(q.pending)+len(newKeys) > q.size {
+		eventsDropped.Add(float64(len(events)))
+		return false
+	}
+	for _, event := range events {
+		key := getCoalescingKey(event)
+		queued, ok := q.pending[key]
+		queued.name = name
+		queued.events = append(queued.events, event)
+		if len(queued.events) > maxCoalescedEvents {
+			queued.events = queued.events[len(queued.events)-maxCoalescedEvents:]
+		}
+		q.pending[key] = queued
+		if ok {
+			eventsCoalesced.Inc()
+			continue
+		}
+		// a key being processed is queued again by the workqueue once the processing is done
+		q.queue.Add(key)
+	}
+	return true
+}
+
+// processNextEvent waits for the next event and processes it, false is returned when the queue is shut down
+func (q *eventQueue) processNextEvent(process func(name string, events []pollData.OdimEvent)) bool {
+	key, shutdown := q.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer q.queue.Done(key)
+	q.mutex.Lock()
+	queued, ok := q.pending[key.(string)]
+	delete(q.pending, key.(string))
+	q.mutex.Unlock()
+	if ok {
+		process(queued.name, queued.events)
+	}
+	return true
+}
+
+// getCoalescingKey returns the key on which events are coalesced, the URI of the system the origin of condition
+// belongs to. Events of other resources are coalesced on their origin of condition
+func getCoalescingKey(event pollData.OdimEvent) string {
+	oid := strings.TrimSuffix(event.OriginOfCondition.Oid, "/")
+	if strings.HasPrefix(oid, systemsURIPrefix) {
+		if idx := strings.Index(oid[len(systemsURIPrefix):], "/"); idx >= 0 {
+			return oid[:len(systemsURIPrefix)+idx]
+		}
+	}
+	return oid
+}
+
+// getLatestEvents returns the latest event of every origin of condition and kind of event among the coalesced
+// events of a system, in the order they were received. ResourceAdded and ResourceRemoved events of a resource
+// share the kind as only the latest of them is relevant
+func getLatestEvents(events []pollData.OdimEvent) []pollData.OdimEvent {
+	latest := map[string]int{}
+	for i, event := range events {
+		latest[event.OriginOfCondition.Oid+"|"+getEventKind(event)] = i
+	}
+	latestEvents := []pollData.OdimEvent{}
+	for i, event := range events {
+		if latest[event.OriginOfCondition.Oid+"|"+getEventKind(event)] == i {
+			latestEvents = append(latestEvents, event)
+		}
+	}
+	return latestEvents
+}
+
+// getEventKind returns the kind of the event used to find the latest events of a resource
+func getEventKind(event pollData.OdimEvent) string {
+	if strings.Contains(event.MessageID, "ResourceAdded") || strings.Contains(event.MessageID, "ResourceRemoved") {
+		return "ResourceMembership"
+	}
+	if idx := strings.LastIndex(event.MessageID, "."); idx >= 0 {
+		// ignore the registry prefix and version, for example iLOEvents.3.2.ServerPostComplete
+		return event.MessageID[idx+1:]
+	}
+	return event.MessageID
+}
+
+// getConfigInt returns the positive integer value of the config parameter or the default value
+func getConfigInt(value string, defaultValue int) int {
+	val, err := strconv.Atoi(value)
+	if err != nil || val <= 0 {
+		return defaultValue
+	}
+	return val
+}
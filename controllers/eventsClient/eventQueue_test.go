@@ -0,0 +1,131 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	pollData "github.com/ODIM-Project/BMCOperator/controllers/pollData"
+)
+
+func newOdimEvent(messageID, oid string) pollData.OdimEvent {
+	return pollData.OdimEvent{MessageID: messageID, OriginOfCondition: &pollData.Link{Oid: oid}}
+}
+
+func Test_eventQueue(t *testing.T) {
+	q := newEventQueue(2)
+	messages := []struct {
+		events    []pollData.OdimEvent
+		wantAdded bool
+	}{
+		{events: []pollData.OdimEvent{newOdimEvent("ResourceEvent.1.2.0.ResourceAdded", "/redfish/v1/Systems/1")}, wantAdded: true},
+		// coalesced with the events of the same system
+		{
+			events: []pollData.OdimEvent{
+				newOdimEvent("ResourceEvent.1.2.0.ResourceRemoved", "/redfish/v1/Systems/1"),
+				newOdimEvent("ResourceEvent.1.2.0.ResourceUpdated", "/redfish/v1/Systems/1/Bios"),
+			},
+			wantAdded: true,
+		},
+		{events: []pollData.OdimEvent{newOdimEvent("iLOEvents.3.2.ServerPostComplete", "/redfish/v1/Systems/2")}, wantAdded: true},
+		// queue is full, no event of the message is queued
+		{
+			events: []pollData.OdimEvent{
+				newOdimEvent("ResourceEvent.1.2.0.ResourceUpdated", "/redfish/v1/Systems/1/Storage/1"),
+				newOdimEvent("ResourceEvent.1.2.0.ResourceAdded", "/redfish/v1/Systems/3"),
+			},
+			wantAdded: false,
+		},
+		// coalesced even when the queue is full
+		{events: []pollData.OdimEvent{newOdimEvent("iLOEvents.3.2.ServerPostComplete", "/redfish/v1/Systems/1")}, wantAdded: true},
+	}
+	for _, m := range messages {
+		if got := q.addEvents("Event", m.events); got != m.wantAdded {
+			t.Errorf("addEvents(%s, %s) = %v, want %v", m.events[0].MessageID, m.events[0].OriginOfCondition.Oid, got, m.wantAdded)
+		}
+	}
+	if q.queue.Len() != 2 {
+		t.Fatalf("queue length = %d, want 2", q.queue.Len())
+	}
+	processed := [][]string{}
+	coalesced := 0
+	process := func(name string, events []pollData.OdimEvent) {
+		latest := []string{}
+		for _, event := range getLatestEvents(events) {
+			latest = append(latest, event.MessageID)
+		}
+		processed = append(processed, latest)
+		coalesced += len(events)
+	}
+	q.processNextEvent(process)
+	q.processNextEvent(process)
+	want := [][]string{
+		{"ResourceEvent.1.2.0.ResourceRemoved", "ResourceEvent.1.2.0.ResourceUpdated", "iLOEvents.3.2.ServerPostComplete"},
+		{"iLOEvents.3.2.ServerPostComplete"},
+	}
+	if !reflect.DeepEqual(processed, want) {
+		t.Errorf("processed events = %v, want %v", processed, want)
+	}
+	// coalesced events are all passed to be recorded in the event log
+	if coalesced != 5 {
+		t.Errorf("number of processed events = %d, want 5", coalesced)
+	}
+	// queue accepts events again once processed
+	if !q.addEvents("Event", []pollData.OdimEvent{newOdimEvent("ResourceEvent.1.2.0.ResourceAdded", "/redfish/v1/Systems/3")}) {
+		t.Errorf("addEvents() = false after the queue is drained")
+	}
+	q.queue.ShutDown()
+}
+
+func Test_getCoalescingKey(t *testing.T) {
+	tests := []struct {
+		name string
+		oid  string
+		want string
+	}{
+		{name: "system", oid: "/redfish/v1/Systems/1", want: "/redfish/v1/Systems/1"},
+		{name: "sub-resource of a system", oid: "/redfish/v1/Systems/1/Storage/ArrayControllers-0/Volumes/1", want: "/redfish/v1/Systems/1"},
+		{name: "system with trailing slash", oid: "/redfish/v1/Systems/1/", want: "/redfish/v1/Systems/1"},
+		{name: "other resource", oid: "/redfish/v1/Managers/1/LogServices", want: "/redfish/v1/Managers/1/LogServices"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getCoalescingKey(newOdimEvent("ResourceEvent.1.2.0.ResourceUpdated", tt.oid)); got != tt.want {
+				t.Errorf("getCoalescingKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getConfigInt(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  int
+	}{
+		{name: "configured", value: "8", want: 8},
+		{name: "empty", value: "", want: 4},
+		{name: "not a number", value: "four", want: 4},
+		{name: "negative", value: "-1", want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getConfigInt(tt.value, 4); got != tt.want {
+				t.Errorf("getConfigInt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
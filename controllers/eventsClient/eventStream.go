@@ -0,0 +1,175 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	pollData "github.com/ODIM-Project/BMCOperator/controllers/pollData"
+	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+)
+
+const (
+	// SSEMode is the event listener mode in which the operator consumes the Server-Sent Events stream of ODIM
+	// instead of receiving events on its listener
+	SSEMode = "SSE"
+
+	sseURI             = "/redfish/v1/EventService/SSE"
+	sseMinRetryBackoff = 5 * time.Second
+	sseMaxRetryBackoff = 5 * time.Minute
+	// sseModeCheckInterval is the interval at which the mode is checked while the stream is not consumed
+	sseModeCheckInterval = 30 * time.Second
+)
+
+// eventStreamer opens the Server-Sent Events stream of ODIM
+type eventStreamer interface {
+	Stream(ctx context.Context, uri, reason string) (*http.Response, error)
+}
+
+func isSSEMode() bool {
+	return strings.EqualFold(config.Data.EventListener.Mode, SSEMode)
+}
+
+// consumeEventStream reads the event stream of ODIM while the event listener is in SSE mode and reconnects with
+// an exponential backoff when it ends, the mode configured at runtime is followed until ctx is done
+func consumeEventStream(ctx context.Context, ecr *EventsClientReconciler) {
+	backoff := sseMinRetryBackoff
+	for {
+		if !isSSEMode() {
+			if !waitOrDone(ctx, sseModeCheckInterval) {
+				return
+			}
+			continue
+		}
+		streamCtx := l.CreateContextForLogging(ctx, uuid.New().String(), constants.BMCOPERATOR,
+			constants.EventClientActionID, constants.EventClientActionName, podName)
+		connectedAt := time.Now()
+		err := streamEvents(streamCtx, ecr)
+		sseConnected.Set(0)
+		if ctx.Err() != nil {
+			l.LogWithFields(streamCtx).Info("Stopped consuming the event stream of ODIM")
+			return
+		}
+		if time.Since(connectedAt) > sseMaxRetryBackoff {
+			backoff = sseMinRetryBackoff
+		}
+		l.LogWithFields(streamCtx).Warnf("Event stream of ODIM ended: %v, reconnecting in %s", err, backoff)
+		if !waitOrDone(ctx, backoff) {
+			return
+		}
+		backoff *= 2
+		if backoff > sseMaxRetryBackoff {
+			backoff = sseMaxRetryBackoff
+		}
+	}
+}
+
+// waitOrDone waits for the duration, false is returned when ctx is done before
+func waitOrDone(ctx context.Context, duration time.Duration) bool {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// streamEvents opens the event stream with the credentials of the Odim object and queues the received events
+func streamEvents(ctx context.Context, ecr *EventsClientReconciler) error {
+	commonRec := utils.GetCommonReconciler(ecr.Client, ecr.Scheme)
+	odimObj := commonRec.GetOdimObject(ctx, constants.MetadataName, "odim", config.Data.Namespace)
+	if odimObj == nil {
+		return fmt.Errorf("odim object not found")
+	}
+	restClient, err := restclient.NewRestClient(ctx, odimObj, commonRec.(*utils.CommonReconciler), constants.BMCOPERATOR)
+	if err != nil {
+		return err
+	}
+	streamer, ok := restClient.(eventStreamer)
+	if !ok {
+		return fmt.Errorf("rest client does not support event streams")
+	}
+	resp, err := streamer.Stream(ctx, getSSEURI(), "Opening event stream of ODIM")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("ODIM responded with status %d", resp.StatusCode)
+	}
+	l.LogWithFields(ctx).Info("Connected to the event stream of ODIM")
+	sseConnected.Set(1)
+	return readEventStream(resp.Body, func(data []byte) bool {
+		var messageData pollData.OdimEventMessage
+		if err := json.Unmarshal(data, &messageData); err != nil {
+			l.LogWithFields(ctx).Error("error while un-marshalling event from the event stream of ODIM: " + err.Error())
+			return isSSEMode()
+		}
+		eventRequestsReceived.Inc()
+		queueEvents(ctx, messageData)
+		return isSSEMode()
+	})
+}
+
+// getSSEURI returns the URI of the event stream with the filter configured in eventListener.sseFilter
+func getSSEURI() string {
+	if config.Data.EventListener.SSEFilter == "" {
+		return sseURI
+	}
+	return sseURI + "?$filter=" + url.PathEscape(config.Data.EventListener.SSEFilter)
+}
+
+// readEventStream parses the Server-Sent Events of the stream and calls handle with the data of each event,
+// reading stops when handle returns false or the stream ends
+func readEventStream(stream io.Reader, handle func(data []byte) bool) error {
+	reader := bufio.NewReader(stream)
+	var data []string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return fmt.Errorf("stream closed by ODIM")
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "":
+			if len(data) != 0 && !handle([]byte(strings.Join(data, "\n"))) {
+				return fmt.Errorf("event listener is not in %s mode", SSEMode)
+			}
+			data = nil
+		case strings.HasPrefix(line, ":"):
+			// comment, ODIM sends them to keep the connection alive
+		case strings.HasPrefix(line, "data:"):
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+}
@@ -0,0 +1,81 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+)
+
+func Test_readEventStream(t *testing.T) {
+	tests := []struct {
+		name   string
+		stream string
+		stopAt int
+		want   []string
+	}{
+		{
+			name:   "events separated by blank lines",
+			stream: ": keep-alive\n\nid: 1\ndata: {\"Name\":\"first\"}\n\ndata: {\"Name\":\"second\"}\r\n\r\n",
+			want:   []string{`{"Name":"first"}`, `{"Name":"second"}`},
+		},
+		{
+			name:   "data spread over several lines",
+			stream: "data: {\"Name\":\ndata: \"multi\"}\n\n",
+			want:   []string{"{\"Name\":\n\"multi\"}"},
+		},
+		{
+			name:   "incomplete event at the end of the stream",
+			stream: "data: {\"Name\":\"first\"}\n\ndata: {\"Name\":",
+			want:   []string{`{"Name":"first"}`},
+		},
+		{
+			name:   "reading stops when the handler returns false",
+			stream: "data: 1\n\ndata: 2\n\ndata: 3\n\n",
+			stopAt: 2,
+			want:   []string{"1", "2"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := readEventStream(strings.NewReader(tt.stream), func(data []byte) bool {
+				got = append(got, string(data))
+				return tt.stopAt == 0 || len(got) < tt.stopAt
+			})
+			if err == nil {
+				t.Errorf("readEventStream() expected an error when reading stops")
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readEventStream() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSSEURI(t *testing.T) {
+	defer func(filter string) { config.Data.EventListener.SSEFilter = filter }(config.Data.EventListener.SSEFilter)
+	config.Data.EventListener.SSEFilter = ""
+	if got := getSSEURI(); got != "/redfish/v1/EventService/SSE" {
+		t.Errorf("getSSEURI() = %s", got)
+	}
+	config.Data.EventListener.SSEFilter = "EventType eq 'Alert'"
+	if got := getSSEURI(); got != "/redfish/v1/EventService/SSE?$filter=EventType%20eq%20%27Alert%27" {
+		t.Errorf("getSSEURI() = %s", got)
+	}
+}
@@ -0,0 +1,60 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// reasons for which requests to the event listener are rejected
+const (
+	rejectMissingClientCert   = "missing_client_certificate"
+	rejectUntrustedClientCert = "untrusted_client_certificate"
+	rejectUnexpectedContext   = "unexpected_context"
+)
+
+var (
+	// eventRequestsReceived counts the requests received by the event listener
+	eventRequestsReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "bmc_operator_event_requests_received_total",
+		Help: "Number of requests received by the event listener",
+	})
+	// eventRequestsRejected counts the requests rejected by the event listener for an unexpected sender
+	eventRequestsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "bmc_operator_event_requests_rejected_total",
+		Help: "Number of requests rejected by the event listener, partitioned by the reason of rejection",
+	}, []string{"reason"})
+	// eventsCoalesced counts the events which replaced an event of the same system waiting in the event queue
+	eventsCoalesced = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "bmc_operator_events_coalesced_total",
+		Help: "Number of events coalesced with an event of the same system waiting in the event queue",
+	})
+	// eventsDropped counts the events which could not be queued as the event queue is full
+	eventsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "bmc_operator_events_dropped_total",
+		Help: "Number of events dropped as the event queue is full",
+	})
+	// sseConnected reports whether the operator is connected to the event stream of ODIM
+	sseConnected = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "bmc_operator_event_stream_connected",
+		Help: "1 when the operator is connected to the Server-Sent Events stream of ODIM, 0 otherwise",
+	})
+)
+
+func init() {
+	// metrics are exposed on the metrics endpoint of the manager
+	metrics.Registry.MustRegister(eventRequestsReceived, eventRequestsRejected, eventsCoalesced, eventsDropped, sseConnected)
+}
@@ -0,0 +1,123 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+	"github.com/kataras/iris/v12"
+)
+
+// supported values of clientAuth in eventListener configuration
+const (
+	noClientCert               = "NoClientCert"
+	verifyClientCertIfGiven    = "VerifyClientCertIfGiven"
+	requireAndVerifyClientCert = "RequireAndVerifyClientCert"
+)
+
+// getClientAuthType returns the client authentication policy of the event listener, client certificates
+// are not requested when clientAuth is not configured, as before clientAuth was introduced
+func getClientAuthType(clientAuth string) tls.ClientAuthType {
+	switch {
+	case clientAuth == "", strings.EqualFold(clientAuth, noClientCert):
+		return tls.NoClientCert
+	case strings.EqualFold(clientAuth, verifyClientCertIfGiven):
+		return tls.VerifyClientCertIfGiven
+	default:
+		// unknown values fall back to the strictest policy
+		return tls.RequireAndVerifyClientCert
+	}
+}
+
+// getClientVerification returns the client authentication policy used in the TLS handshake and the callback
+// verifying the connection, required client certificates are checked in the callback instead of the handshake
+// so that connections without a client certificate are counted before being rejected
+func getClientVerification(clientAuth string) (tls.ClientAuthType, func(tls.ConnectionState) error) {
+	authType := getClientAuthType(clientAuth)
+	if authType != tls.RequireAndVerifyClientCert {
+		return authType, nil
+	}
+	// given certificates are still verified with the root CA during the handshake
+	return tls.VerifyClientCertIfGiven, verifyClientCertPresented
+}
+
+// verifyClientCertPresented rejects the connection when the client did not present a certificate
+func verifyClientCertPresented(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		eventRequestsRejected.WithLabelValues(rejectMissingClientCert).Inc()
+		return errors.New("client certificate is required")
+	}
+	return nil
+}
+
+// verifySender rejects requests whose verified client certificate is not in allowedClientSubjects,
+// all senders are accepted when allowedClientSubjects is empty
+func verifySender(ctx iris.Context) {
+	eventRequestsReceived.Inc()
+	allowedSubjects := config.Data.EventListener.AllowedClientSubjects
+	if len(allowedSubjects) == 0 {
+		ctx.Next()
+		return
+	}
+	ctxt := l.CreateContextForLogging(ctx.Request().Context(), uuid.New().String(), constants.BMCOPERATOR,
+		constants.EventClientActionID, constants.EventClientActionName, podName)
+	req := ctx.Request()
+	if req.TLS == nil || len(req.TLS.VerifiedChains) == 0 || len(req.TLS.VerifiedChains[0]) == 0 {
+		l.LogWithFields(ctxt).Warn("Rejecting event from " + req.RemoteAddr + ", verified client certificate is not presented")
+		eventRequestsRejected.WithLabelValues(rejectMissingClientCert).Inc()
+		ctx.StatusCode(http.StatusUnauthorized)
+		return
+	}
+	clientCert := req.TLS.VerifiedChains[0][0]
+	if !isSubjectAllowed(clientCert, allowedSubjects) {
+		l.LogWithFields(ctxt).Warn("Rejecting event from " + req.RemoteAddr + ", client certificate subject " + clientCert.Subject.String() + " is not allowed")
+		eventRequestsRejected.WithLabelValues(rejectUntrustedClientCert).Inc()
+		ctx.StatusCode(http.StatusForbidden)
+		return
+	}
+	ctx.Next()
+}
+
+// isSubjectAllowed checks if the subject, the common name or one of the DNS names of the certificate is allowed
+func isSubjectAllowed(cert *x509.Certificate, allowedSubjects []string) bool {
+	for _, allowed := range allowedSubjects {
+		if allowed == cert.Subject.String() || allowed == cert.Subject.CommonName {
+			return true
+		}
+		for _, dnsName := range cert.DNSNames {
+			if strings.EqualFold(allowed, dnsName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isContextAllowed checks the context of the event subscription the event is sent for,
+// events of other subscriptions are rejected only when verifyContext is enabled
+func isContextAllowed(subscriptionContext string) bool {
+	if !config.Data.EventListener.VerifyContext {
+		return true
+	}
+	return subscriptionContext == constants.DefaultEventSubscriptionContext
+}
@@ -0,0 +1,125 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+)
+
+func Test_getClientAuthType(t *testing.T) {
+	tests := []struct {
+		name       string
+		clientAuth string
+		want       tls.ClientAuthType
+	}{
+		{name: "not configured", clientAuth: "", want: tls.NoClientCert},
+		{name: "no client certificate", clientAuth: "NoClientCert", want: tls.NoClientCert},
+		{name: "verify if given", clientAuth: "verifyclientcertifgiven", want: tls.VerifyClientCertIfGiven},
+		{name: "require and verify", clientAuth: "RequireAndVerifyClientCert", want: tls.RequireAndVerifyClientCert},
+		{name: "unknown value", clientAuth: "Required", want: tls.RequireAndVerifyClientCert},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getClientAuthType(tt.clientAuth); got != tt.want {
+				t.Errorf("getClientAuthType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getClientVerification(t *testing.T) {
+	tests := []struct {
+		name          string
+		clientAuth    string
+		want          tls.ClientAuthType
+		state         tls.ConnectionState
+		wantVerifyErr bool
+	}{
+		{name: "not configured", clientAuth: "", want: tls.NoClientCert},
+		{name: "verify if given", clientAuth: "VerifyClientCertIfGiven", want: tls.VerifyClientCertIfGiven},
+		{name: "required certificate presented", clientAuth: "RequireAndVerifyClientCert", want: tls.VerifyClientCertIfGiven, state: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{}}}},
+		{name: "required certificate missing", clientAuth: "RequireAndVerifyClientCert", want: tls.VerifyClientCertIfGiven, wantVerifyErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, verify := getClientVerification(tt.clientAuth)
+			if got != tt.want {
+				t.Errorf("getClientVerification() = %v, want %v", got, tt.want)
+			}
+			if verify == nil {
+				if tt.wantVerifyErr {
+					t.Errorf("getClientVerification() returned no connection verification")
+				}
+				return
+			}
+			if err := verify(tt.state); (err != nil) != tt.wantVerifyErr {
+				t.Errorf("VerifyConnection() error = %v, wantErr %v", err, tt.wantVerifyErr)
+			}
+		})
+	}
+}
+
+func Test_isSubjectAllowed(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "odimra", Organization: []string{"HPE"}},
+		DNSNames: []string{"odimra.odim.svc.cluster.local"},
+	}
+	tests := []struct {
+		name    string
+		allowed []string
+		want    bool
+	}{
+		{name: "common name", allowed: []string{"odimra"}, want: true},
+		{name: "full subject", allowed: []string{"CN=odimra,O=HPE"}, want: true},
+		{name: "dns name", allowed: []string{"ODIMRA.odim.svc.cluster.local"}, want: true},
+		{name: "other subject", allowed: []string{"CN=attacker", "attacker"}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSubjectAllowed(cert, tt.allowed); got != tt.want {
+				t.Errorf("isSubjectAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isContextAllowed(t *testing.T) {
+	tests := []struct {
+		name          string
+		verifyContext bool
+		context       string
+		want          bool
+	}{
+		{name: "verification disabled", verifyContext: false, context: "other", want: true},
+		{name: "operator subscription context", verifyContext: true, context: constants.DefaultEventSubscriptionContext, want: true},
+		{name: "other context", verifyContext: true, context: "other", want: false},
+		{name: "missing context", verifyContext: true, context: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.Data.EventListener.VerifyContext = tt.verifyContext
+			if got := isContextAllowed(tt.context); got != tt.want {
+				t.Errorf("isContextAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	config.Data.EventListener.VerifyContext = false
+}
@@ -0,0 +1,293 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package controllers ...
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	pollData "github.com/ODIM-Project/BMCOperator/controllers/pollData"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+const (
+	// WebhookSink posts the events as JSON to an HTTP(S) endpoint
+	WebhookSink = "webhook"
+	// CloudEventsSink posts the events as CloudEvents in binary or structured mode
+	CloudEventsSink = "cloudevents"
+	// KafkaRestSink produces the events to a kafka topic through a kafka REST proxy
+	KafkaRestSink = "kafkarest"
+	// FileSink appends the events as JSON lines to a file or to stdout
+	FileSink = "file"
+
+	defaultMaxRetries    = 3
+	defaultRetryInterval = 5 * time.Second
+	defaultSinkTimeout   = 10 * time.Second
+	sinkQueueSize        = 1000
+)
+
+// EnrichedEvent is the event received from ODIM along with the details of the BMC which originated it
+type EnrichedEvent struct {
+	pollData.OdimEvent
+	BmcName      string            `json:"BmcName,omitempty"`
+	BmcAddress   string            `json:"BmcAddress,omitempty"`
+	SerialNumber string            `json:"SerialNumber,omitempty"`
+	Labels       map[string]string `json:"Labels,omitempty"`
+}
+
+// eventSender delivers a single event to the destination of a sink
+type eventSender interface {
+	send(ctx context.Context, event *EnrichedEvent) error
+}
+
+type eventSink struct {
+	conf          config.EventSinkConfig
+	sender        eventSender
+	queue         chan *EnrichedEvent
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+// deadLetter is the record appended to the dead-letter file of a sink for an event which could not be delivered
+type deadLetter struct {
+	Sink  string         `json:"sink"`
+	Error string         `json:"error"`
+	Time  string         `json:"time"`
+	Event *EnrichedEvent `json:"event"`
+}
+
+var (
+	sinks          []*eventSink
+	sinksConf      []config.EventSinkConfig
+	sinksMutex     = &sync.Mutex{}
+	deadLetterLock = &sync.Mutex{}
+)
+
+// ForwardEvents enriches the events with the details of the BMC which originated them
+// and queues them to every configured sink whose filters match the event
+func ForwardEvents(ctx context.Context, commonRec utils.ReconcilerInterface, ns string, events []pollData.OdimEvent) {
+	sinksMutex.Lock()
+	noSinks := len(config.Data.EventSinks) == 0 && len(sinks) == 0
+	sinksMutex.Unlock()
+	if noSinks {
+		return
+	}
+	enriched := make([]*EnrichedEvent, 0, len(events))
+	bmcs := map[string]*EnrichedEvent{}
+	for _, event := range events {
+		enriched = append(enriched, enrichEvent(ctx, commonRec, ns, event, bmcs))
+	}
+	sinksMutex.Lock()
+	defer sinksMutex.Unlock()
+	for _, sink := range getEventSinks(ctx) {
+		for _, event := range enriched {
+			if !sink.accepts(event.OdimEvent) {
+				continue
+			}
+			select {
+			case sink.queue <- event:
+			default:
+				sink.deadLetter(ctx, event, errors.New("sink queue is full"))
+			}
+		}
+	}
+}
+
+// enrichEvent adds the name, address, serial number and labels of the BMC which originated the event,
+// bmcs caches the lookups done for the events of a batch by origin
+func enrichEvent(ctx context.Context, commonRec utils.ReconcilerInterface, ns string, event pollData.OdimEvent, bmcs map[string]*EnrichedEvent) *EnrichedEvent {
+	enriched := &EnrichedEvent{OdimEvent: event}
+	if event.OriginOfCondition == nil {
+		return enriched
+	}
+	origin := event.OriginOfCondition.Oid
+	if cached, ok := bmcs[origin]; ok {
+		enriched.BmcName, enriched.BmcAddress = cached.BmcName, cached.BmcAddress
+		enriched.SerialNumber, enriched.Labels = cached.SerialNumber, cached.Labels
+		return enriched
+	}
+	if bmcObj := utils.GetBmcObjectForOrigin(ctx, commonRec, origin, ns); bmcObj != nil {
+		enriched.BmcName = bmcObj.ObjectMeta.Name
+		enriched.BmcAddress = bmcObj.Spec.BmcDetails.Address
+		enriched.SerialNumber = bmcObj.Status.SerialNumber
+		enriched.Labels = bmcObj.ObjectMeta.Labels
+	}
+	bmcs[origin] = enriched
+	return enriched
+}
+
+// getEventSinks returns the sinks configured in eventSinks, the sinks are rebuilt when the configuration changes.
+// The workers of the sinks outlive the request which created them and stop when their queue is closed.
+// The caller must hold sinksMutex
+func getEventSinks(ctx context.Context) []*eventSink {
+	if reflect.DeepEqual(sinksConf, config.Data.EventSinks) {
+		return sinks
+	}
+	for _, sink := range sinks {
+		close(sink.queue)
+	}
+	sinks = nil
+	for _, conf := range config.Data.EventSinks {
+		sink, err := newEventSink(conf)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Ignoring event sink %s: %s", conf.Name, err.Error())
+			continue
+		}
+		go sink.run(context.Background())
+		sinks = append(sinks, sink)
+	}
+	sinksConf = append([]config.EventSinkConfig{}, config.Data.EventSinks...)
+	return sinks
+}
+
+// newEventSink validates the configuration of the sink and creates the sender for its type
+func newEventSink(conf config.EventSinkConfig) (*eventSink, error) {
+	timeout := getDuration(conf.Timeout, defaultSinkTimeout)
+	var sender eventSender
+	var err error
+	switch strings.ToLower(conf.Type) {
+	case WebhookSink:
+		sender, err = newWebhookSender(conf, timeout)
+	case CloudEventsSink:
+		sender, err = newCloudEventsSender(conf, timeout)
+	case KafkaRestSink:
+		sender, err = newKafkaRestSender(conf, timeout)
+	case FileSink:
+		sender, err = newFileSender(conf)
+	default:
+		err = fmt.Errorf("unsupported sink type %q", conf.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	maxRetries := defaultMaxRetries
+	if conf.MaxRetries != "" {
+		if val, convErr := strconv.Atoi(conf.MaxRetries); convErr == nil && val >= 0 {
+			maxRetries = val
+		}
+	}
+	return &eventSink{
+		conf:          conf,
+		sender:        sender,
+		queue:         make(chan *EnrichedEvent, sinkQueueSize),
+		maxRetries:    maxRetries,
+		retryInterval: getDuration(conf.RetryInterval, defaultRetryInterval),
+	}, nil
+}
+
+// accepts returns true when the severity and the message id of the event match the filters of the sink,
+// message ids are matched on the full id, on the message name or on a prefix ending with *
+func (s *eventSink) accepts(event pollData.OdimEvent) bool {
+	if len(s.conf.Severities) != 0 && !containsFold(s.conf.Severities, event.Severity) {
+		return false
+	}
+	if len(s.conf.MessageIDs) == 0 {
+		return true
+	}
+	messageName := event.MessageID[strings.LastIndex(event.MessageID, ".")+1:]
+	for _, id := range s.conf.MessageIDs {
+		if strings.HasSuffix(id, "*") && strings.HasPrefix(strings.ToLower(event.MessageID), strings.ToLower(strings.TrimSuffix(id, "*"))) {
+			return true
+		}
+		if strings.EqualFold(id, event.MessageID) || strings.EqualFold(id, messageName) {
+			return true
+		}
+	}
+	return false
+}
+
+// run delivers the queued events until the sink is closed, an event which could not be delivered
+// after the configured retries, or before the context is done, is written to the dead-letter file
+func (s *eventSink) run(ctx context.Context) {
+	for event := range s.queue {
+		var err error
+		for attempt := 0; attempt <= s.maxRetries; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+				case <-time.After(s.retryInterval):
+				}
+				// event is written to the dead-letter file with the error of the last attempt when the operator stops
+				if ctx.Err() != nil {
+					break
+				}
+			}
+			if err = s.sender.send(ctx, event); err == nil {
+				break
+			}
+			l.LogWithFields(ctx).Warnf("Attempt %d to forward event %s to sink %s failed: %s", attempt+1, event.MessageID, s.conf.Name, err.Error())
+		}
+		if err != nil {
+			s.deadLetter(ctx, event, err)
+		}
+	}
+}
+
+// deadLetter appends the event which could not be delivered to the dead-letter file of the sink
+func (s *eventSink) deadLetter(ctx context.Context, event *EnrichedEvent, reason error) {
+	if s.conf.DeadLetterPath == "" {
+		l.LogWithFields(ctx).Errorf("Dropping event %s for sink %s: %s", event.MessageID, s.conf.Name, reason.Error())
+		return
+	}
+	record, err := json.Marshal(deadLetter{Sink: s.conf.Name, Error: reason.Error(), Time: time.Now().UTC().Format(time.RFC3339), Event: event})
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Failed to marshal dead-letter of sink %s: %s", s.conf.Name, err.Error())
+		return
+	}
+	deadLetterLock.Lock()
+	defer deadLetterLock.Unlock()
+	if err = appendLine(s.conf.DeadLetterPath, record); err != nil {
+		l.LogWithFields(ctx).Errorf("Failed to write dead-letter of sink %s to %s: %s", s.conf.Name, s.conf.DeadLetterPath, err.Error())
+	}
+}
+
+// appendLine appends the record followed by a new line to the file at path, the file is created if missing
+func appendLine(path string, record []byte) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.Write(append(record, '\n'))
+	return err
+}
+
+func getDuration(value string, defaultValue time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return defaultValue
+	}
+	return duration
+}
+
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,310 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	pollData "github.com/ODIM-Project/BMCOperator/controllers/pollData"
+)
+
+type receivedRequest struct {
+	path    string
+	headers http.Header
+	body    []byte
+}
+
+// newReceiver starts a local receiver which responds with the given statuses in order and records the requests
+func newReceiver(t *testing.T, statuses ...int) (*httptest.Server, chan receivedRequest) {
+	requests := make(chan receivedRequest, 10)
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests <- receivedRequest{path: r.URL.Path, headers: r.Header, body: body}
+		status := http.StatusOK
+		if calls < len(statuses) {
+			status = statuses[calls]
+		}
+		calls++
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func testEvent() *EnrichedEvent {
+	return &EnrichedEvent{
+		OdimEvent: pollData.OdimEvent{
+			EventType:         "Alert",
+			EventID:           "1234",
+			Severity:          "Critical",
+			EventTimestamp:    "2023-05-10T10:00:00Z",
+			Message:           "Fan 1 failed",
+			MessageID:         "iLOEvents.2.1.FanFailed",
+			OriginOfCondition: &pollData.Link{Oid: "/redfish/v1/Systems/uuid.1"},
+		},
+		BmcName:      "10.10.10.10",
+		SerialNumber: "SN123",
+		Labels:       map[string]string{"rack": "r1"},
+	}
+}
+
+func TestSenders(t *testing.T) {
+	tests := []struct {
+		name     string
+		conf     config.EventSinkConfig
+		wantPath string
+		check    func(t *testing.T, req receivedRequest)
+	}{
+		{
+			name:     "webhook posts the enriched event",
+			conf:     config.EventSinkConfig{Type: WebhookSink, Headers: map[string]string{"Authorization": "Bearer token"}},
+			wantPath: "/",
+			check: func(t *testing.T, req receivedRequest) {
+				var event EnrichedEvent
+				if err := json.Unmarshal(req.body, &event); err != nil {
+					t.Fatalf("invalid body: %s", err)
+				}
+				if event.BmcName != "10.10.10.10" || event.MessageID != "iLOEvents.2.1.FanFailed" || event.Labels["rack"] != "r1" {
+					t.Errorf("unexpected event %+v", event)
+				}
+				if req.headers.Get("Authorization") != "Bearer token" {
+					t.Errorf("configured header not sent")
+				}
+			},
+		},
+		{
+			name:     "cloudevents structured mode",
+			conf:     config.EventSinkConfig{Type: CloudEventsSink, Mode: "structured"},
+			wantPath: "/",
+			check: func(t *testing.T, req receivedRequest) {
+				if req.headers.Get("Content-Type") != cloudEventsContentType {
+					t.Errorf("got content type %s", req.headers.Get("Content-Type"))
+				}
+				var ce cloudEvent
+				if err := json.Unmarshal(req.body, &ce); err != nil {
+					t.Fatalf("invalid body: %s", err)
+				}
+				if ce.SpecVersion != "1.0" || ce.ID != "1234" || ce.Source != "/redfish/v1/Systems/uuid.1" ||
+					ce.Type != "io.odimra.bmc.Alert" || ce.Subject != "10.10.10.10" || ce.Data == nil || ce.Data.SerialNumber != "SN123" {
+					t.Errorf("unexpected cloud event %+v", ce)
+				}
+			},
+		},
+		{
+			name:     "cloudevents binary mode",
+			conf:     config.EventSinkConfig{Type: CloudEventsSink, Mode: "binary"},
+			wantPath: "/",
+			check: func(t *testing.T, req receivedRequest) {
+				if req.headers.Get("ce-specversion") != "1.0" || req.headers.Get("ce-id") != "1234" ||
+					req.headers.Get("ce-type") != "io.odimra.bmc.Alert" || req.headers.Get("ce-subject") != "10.10.10.10" ||
+					req.headers.Get("ce-time") != "2023-05-10T10:00:00Z" || req.headers.Get("Content-Type") != "application/json" {
+					t.Errorf("unexpected headers %v", req.headers)
+				}
+				var event EnrichedEvent
+				if err := json.Unmarshal(req.body, &event); err != nil || event.EventID != "1234" {
+					t.Errorf("unexpected body %s", string(req.body))
+				}
+			},
+		},
+		{
+			name:     "kafka rest proxy",
+			conf:     config.EventSinkConfig{Type: KafkaRestSink, Topic: "bmc-events"},
+			wantPath: "/topics/bmc-events",
+			check: func(t *testing.T, req receivedRequest) {
+				if req.headers.Get("Content-Type") != kafkaRestContentType {
+					t.Errorf("got content type %s", req.headers.Get("Content-Type"))
+				}
+				var records kafkaRecords
+				if err := json.Unmarshal(req.body, &records); err != nil || len(records.Records) != 1 ||
+					records.Records[0].Key != "10.10.10.10" || records.Records[0].Value.EventID != "1234" {
+					t.Errorf("unexpected body %s", string(req.body))
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newReceiver(t)
+			tt.conf.Name = tt.name
+			tt.conf.URL = server.URL
+			sink, err := newEventSink(tt.conf)
+			if err != nil {
+				t.Fatalf("newEventSink() error = %v", err)
+			}
+			if err = sink.sender.send(context.TODO(), testEvent()); err != nil {
+				t.Fatalf("send() error = %v", err)
+			}
+			req := <-requests
+			if req.path != tt.wantPath {
+				t.Errorf("got path %s, want %s", req.path, tt.wantPath)
+			}
+			tt.check(t, req)
+		})
+	}
+}
+
+func TestNewEventSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    config.EventSinkConfig
+		wantErr bool
+	}{
+		{name: "webhook without url", conf: config.EventSinkConfig{Type: WebhookSink}, wantErr: true},
+		{name: "kafka without topic", conf: config.EventSinkConfig{Type: KafkaRestSink, URL: "http://proxy"}, wantErr: true},
+		{name: "invalid cloudevents mode", conf: config.EventSinkConfig{Type: CloudEventsSink, URL: "http://ce", Mode: "batch"}, wantErr: true},
+		{name: "file without path", conf: config.EventSinkConfig{Type: FileSink}, wantErr: true},
+		{name: "unknown type", conf: config.EventSinkConfig{Type: "syslog"}, wantErr: true},
+		{name: "stdout file sink", conf: config.EventSinkConfig{Type: FileSink, Path: stdoutPath}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newEventSink(tt.conf); (err != nil) != tt.wantErr {
+				t.Errorf("newEventSink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAccepts(t *testing.T) {
+	event := testEvent().OdimEvent
+	tests := []struct {
+		name string
+		conf config.EventSinkConfig
+		want bool
+	}{
+		{name: "no filters", conf: config.EventSinkConfig{}, want: true},
+		{name: "matching severity", conf: config.EventSinkConfig{Severities: []string{"warning", "critical"}}, want: true},
+		{name: "other severity", conf: config.EventSinkConfig{Severities: []string{"OK"}}, want: false},
+		{name: "full message id", conf: config.EventSinkConfig{MessageIDs: []string{"iLOEvents.2.1.FanFailed"}}, want: true},
+		{name: "message name", conf: config.EventSinkConfig{MessageIDs: []string{"fanfailed"}}, want: true},
+		{name: "message id prefix", conf: config.EventSinkConfig{MessageIDs: []string{"iLOEvents.*"}}, want: true},
+		{name: "other message id", conf: config.EventSinkConfig{MessageIDs: []string{"ServerPoweredOn"}}, want: false},
+		{name: "severity matches but message id does not", conf: config.EventSinkConfig{Severities: []string{"Critical"}, MessageIDs: []string{"Base.*"}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &eventSink{conf: tt.conf}
+			if got := sink.accepts(event); got != tt.want {
+				t.Errorf("accepts() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunRetryAndDeadLetter(t *testing.T) {
+	tests := []struct {
+		name           string
+		statuses       []int
+		wantCalls      int
+		wantDeadLetter bool
+	}{
+		{name: "delivered after a retry", statuses: []int{http.StatusServiceUnavailable, http.StatusAccepted}, wantCalls: 2},
+		{name: "dead-lettered after retries", statuses: []int{500, 500, 500}, wantCalls: 3, wantDeadLetter: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newReceiver(t, tt.statuses...)
+			deadLetterPath := filepath.Join(t.TempDir(), "deadletter.jsonl")
+			sink, err := newEventSink(config.EventSinkConfig{Name: "alerts", Type: WebhookSink, URL: server.URL,
+				MaxRetries: "2", RetryInterval: "10ms", DeadLetterPath: deadLetterPath})
+			if err != nil {
+				t.Fatalf("newEventSink() error = %v", err)
+			}
+			sink.queue <- testEvent()
+			close(sink.queue)
+			sink.run(context.TODO())
+			if len(requests) != tt.wantCalls {
+				t.Errorf("got %d deliveries, want %d", len(requests), tt.wantCalls)
+			}
+			data, err := ioutil.ReadFile(deadLetterPath)
+			if !tt.wantDeadLetter {
+				if err == nil {
+					t.Errorf("unexpected dead-letter %s", string(data))
+				}
+				return
+			}
+			var record deadLetter
+			if err != nil || json.Unmarshal([]byte(strings.TrimSpace(string(data))), &record) != nil {
+				t.Fatalf("invalid dead-letter file %s: %v", string(data), err)
+			}
+			if record.Sink != "alerts" || record.Event == nil || record.Event.EventID != "1234" || !strings.Contains(record.Error, "500") {
+				t.Errorf("unexpected dead-letter %+v", record)
+			}
+		})
+	}
+}
+
+func TestRunStopsRetryingWhenContextIsDone(t *testing.T) {
+	server, requests := newReceiver(t, 500, 500)
+	deadLetterPath := filepath.Join(t.TempDir(), "deadletter.jsonl")
+	sink, err := newEventSink(config.EventSinkConfig{Name: "alerts", Type: WebhookSink, URL: server.URL,
+		MaxRetries: "1", RetryInterval: "1h", DeadLetterPath: deadLetterPath})
+	if err != nil {
+		t.Fatalf("newEventSink() error = %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.TODO())
+	go func() {
+		<-requests
+		cancel()
+	}()
+	sink.queue <- testEvent()
+	close(sink.queue)
+	done := make(chan struct{})
+	go func() {
+		sink.run(ctx)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("run() is still waiting for the retry interval after the context is done")
+	}
+	data, err := ioutil.ReadFile(deadLetterPath)
+	if err != nil || !strings.Contains(string(data), "1234") {
+		t.Errorf("event is not written to the dead-letter file: %s %v", string(data), err)
+	}
+}
+
+func TestFileSender(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := newEventSink(config.EventSinkConfig{Name: "file", Type: FileSink, Path: path, Timeout: time.Second.String()})
+	if err != nil {
+		t.Fatalf("newEventSink() error = %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if err = sink.sender.send(context.TODO(), testEvent()); err != nil {
+			t.Fatalf("send() error = %v", err)
+		}
+	}
+	data, _ := ioutil.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2", len(lines))
+	}
+	var event EnrichedEvent
+	if err = json.Unmarshal([]byte(lines[0]), &event); err != nil || event.BmcName != "10.10.10.10" {
+		t.Errorf("unexpected line %s", lines[0])
+	}
+}
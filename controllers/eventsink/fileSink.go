@@ -0,0 +1,55 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+)
+
+// stdoutPath is the path of a file sink which writes the events to the standard output of the operator
+const stdoutPath = "stdout"
+
+// fileSender appends every event as a JSON line to the file at path
+type fileSender struct {
+	path  string
+	mutex *sync.Mutex
+}
+
+func newFileSender(conf config.EventSinkConfig) (eventSender, error) {
+	if conf.Path == "" {
+		return nil, fmt.Errorf("path is required for %s sink", conf.Type)
+	}
+	return &fileSender{path: conf.Path, mutex: &sync.Mutex{}}, nil
+}
+
+func (fs *fileSender) send(ctx context.Context, event *EnrichedEvent) error {
+	record, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	fs.mutex.Lock()
+	defer fs.mutex.Unlock()
+	if fs.path == stdoutPath {
+		_, err = os.Stdout.Write(append(record, '\n'))
+		return err
+	}
+	return appendLine(fs.path, record)
+}
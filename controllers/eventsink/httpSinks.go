@@ -0,0 +1,236 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	"github.com/google/uuid"
+)
+
+const (
+	cloudEventsSpecVersion   = "1.0"
+	cloudEventsTypePrefix    = "io.odimra.bmc."
+	cloudEventsDefaultSource = "bmc-operator"
+	cloudEventsContentType   = "application/cloudevents+json"
+	kafkaRestContentType     = "application/vnd.kafka.json.v2+json"
+	binaryMode               = "binary"
+	structuredMode           = "structured"
+)
+
+// httpSender posts the payloads of a sink to its url with the configured headers
+type httpSender struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// webhookSender posts every event as JSON
+type webhookSender struct {
+	httpSender
+}
+
+// cloudEventsSender posts every event as a CloudEvent, in binary mode the attributes
+// are sent as ce- headers, in structured mode the whole CloudEvent is the body
+type cloudEventsSender struct {
+	httpSender
+	binary bool
+}
+
+// kafkaRestSender produces every event as a record of the topic through a kafka REST proxy,
+// the name of the BMC is used as the key of the record
+type kafkaRestSender struct {
+	httpSender
+	topic string
+}
+
+// cloudEvent is a CloudEvent in the JSON event format
+type cloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	ID              string         `json:"id"`
+	Source          string         `json:"source"`
+	Type            string         `json:"type"`
+	Subject         string         `json:"subject,omitempty"`
+	Time            string         `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            *EnrichedEvent `json:"data"`
+}
+
+type kafkaRecord struct {
+	Key   string         `json:"key,omitempty"`
+	Value *EnrichedEvent `json:"value"`
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+func newHTTPSender(conf config.EventSinkConfig, timeout time.Duration) (httpSender, error) {
+	if conf.URL == "" {
+		return httpSender{}, fmt.Errorf("url is required for %s sink", conf.Type)
+	}
+	transport := &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12}}
+	if conf.CACertPath != "" {
+		caCert, err := ioutil.ReadFile(conf.CACertPath)
+		if err != nil {
+			return httpSender{}, fmt.Errorf("failed to read CA certificate: %s", err.Error())
+		}
+		pool := x509.NewCertPool()
+		pool.AppendCertsFromPEM(caCert)
+		transport.TLSClientConfig.RootCAs = pool
+	}
+	return httpSender{
+		url:     conf.URL,
+		headers: conf.Headers,
+		client:  &http.Client{Transport: transport, Timeout: timeout},
+	}, nil
+}
+
+func newWebhookSender(conf config.EventSinkConfig, timeout time.Duration) (eventSender, error) {
+	sender, err := newHTTPSender(conf, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookSender{httpSender: sender}, nil
+}
+
+func newCloudEventsSender(conf config.EventSinkConfig, timeout time.Duration) (eventSender, error) {
+	mode := strings.ToLower(conf.Mode)
+	if mode != "" && mode != binaryMode && mode != structuredMode {
+		return nil, fmt.Errorf("unsupported CloudEvents mode %q", conf.Mode)
+	}
+	sender, err := newHTTPSender(conf, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &cloudEventsSender{httpSender: sender, binary: mode == binaryMode}, nil
+}
+
+func newKafkaRestSender(conf config.EventSinkConfig, timeout time.Duration) (eventSender, error) {
+	if conf.Topic == "" {
+		return nil, fmt.Errorf("topic is required for %s sink", conf.Type)
+	}
+	sender, err := newHTTPSender(conf, timeout)
+	if err != nil {
+		return nil, err
+	}
+	sender.url = strings.TrimSuffix(sender.url, "/") + "/topics/" + conf.Topic
+	return &kafkaRestSender{httpSender: sender, topic: conf.Topic}, nil
+}
+
+func (ws *webhookSender) send(ctx context.Context, event *EnrichedEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return ws.post(ctx, body, map[string]string{"Content-Type": "application/json"})
+}
+
+func (cs *cloudEventsSender) send(ctx context.Context, event *EnrichedEvent) error {
+	ce := newCloudEvent(event)
+	if !cs.binary {
+		body, err := json.Marshal(ce)
+		if err != nil {
+			return err
+		}
+		return cs.post(ctx, body, map[string]string{"Content-Type": cloudEventsContentType})
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Content-Type":   ce.DataContentType,
+		"ce-specversion": ce.SpecVersion,
+		"ce-id":          ce.ID,
+		"ce-source":      ce.Source,
+		"ce-type":        ce.Type,
+		"ce-time":        ce.Time,
+	}
+	if ce.Subject != "" {
+		headers["ce-subject"] = ce.Subject
+	}
+	return cs.post(ctx, body, headers)
+}
+
+func (ks *kafkaRestSender) send(ctx context.Context, event *EnrichedEvent) error {
+	body, err := json.Marshal(kafkaRecords{Records: []kafkaRecord{{Key: event.BmcName, Value: event}}})
+	if err != nil {
+		return err
+	}
+	return ks.post(ctx, body, map[string]string{"Content-Type": kafkaRestContentType})
+}
+
+// newCloudEvent maps the event to the CloudEvent attributes, the origin of the event is the source
+// and the name of the BMC is the subject
+func newCloudEvent(event *EnrichedEvent) cloudEvent {
+	id := event.EventID
+	if id == "" {
+		id = uuid.New().String()
+	}
+	source := cloudEventsDefaultSource
+	if event.OriginOfCondition != nil && event.OriginOfCondition.Oid != "" {
+		source = event.OriginOfCondition.Oid
+	}
+	eventTime := time.Now().UTC().Format(time.RFC3339)
+	if ts, err := time.Parse(time.RFC3339, event.EventTimestamp); err == nil {
+		eventTime = ts.UTC().Format(time.RFC3339)
+	}
+	return cloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              id,
+		Source:          source,
+		Type:            cloudEventsTypePrefix + event.EventType,
+		Subject:         event.BmcName,
+		Time:            eventTime,
+		DataContentType: "application/json",
+		Data:            event,
+	}
+}
+
+// post sends the body with the sink and payload headers, any status other than 2xx is an error
+func (hs *httpSender) post(ctx context.Context, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hs.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, value := range hs.headers {
+		req.Header.Set(key, value)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := hs.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s responded with status %d", hs.url, resp.StatusCode)
+	}
+	return nil
+}
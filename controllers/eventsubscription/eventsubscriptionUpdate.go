@@ -0,0 +1,302 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"golang.org/x/exp/slices"
+)
+
+const subscriptionsURI = "/redfish/v1/EventService/Subscriptions"
+
+// patchableProperties are the properties of a subscription which ODIM allows to modify with PATCH
+var patchableProperties = []string{"Context", "DeliveryRetryPolicy"}
+
+const (
+	defaultDeliveryRetryPolicy = "RetryForever"
+	// defaultStatusInterval is the interval at which the state of subscriptions in ODIM is checked
+	defaultStatusInterval = 5 * time.Minute
+)
+
+// updateEventsubscription applies the changes of the spec to the subscription in ODIM,
+// the subscription is patched when only patchable properties are modified, otherwise it is replaced by a new subscription
+func (esu *eventsubscriptionUtils) updateEventsubscription() (bool, error) {
+	if esu.eventSubObj.Status.RequestedOriginResources == nil && len(esu.eventSubObj.Spec.OriginResources) != 0 {
+		// subscriptions created before the requested origin resources were recorded are assumed to match the spec
+		esu.eventSubObj.Status.RequestedOriginResources = esu.eventSubObj.Spec.OriginResources
+	}
+	selected, err := esu.getSelectedBmcs()
+	if err != nil {
+		return false, fmt.Errorf("invalid origin selector of eventsubscription %s: %s", esu.eventSubObj.ObjectMeta.Name, err.Error())
+	}
+	modified := getModifiedProperties(esu.eventSubObj.Spec, esu.eventSubObj.Status, getBmcNames(selected))
+	if len(modified) == 0 {
+		return esu.refreshEventsubscriptionStatus()
+	}
+	if esu.hasNoOrigin() {
+		return esu.removeEventsubscriptionWithoutOrigin()
+	}
+	l.LogWithFields(esu.ctx).Infof("Properties %s of eventsubscription %s are modified", strings.Join(modified, ","), esu.eventSubObj.ObjectMeta.Name)
+	if isPatchable(modified) && esu.patchEventsubscription() {
+		if !esu.UpdateEventsubscriptionStatus(esu.eventSubObj.Status.ID) {
+			return false, fmt.Errorf("eventsubscription %s patched but failed to update the status", esu.eventSubObj.Status.ID)
+		}
+		return true, nil
+	}
+	return esu.replaceEventsubscription()
+}
+
+// getModifiedProperties returns the properties of the spec which differ from the subscription recorded in the status,
+// selectedBmcs are the Bmc objects currently matching the origin selector
+func getModifiedProperties(spec infraiov1.EventsubscriptionSpec, status infraiov1.EventsubscriptionStatus, selectedBmcs []string) []string {
+	var modified []string
+	if spec.Destination != status.Destination {
+		modified = append(modified, "Destination")
+	}
+	if spec.Context != status.Context {
+		modified = append(modified, "Context")
+	}
+	if !utils.CompareArray(spec.EventTypes, status.EventTypes) {
+		modified = append(modified, "EventTypes")
+	}
+	if !utils.CompareArray(spec.MessageIds, status.MessageIds) {
+		modified = append(modified, "MessageIds")
+	}
+	if !utils.CompareArray(spec.ResourceTypes, status.ResourceTypes) {
+		modified = append(modified, "ResourceTypes")
+	}
+	if !utils.CompareArray(spec.OriginResources, status.RequestedOriginResources) {
+		modified = append(modified, "OriginResources")
+	}
+	if status.DeliveryRetryPolicy != "" && getDeliveryRetryPolicy(spec) != status.DeliveryRetryPolicy {
+		modified = append(modified, "DeliveryRetryPolicy")
+	}
+	if !utils.CompareArray(selectedBmcs, status.SelectedBmcs) {
+		modified = append(modified, "OriginSelector")
+	}
+	return modified
+}
+
+func isPatchable(modified []string) bool {
+	for _, property := range modified {
+		if !slices.Contains(patchableProperties, property) {
+			return false
+		}
+	}
+	return true
+}
+
+// refreshEventsubscriptionStatus updates the state of the subscription in the status,
+// the subscription is created again when it was removed from ODIM
+func (esu *eventsubscriptionUtils) refreshEventsubscriptionStatus() (bool, error) {
+	_, statusCode, err := esu.eventsubRestClient.Get(subscriptionsURI+"/"+esu.eventSubObj.Status.ID, fmt.Sprintf("Fetching eventsubscription %s details", esu.eventSubObj.Status.ID))
+	if err != nil {
+		return false, err
+	}
+	if statusCode == http.StatusNotFound {
+		l.LogWithFields(esu.ctx).Warnf("Eventsubscription %s is not present in ODIM, creating it again", esu.eventSubObj.Status.ID)
+		eventSubReq, err := esu.GetEventSubscriptionRequest()
+		if err != nil {
+			return false, err
+		}
+		newID, created, _ := esu.postEventsubscription(eventSubReq)
+		if !created {
+			return false, fmt.Errorf("failed to create eventsubscription %s again", esu.eventSubObj.ObjectMeta.Name)
+		}
+		return esu.UpdateEventsubscriptionStatus(newID), nil
+	}
+	if statusCode != http.StatusOK {
+		return false, fmt.Errorf("failed to fetch eventsubscription %s, status %d", esu.eventSubObj.Status.ID, statusCode)
+	}
+	previousState := esu.eventSubObj.Status.State
+	if !esu.UpdateEventsubscriptionStatus(esu.eventSubObj.Status.ID) {
+		return false, fmt.Errorf("failed to update status of eventsubscription %s", esu.eventSubObj.Status.ID)
+	}
+	if state := esu.eventSubObj.Status.State; state != previousState && state != "" && state != "Enabled" {
+		l.LogWithFields(esu.ctx).Warnf("Eventsubscription %s is %s in ODIM, events are not delivered to %s", esu.eventSubObj.Status.ID, state, esu.eventSubObj.Status.Destination)
+	}
+	return true, nil
+}
+
+// hasNoOrigin checks if the spec selects its origins only with an origin selector which does not match any Bmc,
+// such a subscription is not created as ODIM would send the events of all resources
+func (esu *eventsubscriptionUtils) hasNoOrigin() bool {
+	if esu.eventSubObj.Spec.OriginSelector == nil || len(esu.eventSubObj.Spec.OriginResources) != 0 {
+		return false
+	}
+	selected, err := esu.getSelectedBmcs()
+	return err == nil && len(selected) == 0
+}
+
+// removeEventsubscriptionWithoutOrigin deletes the subscription when no Bmc matches its origin selector anymore,
+// the subscription is created again when a Bmc matches
+func (esu *eventsubscriptionUtils) removeEventsubscriptionWithoutOrigin() (bool, error) {
+	l.LogWithFields(esu.ctx).Infof("No Bmc matches the origin selector of eventsubscription %s anymore, deleting subscription %s", esu.eventSubObj.ObjectMeta.Name, esu.eventSubObj.Status.ID)
+	if !esu.DeleteEventsubscription() {
+		return false, fmt.Errorf("failed to delete eventsubscription %s", esu.eventSubObj.Status.ID)
+	}
+	esu.eventSubObj.Status = infraiov1.EventsubscriptionStatus{}
+	err := esu.commonRec.GetCommonReconcilerClient().Status().Update(esu.ctx, esu.eventSubObj)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// patchEventsubscription patches the patchable properties of the subscription with the values of the spec
+func (esu *eventsubscriptionUtils) patchEventsubscription() bool {
+	uri := subscriptionsURI + "/" + esu.eventSubObj.Status.ID
+	body, err := json.Marshal(map[string]interface{}{"Context": esu.eventSubObj.Spec.Context, "DeliveryRetryPolicy": getDeliveryRetryPolicy(esu.eventSubObj.Spec)})
+	if err != nil {
+		l.LogWithFields(esu.ctx).Errorf("error while marshalling patch request of eventsubscription %s: %s", esu.eventSubObj.Status.ID, err.Error())
+		return false
+	}
+	resp, err := esu.eventsubRestClient.Patch(uri, fmt.Sprintf("Patching eventsubscription %s", esu.eventSubObj.Status.ID), body)
+	if err != nil {
+		l.LogWithFields(esu.ctx).Errorf("error while patching eventsubscription %s: %s", esu.eventSubObj.Status.ID, err.Error())
+		return false
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		l.LogWithFields(esu.ctx).Infof("Patching eventsubscription %s is not supported, status %d, the subscription will be replaced", esu.eventSubObj.Status.ID, resp.StatusCode)
+		return false
+	}
+	l.LogWithFields(esu.ctx).Infof("Successfully patched eventsubscription %s", esu.eventSubObj.Status.ID)
+	return true
+}
+
+// replaceEventsubscription creates a subscription for the spec and deletes the existing subscription.
+// When ODIM does not allow two subscriptions with the same destination, the existing subscription is deleted first
+// and restored if the new subscription could not be created
+func (esu *eventsubscriptionUtils) replaceEventsubscription() (bool, error) {
+	eventSubReq, err := esu.GetEventSubscriptionRequest()
+	if err != nil {
+		return false, err
+	}
+	oldID := esu.eventSubObj.Status.ID
+	newID, created, conflict := esu.postEventsubscription(eventSubReq)
+	if !created && conflict {
+		l.LogWithFields(esu.ctx).Infof("Deleting eventsubscription %s before creating its replacement", oldID)
+		if !esu.DeleteEventsubscription() {
+			return false, fmt.Errorf("failed to delete eventsubscription %s for replacement", oldID)
+		}
+		newID, created, _ = esu.postEventsubscription(eventSubReq)
+		if !created {
+			esu.restoreEventsubscription()
+			return false, fmt.Errorf("failed to create the replacement of eventsubscription %s", oldID)
+		}
+	} else if !created {
+		return false, fmt.Errorf("failed to create the replacement of eventsubscription %s", oldID)
+	} else if !esu.DeleteEventsubscription() {
+		l.LogWithFields(esu.ctx).Errorf("Eventsubscription %s is replaced by %s but could not be deleted, delete it from ODIM", oldID, newID)
+	}
+	l.LogWithFields(esu.ctx).Infof("Eventsubscription %s replaced by %s", oldID, newID)
+	if !esu.UpdateEventsubscriptionStatus(newID) {
+		return false, fmt.Errorf("eventsubscription %s created but failed to update the status", newID)
+	}
+	return true, nil
+}
+
+// restoreEventsubscription recreates the subscription recorded in the status after its replacement failed,
+// the ID is cleared when the subscription could not be restored so that it is created on the next reconciliation
+func (esu *eventsubscriptionUtils) restoreEventsubscription() {
+	status := esu.eventSubObj.Status
+	previous := esu.eventSubObj.DeepCopy()
+	previous.Spec.Destination = status.Destination
+	previous.Spec.Context = status.Context
+	previous.Spec.EventTypes = status.EventTypes
+	previous.Spec.MessageIds = status.MessageIds
+	previous.Spec.ResourceTypes = status.ResourceTypes
+	previous.Spec.OriginResources = status.RequestedOriginResources
+	previousUtil := *esu
+	previousUtil.eventSubObj = previous
+	eventSubReq, err := previousUtil.GetEventSubscriptionRequest()
+	if err == nil {
+		if restoredID, created, _ := esu.postEventsubscription(eventSubReq); created {
+			l.LogWithFields(esu.ctx).Infof("Eventsubscription %s restored with ID %s", status.ID, restoredID)
+			esu.eventSubObj.Status.ID = restoredID
+			err = esu.commonRec.GetCommonReconcilerClient().Status().Update(esu.ctx, esu.eventSubObj)
+			if err != nil {
+				l.LogWithFields(esu.ctx).Errorf("error while updating status of eventsubscription %s: %s", esu.eventSubObj.ObjectMeta.Name, err.Error())
+			}
+			return
+		}
+	}
+	l.LogWithFields(esu.ctx).Errorf("Failed to restore eventsubscription %s, it will be created on the next reconciliation", status.ID)
+	esu.eventSubObj.Status.ID = ""
+	err = esu.commonRec.GetCommonReconcilerClient().Status().Update(esu.ctx, esu.eventSubObj)
+	if err != nil {
+		l.LogWithFields(esu.ctx).Errorf("error while updating status of eventsubscription %s: %s", esu.eventSubObj.ObjectMeta.Name, err.Error())
+	}
+}
+
+// postEventsubscription creates a subscription and waits for the task to complete,
+// it returns the ID of the created subscription and whether ODIM rejected the request with a conflict
+func (esu *eventsubscriptionUtils) postEventsubscription(eventSubReq []byte) (string, bool, bool) {
+	resp, err := esu.eventsubRestClient.Post(subscriptionsURI, "Posting eventsubscription creation payload...", eventSubReq)
+	if err != nil {
+		l.LogWithFields(esu.ctx).Error("error while creating eventsubscription: " + err.Error())
+		return "", false, false
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return "", false, true
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		return "", false, false
+	}
+	done, taskResp := esu.commonUtil.MoniteringTaskmon(resp.Header, esu.ctx, common.EVENTSUBSCRIPTION, esu.eventSubObj.ObjectMeta.Name)
+	if !done {
+		return "", false, isConflictResponse(taskResp)
+	}
+	id, _ := taskResp["Id"].(string)
+	return id, true, false
+}
+
+// isConflictResponse checks if the task response of a subscription request reports a conflicting subscription
+func isConflictResponse(taskResp map[string]interface{}) bool {
+	if taskResp == nil {
+		return false
+	}
+	body, err := json.Marshal(taskResp)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(body), "ResourceAlreadyExists") || strings.Contains(string(body), "Conflict")
+}
+
+func getDeliveryRetryPolicy(spec infraiov1.EventsubscriptionSpec) string {
+	if spec.DeliveryRetryPolicy == "" {
+		return defaultDeliveryRetryPolicy
+	}
+	return spec.DeliveryRetryPolicy
+}
+
+// getStatusInterval returns the interval configured in eventSubscriptionStatusInterval
+func getStatusInterval() time.Duration {
+	interval, err := time.ParseDuration(config.Data.EventSubscriptionStatusInterval)
+	if err != nil || interval <= 0 {
+		return defaultStatusInterval
+	}
+	return interval
+}
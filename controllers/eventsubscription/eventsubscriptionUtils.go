@@ -33,10 +33,13 @@ type EventSubscriptionInterface interface {
 	GetSystemIDFromURI(resourceURIArr []string, resourceOID string) string
 	UpdateEventSubscriptionLabels()
 	MapOriginResources(subscriptionDetails map[string]interface{}) ([]string, error)
-	GetMessageRegistryDetails(bmcObj *infraiov1.Bmc, registryName string) ([]string, []map[string]interface{})
+	GetMessageRegistryDetails(bmcObj *infraiov1.Bmc, registryPrefixes []string) ([]string, []map[string]interface{})
 	ValidateMessageIDs(messageIDs []string) error
 }
 
+// DefaultMessageRegistryPrefixes are the prefixes of the standard and vendor message registries fetched for every BMC
+var DefaultMessageRegistryPrefixes = []string{"Base", "ResourceEvent", "TaskEvent", "iLOEvents", "IDRAC", "Lenovo"}
+
 // MapCollectionToResourceURI maps collection to corresponding collection URI used while sending create request to ODIM
 var MapCollectionToResourceURI = map[string]string{
 	"systemCollection":  "/redfish/v1/Systems",
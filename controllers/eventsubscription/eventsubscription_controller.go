@@ -30,12 +30,16 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
 	"github.com/ODIM-Project/BMCOperator/config/constants"
 	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
 	l "github.com/ODIM-Project/BMCOperator/logs"
 	"github.com/google/uuid"
@@ -127,6 +131,10 @@ func (r *EventsubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		l.LogWithFields(ctx).Info("Updating labels for event subscription object..")
 		eventSubscriptionUtil.UpdateEventSubscriptionLabels()
 		l.LogWithFields(ctx).Info("Successfully updated lables for event subscription object")
+		if eventSubscriptionObj.Status.ID != "" {
+			// the subscription is checked periodically to report its state in ODIM
+			return ctrl.Result{RequeueAfter: getStatusInterval()}, nil
+		}
 
 	} else {
 		l.LogWithFields(ctx).Info("Please enter all the required details for creating a event subscription")
@@ -138,13 +146,17 @@ func (r *EventsubscriptionReconciler) Reconcile(ctx context.Context, req ctrl.Re
 func (esu *eventsubscriptionUtils) CreateEventsubscription(eventSubObj *infraiov1.Eventsubscription, namespacedName types.NamespacedName) (bool, error) {
 	esu.commonRec.GetUpdatedEventsubscriptionObjects(esu.ctx, namespacedName, esu.eventSubObj)
 	if esu.eventSubObj.Status.ID != "" {
+		return esu.updateEventsubscription()
+	}
+	if esu.hasNoOrigin() {
+		l.LogWithFields(esu.ctx).Infof("No Bmc matches the origin selector of eventsubscription %s, it is created when a Bmc matches", esu.eventSubObj.ObjectMeta.Name)
 		return true, nil
 	}
 	// eventsubscription creation request
 	eventSubReq, err := esu.GetEventSubscriptionRequest()
 	if err == nil {
 		//send request for event subscription creation
-		resp, err := esu.eventsubRestClient.Post("/redfish/v1/EventService/Subscriptions", "Posting eventsubscription creation payload...", eventSubReq)
+		resp, err := esu.eventsubRestClient.Post(subscriptionsURI, "Posting eventsubscription creation payload...", eventSubReq)
 		if err != nil {
 			l.LogWithFields(esu.ctx).Error("error while creating eventsubscription: " + err.Error())
 			return false, err
@@ -178,9 +190,11 @@ func (esu *eventsubscriptionUtils) CreateEventsubscription(eventSubObj *infraiov
 	return false, nil
 }
 
+// UpdateEventsubscriptionStatus updates the status with the details of the subscription with the ID,
+// or of the subscription with the destination and context of the spec when the ID is empty
 func (esu *eventsubscriptionUtils) UpdateEventsubscriptionStatus(eventSubscriptionID string) bool {
 
-	subscriptionList, statusCode, err := esu.eventsubRestClient.Get("/redfish/v1/EventService/Subscriptions", "Fetching all subsciptions..")
+	subscriptionList, statusCode, err := esu.eventsubRestClient.Get(subscriptionsURI, "Fetching all subsciptions..")
 	if err != nil {
 		l.LogWithFields(esu.ctx).Error("error while fetching all subscriptions:" + err.Error())
 		return false
@@ -192,6 +206,9 @@ func (esu *eventsubscriptionUtils) UpdateEventsubscriptionStatus(eventSubscripti
 	if subscriptions, ok := subscriptionList["Members"].([]interface{}); ok {
 		for _, subscription := range subscriptions {
 			reqURL := subscription.(map[string]interface{})["@odata.id"].(string)
+			if eventSubscriptionID != "" && !strings.HasSuffix(reqURL, "/"+eventSubscriptionID) {
+				continue
+			}
 
 			subscriptionDetails, statusCode, err := esu.eventsubRestClient.Get(reqURL, fmt.Sprintf("Fetching %s eventsubscription details..", reqURL[len(reqURL)-1:]))
 
@@ -208,6 +225,10 @@ func (esu *eventsubscriptionUtils) UpdateEventsubscriptionStatus(eventSubscripti
 					if err != nil {
 						l.LogWithFields(esu.ctx).Error(err.Error())
 					}
+					esu.eventSubObj.Status.RequestedOriginResources = esu.eventSubObj.Spec.OriginResources
+					if selected, err := esu.getSelectedBmcs(); err == nil {
+						esu.eventSubObj.Status.SelectedBmcs = getBmcNames(selected)
+					}
 					esu.commonRec.UpdateEventsubscriptionStatus(esu.ctx, esu.eventSubObj, subscriptionDetails, originResources)
 					return true
 				}
@@ -250,7 +271,7 @@ func (esu *eventsubscriptionUtils) GetEventSubscriptionRequest() ([]byte, error)
 		SubordinateResources: esu.eventSubObj.Spec.SubordinateResources,
 		Protocol:             "Redfish",
 		SubscriptionType:     "RedfishEvent",
-		DeliveryRetryPolicy:  "RetryForever",
+		DeliveryRetryPolicy:  getDeliveryRetryPolicy(esu.eventSubObj.Spec),
 		OriginResources:      []map[string]string{},
 	}
 
@@ -333,6 +354,12 @@ func (esu *eventsubscriptionUtils) GetEventSubscriptionRequest() ([]byte, error)
 		}
 	}
 
+	selected, err := esu.getSelectedBmcs()
+	if err != nil {
+		return []byte{}, fmt.Errorf("failed to create eventsubscription request: invalid origin selector: %s", err.Error())
+	}
+	addSelectedSystems(subscribedCollection, selected)
+
 	for key := range subscribedCollection {
 		resourceLink := map[string]string{
 			"@odata.id": key,
@@ -369,9 +396,19 @@ func (esu *eventsubscriptionUtils) DeleteEventsubscription() bool {
 	return false
 }
 
+// UpdateEventSubscriptionLabels sets the eventSubscriptionID label of the object to the ID of the subscription
 func (esu *eventsubscriptionUtils) UpdateEventSubscriptionLabels() {
-	esu.eventSubObj.ObjectMeta.Labels = map[string]string{}
+	if esu.eventSubObj.ObjectMeta.Labels == nil {
+		esu.eventSubObj.ObjectMeta.Labels = map[string]string{}
+	}
+	if esu.eventSubObj.ObjectMeta.Labels["eventSubscriptionID"] == esu.eventSubObj.Status.ID {
+		return
+	}
 	esu.eventSubObj.ObjectMeta.Labels["eventSubscriptionID"] = esu.eventSubObj.Status.ID
+	err := esu.commonRec.GetCommonReconcilerClient().Update(esu.ctx, esu.eventSubObj)
+	if err != nil {
+		l.LogWithFields(esu.ctx).Errorf("error while updating labels of eventsubscription %s: %s", esu.eventSubObj.ObjectMeta.Name, err.Error())
+	}
 }
 
 func (esu *eventsubscriptionUtils) MapOriginResources(subscriptionDetails map[string]interface{}) ([]string, error) {
@@ -423,62 +460,101 @@ func (esu *eventsubscriptionUtils) MapOriginResources(subscriptionDetails map[st
 	return originResources, nil
 }
 
-func (esu *eventsubscriptionUtils) GetMessageRegistryDetails(bmcObj *infraiov1.Bmc, registryName string) ([]string, []map[string]interface{}) {
+// GetMessageRegistryDetails fetches the message registries whose prefix starts with one of the registry prefixes,
+// it returns the prefix and version of each registry along with the registry JSON
+func (esu *eventsubscriptionUtils) GetMessageRegistryDetails(bmcObj *infraiov1.Bmc, registryPrefixes []string) ([]string, []map[string]interface{}) {
 	uri := "/redfish/v1/Registries/"
 	var registryIDs []string
 	var registryResponses []map[string]interface{}
 	resp, sCode, _ := esu.eventsubRestClient.Get(uri, fmt.Sprintf("Fetching registries details for %s BMC", bmcObj.Spec.BmcDetails.Address))
-	if sCode == http.StatusOK {
-		for _, member := range resp["Members"].([]interface{}) {
-			val := member.(map[string]interface{})["@odata.id"].(string)
-			if strings.Contains(val, registryName) {
-				res, statusCode, _ := esu.eventsubRestClient.Get(val, fmt.Sprintf("Fetching bios attribute registry details for %s BMC", bmcObj.Spec.BmcDetails.Address))
-				if statusCode == http.StatusOK {
-					for _, mem := range res["Location"].([]interface{}) {
-						if mem.(map[string]interface{})["Language"].(string) == "en" {
-							url := mem.(map[string]interface{})["Uri"].(string)
-							r, s, _ := esu.eventsubRestClient.Get(url, fmt.Sprintf("Fetching bios attribute registry JSON for %s BMC", bmcObj.Spec.BmcDetails.Address))
-
-							if s == http.StatusOK {
-								registryIDs = append(registryIDs, r["RegistryPrefix"].(string)+"."+r["RegistryVersion"].(string))
-								registryResponses = append(registryResponses, r)
-							}
-						}
-					}
-				}
+	if sCode != http.StatusOK {
+		return registryIDs, registryResponses
+	}
+	members, _ := resp["Members"].([]interface{})
+	for _, member := range members {
+		val, _ := member.(map[string]interface{})["@odata.id"].(string)
+		if val == "" {
+			continue
+		}
+		res, statusCode, _ := esu.eventsubRestClient.Get(val, fmt.Sprintf("Fetching message registry file details for %s BMC", bmcObj.Spec.BmcDetails.Address))
+		if statusCode != http.StatusOK || !isMessageRegistrySupported(res, val, registryPrefixes) {
+			continue
+		}
+		locations, _ := res["Location"].([]interface{})
+		for _, location := range locations {
+			locationDetails, _ := location.(map[string]interface{})
+			url, _ := locationDetails["Uri"].(string)
+			if language, _ := locationDetails["Language"].(string); language != "en" || url == "" {
+				continue
+			}
+			r, s, _ := esu.eventsubRestClient.Get(url, fmt.Sprintf("Fetching message registry JSON for %s BMC", bmcObj.Spec.BmcDetails.Address))
+			if s != http.StatusOK {
+				continue
+			}
+			registryPrefix, _ := r["RegistryPrefix"].(string)
+			registryVersion, _ := r["RegistryVersion"].(string)
+			if _, ok := r["Messages"].(map[string]interface{}); !ok || registryPrefix == "" || registryVersion == "" {
+				l.LogWithFields(esu.ctx).Infof("Ignoring %s, it is not a message registry", url)
+				continue
 			}
+			registryIDs = append(registryIDs, registryPrefix+"."+registryVersion)
+			registryResponses = append(registryResponses, r)
 		}
 	}
 	return registryIDs, registryResponses
 }
 
+// isMessageRegistrySupported checks the Registry property of the registry file, or its URI when the property is missing,
+// against the registry prefixes
+func isMessageRegistrySupported(registryFile map[string]interface{}, uri string, registryPrefixes []string) bool {
+	registry, _ := registryFile["Registry"].(string)
+	if registry == "" {
+		registry = uri[strings.LastIndex(strings.TrimSuffix(uri, "/"), "/")+1:]
+	}
+	for _, prefix := range registryPrefixes {
+		if strings.HasPrefix(strings.ToLower(registry), strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMessageRegistryPrefixes returns the prefixes of the message registries fetched for every BMC,
+// the default prefixes are extended by messageRegistryPrefixes of the operator configuration
+func GetMessageRegistryPrefixes() []string {
+	prefixes := append([]string{}, DefaultMessageRegistryPrefixes...)
+	for _, prefix := range config.Data.MessageRegistryPrefixes {
+		if !slices.Contains(prefixes, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// ValidateMessageIDs checks that every message ID is defined in the EventsMessageRegistry object
+// of its registry prefix and version
 func (esu *eventsubscriptionUtils) ValidateMessageIDs(messageIDs []string) error {
 	for _, messageID := range messageIDs {
-		registryPrefix, registryVersion, messageKey := getMessageDetails(messageID)
-		if strings.Contains(strings.ToLower(registryPrefix), "iloevents") {
-			objName := strings.ToLower(registryPrefix) + "." + registryVersion
-			messageRegistry := esu.commonRec.GetEventMessageRegistryObject(esu.ctx, constants.MetadataName, objName, esu.namespace)
-			if messageRegistry != nil {
-				if _, ok := messageRegistry.Spec.Messages[messageKey]; !ok {
-					l.LogWithFields(esu.ctx).Errorf("message ID %s not found in the message registry %s. Please enter a valid message ID", messageKey, messageRegistry.Spec.Name)
-					return fmt.Errorf("failed to create event subscription request")
-				}
-			} else {
-				return fmt.Errorf("message registry not found for message ID %s", messageID)
-			}
+		_, _, messageKey, err := utils.ParseMessageID(messageID)
+		if err != nil {
+			l.LogWithFields(esu.ctx).Error(err.Error())
+			return fmt.Errorf("failed to create event subscription request")
+		}
+		messageRegistry, err := utils.GetEventMessageRegistryForMessage(esu.ctx, esu.commonRec, messageID, esu.namespace)
+		if err != nil {
+			return fmt.Errorf("failed to fetch message registry for message ID %s: %s", messageID, err.Error())
+		}
+		if messageRegistry == nil {
+			return fmt.Errorf("message registry not found for message ID %s", messageID)
+		}
+		if _, ok := messageRegistry.Spec.Messages[messageKey]; !ok {
+			l.LogWithFields(esu.ctx).Errorf("message ID %s not found in the message registry %s. Please enter a valid message ID", messageKey, messageRegistry.Spec.Name)
+			return fmt.Errorf("failed to create event subscription request")
 		}
 	}
 	return nil
 }
 
-func getMessageDetails(message string) (string, string, string) {
-	message = strings.Replace(message, ".", "-", 1)
-	index := strings.LastIndex(message, ".")
-	parsedMessage := message[:index] + strings.Replace(message[index:], ".", "-", 1)
-	messageDetails := strings.Split(parsedMessage, "-")
-	return messageDetails[0], messageDetails[1], messageDetails[2]
-}
-
 func addSubscriptionToAllCollections(subscribingCollection map[string]bool) {
 	for collectionURI := range MapResourceURIToCollection {
 		subscribingCollection[collectionURI] = true
@@ -501,8 +577,8 @@ func getOriginResourcesForFirmware(subscribedCollection map[string]bool, invento
 // SetupWithManager sets up the controller with the Manager.
 func (r *EventsubscriptionReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&infraiov1.Eventsubscription{}).
-		WithEventFilter(utils.IgnoreStatusUpdate()).
+		For(&infraiov1.Eventsubscription{}, builder.WithPredicates(utils.IgnoreStatusUpdate())).
+		Watches(&source.Kind{Type: &infraiov1.Bmc{}}, handler.EnqueueRequestsFromMapFunc(r.getSubscriptionsForBmc), builder.WithPredicates(bmcSelectionChanged())).
 		Complete(r)
 }
 
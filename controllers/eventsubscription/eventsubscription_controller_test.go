@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var commonRecObj = MockCommonRec{variable: "eventSubscriptionObject"}
@@ -85,3 +86,159 @@ func Test_DeleteEventsubscription(t *testing.T) {
 		t.Errorf("DeleteEventsubscription(): expected: %v, recieved %v", true, deleteResp)
 	}
 }
+
+func Test_isMessageRegistrySupported(t *testing.T) {
+	tests := []struct {
+		name         string
+		registryFile map[string]interface{}
+		uri          string
+		want         bool
+	}{
+		{name: "standard registry", registryFile: map[string]interface{}{"Registry": "Base.1.13"}, uri: "/redfish/v1/Registries/Base.1.13.0", want: true},
+		{name: "dell registry", registryFile: map[string]interface{}{"Registry": "IDRAC.2.8"}, uri: "/redfish/v1/Registries/Messages", want: true},
+		{name: "registry property missing", registryFile: map[string]interface{}{}, uri: "/redfish/v1/Registries/iLOEvents.3.2/", want: true},
+		{name: "bios attribute registry", registryFile: map[string]interface{}{"Registry": "BiosAttributeRegistryU30.v1_0"}, uri: "/redfish/v1/Registries/BiosAttributeRegistryU30.v1_0", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isMessageRegistrySupported(tt.registryFile, tt.uri, DefaultMessageRegistryPrefixes); got != tt.want {
+				t.Errorf("isMessageRegistrySupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getModifiedProperties(t *testing.T) {
+	status := infraiov1.EventsubscriptionStatus{
+		ID:                       "1",
+		Destination:              "https://10.24.1.14:8093/Destination",
+		Context:                  "ODIMRA_Event",
+		EventTypes:               []string{"Alert", "ResourceAdded"},
+		ResourceTypes:            []string{"ComputerSystem"},
+		OriginResources:          []string{"Bmc/10.10.10.10"},
+		RequestedOriginResources: []string{"Bmc/10.10.10.10"},
+		DeliveryRetryPolicy:      "RetryForever",
+	}
+	spec := infraiov1.EventsubscriptionSpec{
+		Destination:     "https://10.24.1.14:8093/Destination",
+		Context:         "ODIMRA_Event",
+		EventTypes:      []string{"ResourceAdded", "Alert"},
+		ResourceTypes:   []string{"ComputerSystem"},
+		OriginResources: []string{"Bmc/10.10.10.10"},
+	}
+	tests := []struct {
+		name          string
+		modify        func(spec *infraiov1.EventsubscriptionSpec)
+		selectedBmcs  []string
+		want          []string
+		wantPatchable bool
+	}{
+		{name: "spec matches status in another order", modify: func(spec *infraiov1.EventsubscriptionSpec) {}, want: nil, wantPatchable: true},
+		{name: "context modified", modify: func(spec *infraiov1.EventsubscriptionSpec) { spec.Context = "Alerts" }, want: []string{"Context"}, wantPatchable: true},
+		{name: "event types and message ids modified", modify: func(spec *infraiov1.EventsubscriptionSpec) {
+			spec.EventTypes = []string{"Alert"}
+			spec.MessageIds = []string{"iLOEvents.3.2.ServerPoweredOn"}
+		}, want: []string{"EventTypes", "MessageIds"}},
+		{name: "origin resources modified", modify: func(spec *infraiov1.EventsubscriptionSpec) {
+			spec.OriginResources = []string{"Bmc/10.10.10.10", "managerCollection"}
+		}, want: []string{"OriginResources"}},
+		{name: "delivery retry policy modified", modify: func(spec *infraiov1.EventsubscriptionSpec) {
+			spec.DeliveryRetryPolicy = "SuspendRetries"
+		}, want: []string{"DeliveryRetryPolicy"}, wantPatchable: true},
+		{name: "bmc selected by origin selector", modify: func(spec *infraiov1.EventsubscriptionSpec) {
+			spec.OriginSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"vendor": "HPE"}}
+		}, selectedBmcs: []string{"10.10.10.11"}, want: []string{"OriginSelector"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modifiedSpec := *spec.DeepCopy()
+			tt.modify(&modifiedSpec)
+			got := getModifiedProperties(modifiedSpec, status, tt.selectedBmcs)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getModifiedProperties() = %v, want %v", got, tt.want)
+			}
+			if isPatchable(got) != tt.wantPatchable {
+				t.Errorf("isPatchable() = %v, want %v", isPatchable(got), tt.wantPatchable)
+			}
+		})
+	}
+}
+
+func Test_addSelectedSystems(t *testing.T) {
+	bmc := func(name, systemID string) infraiov1.Bmc {
+		return infraiov1.Bmc{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: infraiov1.BmcStatus{BmcSystemID: systemID}}
+	}
+	tests := []struct {
+		name       string
+		subscribed map[string]bool
+		selected   []infraiov1.Bmc
+		want       map[string]bool
+	}{
+		{
+			name:       "systems of selected bmcs replace their subordinate resources",
+			subscribed: map[string]bool{"/redfish/v1/Systems/uuid1.1/Bios": true, "/redfish/v1/Managers": true},
+			selected:   []infraiov1.Bmc{bmc("10.10.10.10", "uuid1.1"), bmc("10.10.10.11", "uuid2.1")},
+			want:       map[string]bool{"/redfish/v1/Systems/uuid1.1": true, "/redfish/v1/Systems/uuid2.1": true, "/redfish/v1/Managers": true},
+		},
+		{
+			name:       "system collection already subscribed",
+			subscribed: map[string]bool{"/redfish/v1/Systems": true},
+			selected:   []infraiov1.Bmc{bmc("10.10.10.10", "uuid1.1")},
+			want:       map[string]bool{"/redfish/v1/Systems": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addSelectedSystems(tt.subscribed, tt.selected)
+			if !reflect.DeepEqual(tt.subscribed, tt.want) {
+				t.Errorf("addSelectedSystems() = %v, want %v", tt.subscribed, tt.want)
+			}
+		})
+	}
+}
+
+func Test_patchEventsubscription(t *testing.T) {
+	eventSubObj := &infraiov1.Eventsubscription{
+		Spec: infraiov1.EventsubscriptionSpec{
+			Destination:         "https://10.24.1.14:8093/Destination",
+			Context:             "ODIMRA_Event_Modified",
+			DeliveryRetryPolicy: "TerminateAfterRetries",
+		},
+		Status: infraiov1.EventsubscriptionStatus{
+			ID:          "1234",
+			Destination: "https://10.24.1.14:8093/Destination",
+			Context:     "ODIMRA_Event",
+		},
+	}
+	tests := []struct {
+		name       string
+		restClient mockRestClient
+		want       bool
+	}{
+		{
+			name:       "patch accepted",
+			restClient: mockRestClient{url: subscriptionsURI + "/1234"},
+			want:       true,
+		},
+		{
+			name:       "patch not supported",
+			restClient: mockRestClient{url: subscriptionsURI + "/5678"},
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restClient := tt.restClient
+			eventSubUtils := &eventsubscriptionUtils{
+				ctx:                context.TODO(),
+				eventsubRestClient: &restClient,
+				commonRec:          &commonRecObj,
+				eventSubObj:        eventSubObj,
+				namespace:          "bmc-op",
+			}
+			if got := eventSubUtils.patchEventsubscription(); got != tt.want {
+				t.Errorf("patchEventsubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
@@ -219,8 +220,18 @@ func (m *mockRestClient) Post(string, string, interface{}) (*http.Response, erro
 func (m *mockRestClient) Get(string, string) (map[string]interface{}, int, error) {
 	return nil, 0, nil
 }
-func (m *mockRestClient) Patch(string, string, interface{}) (*http.Response, error) {
-	return nil, nil
+func (m *mockRestClient) Patch(url, reason string, body interface{}) (*http.Response, error) {
+	if _, ok := body.([]byte); !ok {
+		return nil, fmt.Errorf("request body of %s is not a byte slice", url)
+	}
+	if m.url != "" && m.url != url {
+		return &http.Response{Status: "404 Not Found", StatusCode: 404}, nil
+	}
+	resp := &http.Response{
+		Status:     "200 OK",
+		StatusCode: 200,
+	}
+	return resp, nil
 }
 func (m *mockRestClient) Delete(string, string) (*http.Response, error) {
 	resp := &http.Response{
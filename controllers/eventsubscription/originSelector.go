@@ -0,0 +1,103 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// getSelectedBmcs returns the Bmc objects added to ODIM which match the origin selector of the spec,
+// sorted by name, nil is returned when the spec has no origin selector
+func (esu *eventsubscriptionUtils) getSelectedBmcs() ([]infraiov1.Bmc, error) {
+	if esu.eventSubObj.Spec.OriginSelector == nil {
+		return nil, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(esu.eventSubObj.Spec.OriginSelector)
+	if err != nil {
+		return nil, err
+	}
+	bmcList := &infraiov1.BmcList{}
+	err = esu.commonRec.GetCommonReconcilerClient().List(esu.ctx, bmcList, client.InNamespace(esu.namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, err
+	}
+	selected := []infraiov1.Bmc{}
+	for _, bmcObj := range bmcList.Items {
+		if bmcObj.Status.BmcSystemID != "" && bmcObj.GetDeletionTimestamp() == nil {
+			selected = append(selected, bmcObj)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool { return selected[i].Name < selected[j].Name })
+	return selected, nil
+}
+
+func getBmcNames(bmcObjs []infraiov1.Bmc) []string {
+	if bmcObjs == nil {
+		return nil
+	}
+	names := []string{}
+	for _, bmcObj := range bmcObjs {
+		names = append(names, bmcObj.Name)
+	}
+	return names
+}
+
+// addSelectedSystems subscribes to the systems of the selected Bmc objects
+func addSelectedSystems(subscribedCollection map[string]bool, selected []infraiov1.Bmc) {
+	if _, ok := subscribedCollection["/redfish/v1/Systems"]; ok {
+		return
+	}
+	for _, bmcObj := range selected {
+		subscribedCollection["/redfish/v1/Systems/"+bmcObj.Status.BmcSystemID] = true
+		removeSubordinateResourceSubscriptions(subscribedCollection, bmcObj.Status.BmcSystemID)
+	}
+}
+
+// getSubscriptionsForBmc returns the subscriptions of the bmc namespace which have an origin selector
+func (r *EventsubscriptionReconciler) getSubscriptionsForBmc(obj client.Object) []reconcile.Request {
+	subscriptionList := &infraiov1.EventsubscriptionList{}
+	err := r.List(context.TODO(), subscriptionList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, subscription := range subscriptionList.Items {
+		if subscription.Spec.OriginSelector != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: subscription.Name, Namespace: subscription.Namespace}})
+		}
+	}
+	return requests
+}
+
+// bmcSelectionChanged filters the updates of Bmc objects which can change the result of origin selectors
+func bmcSelectionChanged() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !reflect.DeepEqual(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels()) ||
+				utils.IsDeletionTimestampChanged(e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
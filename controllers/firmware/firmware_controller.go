@@ -76,6 +76,19 @@ func (r *FirmwareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return ctrl.Result{}, err
 	}
 	if firmObj.ObjectMeta.Labels == nil || (firmObj.ObjectMeta.Annotations["old_firmware"] != "" && firmObj.ObjectMeta.Annotations["old_firmware"] != firmObj.Spec.Image.ImageLocation) {
+		bmcObj := commonRec.GetBmcObject(ctx, constants.MetadataName, firmObj.Name, req.Namespace)
+		if deferred, deferredUntil := utils.GetMaintenanceWindowDeferral(ctx, r.Client, bmcObj); deferred {
+			if message := utils.GetDeferredMessage(deferredUntil); firmObj.Status.Deferred != message {
+				firmObj.Status.Deferred = message
+				err = r.Status().Update(ctx, firmObj)
+				if err != nil {
+					l.LogWithFields(ctx).Error("Error: Updating firmware status " + err.Error())
+					return ctrl.Result{}, err
+				}
+			}
+			l.LogWithFields(ctx).Info(fmt.Sprintf("Firmware update of %s is deferred until %s", firmObj.Name, deferredUntil.Format(time.RFC3339)))
+			return ctrl.Result{RequeueAfter: time.Until(deferredUntil)}, nil
+		}
 		l.LogWithFields(ctx).Info("Firmware update starting...")
 		odimObject := commonRec.GetOdimObject(ctx, constants.MetadataName, "odim", req.Namespace)
 		firmRestClient, err := restclient.NewRestClient(ctx, odimObject, commonRec.(*utils.CommonReconciler), constants.BMCOPERATOR)
@@ -113,7 +126,7 @@ func (r *FirmwareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		firmUtil.UpdateFirmwareLabels(firmVersion)
 		commonRec.GetUpdatedFirmwareObject(ctx, req.NamespacedName, firmObj) // getting updated firmware object after updating labels to avoid updating on old firmware object
 		firmUtil.UpdateFirmwareStatus("Success", firmVersion, firmObj.Spec.Image.ImageLocation)
-		bmcObj := commonRec.GetBmcObject(ctx, constants.MetadataName, firmObj.Name, req.Namespace)
+		bmcObj = commonRec.GetBmcObject(ctx, constants.MetadataName, firmObj.Name, req.Namespace)
 		newSchema := firmUtil.checkIfBiosSchemaRegistryChanged(bmcObj)
 		firmUtil.UpdateBmcObjectWithFirmwareVersionAndSchema(bmcObj, firmVersion, newSchema)
 		l.LogWithFields(ctx).Info("Firmware and Bmc object updation completed!")
@@ -122,41 +135,48 @@ func (r *FirmwareReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	return ctrl.Result{}, nil
 }
 
+// checkIfBiosSchemaRegistryChanged returns the new BIOS attribute registry of the bmc when the firmware update changed it,
+// the registry object is created and the difference with the previous registry is recorded in the bmc status
 func (fu *firmwareUtils) checkIfBiosSchemaRegistryChanged(bmcObj *infraiov1.Bmc) string {
-	allBiosSchemaRegistryObjects := fu.commonRec.GetAllBiosSchemaRegistryObjects(fu.ctx, fu.namespace)
-	allBiosSchemaRegistryObjectsNames := getBiosSchemaRegistryObjectNames(allBiosSchemaRegistryObjects)
 	var biosAttributeID string
 	var registryResponse map[string]interface{}
 	systemDetails := fu.commonUtil.GetBmcSystemDetails(fu.ctx, bmcObj)
 	if biosVersion, ok := systemDetails["BiosVersion"].(string); ok {
 		biosAttributeID, registryResponse = fu.biosUtil.GetBiosAttributeID(biosVersion, bmcObj)
 	}
-	if biosAttributeID != "" {
-		formattedBiosAttributeID := utils.RemoveSpecialChar(biosAttributeID)
-		for _, schemaName := range allBiosSchemaRegistryObjectsNames {
-			if formattedBiosAttributeID != schemaName {
-				l.LogWithFields(fu.ctx).Info("Bios Schema Registry has changed, pulling the new schema...")
-				created := fu.commonRec.CheckAndCreateBiosSchemaObject(fu.ctx, registryResponse, bmcObj)
-				if created {
-					l.LogWithFields(fu.ctx).Info(fmt.Sprintf("New Bios Schema Registry object %s created", schemaName))
-					return biosAttributeID
-				}
-			} else {
-				l.LogWithFields(fu.ctx).Info("Bios Schema Registry has not changed")
-			}
-		}
-	} else {
+	if biosAttributeID == "" {
 		l.LogWithFields(fu.ctx).Info("Not able to find the Bios Version")
+		return ""
 	}
-	return ""
+	if biosAttributeID == bmcObj.Status.BiosAttributeRegistry {
+		l.LogWithFields(fu.ctx).Info("Bios Schema Registry has not changed")
+		return ""
+	}
+	l.LogWithFields(fu.ctx).Info("Bios Schema Registry has changed, pulling the new schema...")
+	if !fu.commonRec.CheckAndCreateBiosSchemaObject(fu.ctx, registryResponse, bmcObj) {
+		return ""
+	}
+	l.LogWithFields(fu.ctx).Info(fmt.Sprintf("Bios Schema Registry object %s is used by %s BMC", utils.RemoveSpecialChar(biosAttributeID), bmcObj.Spec.BmcDetails.Address))
+	fu.recordBiosRegistryChange(bmcObj, biosAttributeID)
+	return biosAttributeID
 }
 
-func getBiosSchemaRegistryObjectNames(allBiosSchemaRegistryObjects *[]infraiov1.BiosSchemaRegistry) []string {
-	allBiosRegistryFormattedNames := []string{}
-	for _, biosRegistry := range *allBiosSchemaRegistryObjects {
-		allBiosRegistryFormattedNames = append(allBiosRegistryFormattedNames, biosRegistry.ObjectMeta.Name)
+// recordBiosRegistryChange sets the difference between the previous and the new registry of the bmc in its status,
+// the status is updated with the new registry
+func (fu *firmwareUtils) recordBiosRegistryChange(bmcObj *infraiov1.Bmc, biosAttributeID string) {
+	if bmcObj.Status.BiosAttributeRegistry == "" {
+		return
+	}
+	previous := fu.commonRec.GetBiosSchemaObject(fu.ctx, constants.MetadataName, utils.RemoveSpecialChar(bmcObj.Status.BiosAttributeRegistry), fu.namespace)
+	current := fu.commonRec.GetBiosSchemaObject(fu.ctx, constants.MetadataName, utils.RemoveSpecialChar(biosAttributeID), fu.namespace)
+	if previous == nil || current == nil {
+		l.LogWithFields(fu.ctx).Info(fmt.Sprintf("Not able to compare Bios Schema Registry %s with %s", bmcObj.Status.BiosAttributeRegistry, biosAttributeID))
+		return
 	}
-	return allBiosRegistryFormattedNames
+	change := utils.GetBiosRegistryChange(previous, current)
+	bmcObj.Status.BiosRegistryChange = change
+	l.LogWithFields(fu.ctx).Info(fmt.Sprintf("Bios Schema Registry of %s BMC changed from %s to %s: %d attributes added, %d removed, %d changed",
+		bmcObj.Spec.BmcDetails.Address, change.PreviousRegistry, change.CurrentRegistry, len(change.AddedAttributes), len(change.RemovedAttributes), len(change.ChangedAttributes)))
 }
 
 func (fu *firmwareUtils) CreateFirmwareInSystem(firmwarePayload []byte) (bool, bool, error) {
@@ -230,6 +250,7 @@ func formatForLabels(firmVersion string) string {
 // UpdateFirmwareStatus updates the firmware status
 func (fu *firmwareUtils) UpdateFirmwareStatus(status, firmVersion, imageLocation string) {
 	fu.firmObj.Status.Status = status
+	fu.firmObj.Status.Deferred = ""
 	if firmVersion != "" {
 		fu.firmObj.Status.FirmwareVersion = firmVersion
 		fu.firmObj.Status.ImagePath = imageLocation
@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
 	config "github.com/ODIM-Project/BMCOperator/controllers/config"
 	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
@@ -28,7 +29,7 @@ const (
 	// DefaultEventSubscriptionName is the event subscription name for bmc operator event listener
 	DefaultEventSubscriptionName = "BmcOperatorSubscription"
 	// DefaultEventSubscriptionContext is the context of event subscription for bmc operator event listener
-	DefaultEventSubscriptionContext = "Default Bmc-Operator EventSubscription"
+	DefaultEventSubscriptionContext = constants.DefaultEventSubscriptionContext
 )
 
 type odimInterface interface {
@@ -67,7 +68,13 @@ type Link struct {
 
 // GetEventSubscriptionPayload return the payload of event subscription for bmc operator event listener
 func GetEventSubscriptionPayload(destination string) []byte {
-	payload := EventSubscriptionPayload{
+	body, _ := json.Marshal(getEventSubscription(destination))
+	return body
+}
+
+// getEventSubscription returns the event subscription for bmc operator event listener built from the operatorEventSubscription* configuration
+func getEventSubscription(destination string) EventSubscriptionPayload {
+	return EventSubscriptionPayload{
 		Name:                 DefaultEventSubscriptionName,
 		Destination:          destination + "/OdimEvents",
 		EventTypes:           config.Data.OperatorEventSubscriptionEventTypes,
@@ -85,7 +92,4 @@ func GetEventSubscriptionPayload(destination string) []byte {
 			},
 		},
 	}
-
-	body, _ := json.Marshal(payload)
-	return body
 }
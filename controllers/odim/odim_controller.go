@@ -19,9 +19,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"net/url"
 	"os"
-	"path"
+	"strings"
 
 	Error "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -33,6 +32,7 @@ import (
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
 	"github.com/ODIM-Project/BMCOperator/config/constants"
 	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
 	eventsClient "github.com/ODIM-Project/BMCOperator/controllers/eventsClient"
 	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
@@ -93,7 +93,7 @@ func (r *OdimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	isOdimMarkedToBeDeleted := odimObj.GetDeletionTimestamp() != nil
 	if isOdimMarkedToBeDeleted {
 		if controllerutil.ContainsFinalizer(odimObj, odimFinalizer) {
-			isEventSubscriptionRemoved := removeEventsSubscription(ctx, odimRestClient)
+			isEventSubscriptionRemoved := removeEventsSubscription(ctx, odimRestClient, odimObj)
 			if isEventSubscriptionRemoved {
 				controllerutil.RemoveFinalizer(odimObj, odimFinalizer)
 				err = r.Update(ctx, odimObj)
@@ -126,16 +126,26 @@ func (r *OdimReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	}
 
 	commonRec.GetUpdatedOdimObject(ctx, req.NamespacedName, odimObj)
-	// starting event listener
-	go eventsClient.Start(r.Client, r.Scheme)
-	// Creating default event subscription so that odim will send the events to the server operator listener
-	err = CreateEventSubscription(ctx, odimRestClient, odimObj.Spec.EventListenerHost)
-	if err != nil {
-		l.LogWithFields(ctx).Error("error while creating the event subscription ", err.Error())
+	if strings.EqualFold(config.Data.EventListener.Mode, eventsClient.SSEMode) {
+		l.LogWithFields(ctx).Info("Event listener is in SSE mode, events are read from the event stream of ODIM")
 		return ctrl.Result{}, nil
 	}
-	l.LogWithFields(ctx).Debugf("event subscription is created with destination %s", odimObj.Spec.EventListenerHost)
-	return ctrl.Result{}, nil
+	// Reconciling default event subscription so that odim will send the events to the server operator listener
+	subscriptionID, err := reconcileOperatorSubscription(ctx, odimRestClient, odimObj)
+	if err != nil {
+		l.LogWithFields(ctx).Error("error while reconciling the event subscription ", err.Error())
+		return ctrl.Result{RequeueAfter: getSubscriptionCheckInterval()}, nil
+	}
+	l.LogWithFields(ctx).Debugf("event subscription %s is present with destination %s", subscriptionID, odimObj.Spec.EventListenerHost)
+	if odimObj.Status.EventSubscriptionID != subscriptionID {
+		odimObj.Status.EventSubscriptionID = subscriptionID
+		err = r.Status().Update(ctx, odimObj)
+		if err != nil {
+			l.LogWithFields(ctx).Error("Error: Updating event subscription ID in status of ODIM" + err.Error())
+		}
+	}
+	// requeue so that changes of the operatorEventSubscription* configuration and subscriptions removed from odim are reconciled
+	return ctrl.Result{RequeueAfter: getSubscriptionCheckInterval()}, nil
 }
 
 // SetupWithManager sets up the controller with the Manager.
@@ -206,66 +216,21 @@ func getOdimUtils(ctx context.Context, restClient restclient.RestClientInterface
 	}
 }
 
-// CreateEventSubscription creates event subscription for server operator event listener
-func CreateEventSubscription(ctx context.Context, restClient restclient.RestClientInterface, destination string) error {
-	uri := "/redfish/v1/EventService/Subscriptions"
+// CreateEventSubscription creates event subscription for server operator event listener and returns its ID
+func CreateEventSubscription(ctx context.Context, restClient restclient.RestClientInterface, destination string) (string, error) {
 	body := GetEventSubscriptionPayload(destination)
-	resp, err := restClient.Post(uri, "Creating default event subscription", body)
+	resp, err := restClient.Post(subscriptionsURI, "Creating default event subscription", body)
 	if err != nil {
-		return fmt.Errorf("error while adding default subscription for server operator: %s", err.Error())
+		return "", fmt.Errorf("error while adding default subscription for server operator: %s", err.Error())
 	}
-	if resp.StatusCode == http.StatusAccepted {
-		commonUtil := common.GetCommonUtils(restClient)
-		ok, taskResp := commonUtil.MoniteringTaskmon(resp.Header, ctx, common.EVENTSUBSCRIPTION, destination)
-		if !ok {
-			return fmt.Errorf("failed to create event subscription: task response %v", taskResp)
-		}
-	}
-	return nil
-}
-
-func removeEventsSubscription(ctx context.Context, restClient restclient.RestClientInterface) bool {
-	subscriptionsIds := []string{}
-	subscriptionsCollectionUri := "/redfish/v1/EventService/Subscriptions"
-	subscriptionsUrl := "/redfish/v1/EventService/Subscriptions/%s"
-	resp, statusCode, err := restClient.Get(subscriptionsCollectionUri, "Getting all  event subscription collections")
-	if err != nil {
-		l.LogWithFields(ctx).Errorf("error while getting event subscription collections for bmc operator: %s", err.Error())
-		return false
+	if resp.StatusCode != http.StatusAccepted {
+		return "", fmt.Errorf("received status code %d while adding default subscription for server operator", resp.StatusCode)
 	}
-	if statusCode == http.StatusOK {
-		for _, mem := range resp["Members"].([]interface{}) {
-			members := mem.(map[string]interface{})
-			member, err := url.Parse(members["@odata.id"].(string))
-			if err != nil {
-				l.LogWithFields(ctx).Errorf("error while parsing the url %v", err)
-				return false
-			}
-			subscriptionsIds = append(subscriptionsIds, path.Base(member.String()))
-		}
-	}
-	// Checking for the operator subscription
-	for _, subs := range subscriptionsIds {
-		subUri := fmt.Sprintf(subscriptionsUrl, subs)
-		resp, statusCode, err := restClient.Get(subUri, "Creating default event subscription")
-		if err != nil {
-			l.LogWithFields(ctx).Errorf("error while getting subscription for bmc operator: %s", err.Error())
-			return false
-		}
-		if statusCode == http.StatusOK {
-			if resp["Name"] == "BmcOperatorSubscription" {
-				resp, err := restClient.Delete(subUri, "Deleting the operator events subscription")
-				if err != nil {
-					l.LogWithFields(ctx).Errorf("error while deleting subscription for bmc operator: %s", err.Error())
-					return false
-				}
-				if resp.StatusCode == http.StatusOK {
-					l.LogWithFields(ctx).Infof("deleted events subscription with ID %s", subs)
-					return true
-				}
-			}
-		}
+	commonUtil := common.GetCommonUtils(restClient)
+	ok, taskResp := commonUtil.MoniteringTaskmon(resp.Header, ctx, common.EVENTSUBSCRIPTION, destination)
+	if !ok {
+		return "", fmt.Errorf("failed to create event subscription: task response %v", taskResp)
 	}
-	l.LogWithFields(ctx).Error("Received unexpected status code while deleting the events subscription")
-	return false
+	id, _ := taskResp["Id"].(string)
+	return id, nil
 }
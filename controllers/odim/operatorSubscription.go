@@ -0,0 +1,173 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"time"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+const (
+	subscriptionsURI = "/redfish/v1/EventService/Subscriptions"
+	// defaultSubscriptionCheckInterval is the interval at which the operator event subscription is reconciled
+	// when eventSubscriptionStatusInterval is not configured
+	defaultSubscriptionCheckInterval = 5 * time.Minute
+)
+
+// reconcileOperatorSubscription makes sure that exactly one event subscription of the bmc operator event listener
+// matching the operatorEventSubscription* configuration is present in ODIM, and returns its ID
+func reconcileOperatorSubscription(ctx context.Context, restClient restclient.RestClientInterface, odimObj *infraiov1.Odim) (string, error) {
+	destination := odimObj.Spec.EventListenerHost
+	subscriptions, err := getOperatorSubscriptions(ctx, restClient, odimObj)
+	if err != nil {
+		return "", err
+	}
+	subscriptionID := ""
+	if details, ok := subscriptions[odimObj.Status.EventSubscriptionID]; ok && isOperatorSubscriptionUpToDate(details, destination) {
+		subscriptionID = odimObj.Status.EventSubscriptionID
+	}
+	ids := getSortedIDs(subscriptions)
+	for _, id := range ids {
+		if subscriptionID == "" && isOperatorSubscriptionUpToDate(subscriptions[id], destination) {
+			subscriptionID = id
+		}
+	}
+	// outdated and duplicate subscriptions are removed before creating a new one,
+	// as ODIM does not allow two subscriptions with the same destination
+	for _, id := range ids {
+		if id == subscriptionID {
+			continue
+		}
+		l.LogWithFields(ctx).Infof("removing outdated or duplicate operator event subscription %s", id)
+		if err := deleteOperatorSubscription(ctx, restClient, id); err != nil {
+			return "", err
+		}
+	}
+	if subscriptionID != "" {
+		return subscriptionID, nil
+	}
+	return CreateEventSubscription(ctx, restClient, destination)
+}
+
+// getOperatorSubscriptions returns the details of all the event subscriptions of the bmc operator event listener in ODIM
+// along with the subscription recorded in the status, which is kept to replace it when EventListenerHost changes.
+// Subscriptions of other operator instances sharing ODIM have a different destination and are left out
+func getOperatorSubscriptions(ctx context.Context, restClient restclient.RestClientInterface, odimObj *infraiov1.Odim) (map[string]map[string]interface{}, error) {
+	subscriptions := map[string]map[string]interface{}{}
+	resp, statusCode, err := restClient.Get(subscriptionsURI, "Getting all event subscription collections")
+	if err != nil {
+		return nil, fmt.Errorf("error while getting event subscription collections for bmc operator: %s", err.Error())
+	}
+	if statusCode != http.StatusOK {
+		return nil, fmt.Errorf("received status code %d while getting event subscription collections for bmc operator", statusCode)
+	}
+	members, _ := resp["Members"].([]interface{})
+	for _, mem := range members {
+		member, _ := mem.(map[string]interface{})
+		subURI, _ := member["@odata.id"].(string)
+		if subURI == "" {
+			continue
+		}
+		details, statusCode, err := restClient.Get(subURI, "Getting event subscription details")
+		if err != nil {
+			return nil, fmt.Errorf("error while getting subscription %s for bmc operator: %s", subURI, err.Error())
+		}
+		id := path.Base(subURI)
+		if statusCode == http.StatusOK && (isOperatorSubscription(details, odimObj.Spec.EventListenerHost) ||
+			(id == odimObj.Status.EventSubscriptionID && details["Name"] == DefaultEventSubscriptionName)) {
+			subscriptions[id] = details
+		}
+	}
+	return subscriptions, nil
+}
+
+// isOperatorSubscription checks if the subscription in ODIM is the operator subscription for the event listener destination
+func isOperatorSubscription(details map[string]interface{}, destination string) bool {
+	subDestination, _ := details["Destination"].(string)
+	return details["Name"] == DefaultEventSubscriptionName && subDestination == getEventSubscription(destination).Destination
+}
+
+// isOperatorSubscriptionUpToDate checks if the subscription in ODIM matches the destination and the operatorEventSubscription* configuration
+func isOperatorSubscriptionUpToDate(details map[string]interface{}, destination string) bool {
+	payload := getEventSubscription(destination)
+	subContext, _ := details["Context"].(string)
+	subDestination, _ := details["Destination"].(string)
+	return subDestination == payload.Destination && subContext == payload.Context &&
+		utils.CompareArray(getStringArray(details["EventTypes"]), payload.EventTypes) &&
+		utils.CompareArray(getStringArray(details["MessageIds"]), payload.MessageIds) &&
+		utils.CompareArray(getStringArray(details["ResourceTypes"]), payload.ResourceTypes)
+}
+
+// deleteOperatorSubscription deletes the subscription with the given ID from ODIM
+func deleteOperatorSubscription(ctx context.Context, restClient restclient.RestClientInterface, id string) error {
+	resp, err := restClient.Delete(path.Join(subscriptionsURI, id), "Deleting the operator events subscription")
+	if err != nil {
+		return fmt.Errorf("error while deleting subscription %s for bmc operator: %s", id, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("received status code %d while deleting subscription %s for bmc operator", resp.StatusCode, id)
+	}
+	l.LogWithFields(ctx).Infof("deleted events subscription with ID %s", id)
+	return nil
+}
+
+// removeEventsSubscription deletes all the event subscriptions of the bmc operator event listener from ODIM
+func removeEventsSubscription(ctx context.Context, restClient restclient.RestClientInterface, odimObj *infraiov1.Odim) bool {
+	subscriptions, err := getOperatorSubscriptions(ctx, restClient, odimObj)
+	if err != nil {
+		l.LogWithFields(ctx).Error(err.Error())
+		return false
+	}
+	for _, id := range getSortedIDs(subscriptions) {
+		if err := deleteOperatorSubscription(ctx, restClient, id); err != nil {
+			l.LogWithFields(ctx).Error(err.Error())
+			return false
+		}
+	}
+	return true
+}
+
+// getSubscriptionCheckInterval returns the interval configured in eventSubscriptionStatusInterval
+func getSubscriptionCheckInterval() time.Duration {
+	interval, err := time.ParseDuration(config.Data.EventSubscriptionStatusInterval)
+	if err != nil || interval <= 0 {
+		return defaultSubscriptionCheckInterval
+	}
+	return interval
+}
+
+func getStringArray(value interface{}) []string {
+	items, _ := value.([]interface{})
+	return utils.ConvertInterfaceToStringArray(items)
+}
+
+func getSortedIDs(subscriptions map[string]map[string]interface{}) []string {
+	ids := make([]string, 0, len(subscriptions))
+	for id := range subscriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,96 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+)
+
+func TestIsOperatorSubscriptionUpToDate(t *testing.T) {
+	config.Data.OperatorEventSubscriptionEventTypes = []string{"ResourceAdded", "Alert"}
+	config.Data.OperatorEventSubscriptionMeesageIds = []string{"ResourceEvent.1.2.0.ResourceAdded"}
+	config.Data.OperatorEventSubscriptionResourceTypes = []string{"ComputerSystems"}
+	details := func(destination string, eventTypes ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"Name":          DefaultEventSubscriptionName,
+			"Destination":   destination,
+			"Context":       DefaultEventSubscriptionContext,
+			"EventTypes":    eventTypes,
+			"MessageIds":    []interface{}{"ResourceEvent.1.2.0.ResourceAdded"},
+			"ResourceTypes": []interface{}{"ComputerSystems"},
+		}
+	}
+	tests := []struct {
+		name    string
+		details map[string]interface{}
+		want    bool
+	}{
+		{
+			name:    "subscription matching the configuration",
+			details: details("https://10.0.0.1:32123/OdimEvents", "Alert", "ResourceAdded"),
+			want:    true,
+		},
+		{
+			name:    "event types changed in configuration",
+			details: details("https://10.0.0.1:32123/OdimEvents", "ResourceAdded"),
+			want:    false,
+		},
+		{
+			name:    "destination changed",
+			details: details("https://10.0.0.2:32123/OdimEvents", "Alert", "ResourceAdded"),
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOperatorSubscriptionUpToDate(tt.details, "https://10.0.0.1:32123"); got != tt.want {
+				t.Errorf("isOperatorSubscriptionUpToDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsOperatorSubscription(t *testing.T) {
+	tests := []struct {
+		name    string
+		details map[string]interface{}
+		want    bool
+	}{
+		{
+			name:    "subscription of this operator",
+			details: map[string]interface{}{"Name": DefaultEventSubscriptionName, "Destination": "https://10.0.0.1:32123/OdimEvents"},
+			want:    true,
+		},
+		{
+			name:    "subscription of another operator sharing ODIM",
+			details: map[string]interface{}{"Name": DefaultEventSubscriptionName, "Destination": "https://10.0.0.2:32123/OdimEvents"},
+			want:    false,
+		},
+		{
+			name:    "other subscription to the event listener",
+			details: map[string]interface{}{"Name": "ClientSubscription", "Destination": "https://10.0.0.1:32123/OdimEvents"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isOperatorSubscription(tt.details, "https://10.0.0.1:32123"); got != tt.want {
+				t.Errorf("isOperatorSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+)
+
+const (
+	// defaultPasswordLength is used when length is not given in the policy
+	defaultPasswordLength = 16
+	// minPasswordLength is the minimum length allowed for a generated password
+	minPasswordLength = 8
+	// maxPasswordLength is the maximum length allowed for a generated password, most BMCs reject longer passwords
+	maxPasswordLength = 32
+
+	// rotation status of a BMC
+	rotationSuccess = "Success"
+	rotationFailed  = "Failed"
+	rotationSkipped = "Skipped"
+	// rotationDesynced is set when the password is rotated on the BMC but could not be stored in ODIM or in the bmc object
+	rotationDesynced = "Desynced"
+	// rotationConflict is set when the BMC is selected by several policies, the BMC is not rotated
+	rotationConflict = "Conflict"
+
+	odimDesyncedMessage      = "password is rotated on the BMC but ODIM holds the previous password"
+	bmcObjectDesyncedMessage = "password is rotated on the BMC but the BMC object holds the previous password"
+
+	// desyncedRetryInterval is the interval to retry storing a rotated password in the bmc object
+	desyncedRetryInterval = time.Minute
+)
+
+// characterClasses maps the supported character classes to their characters
+var characterClasses = map[string]string{
+	"lowercase": "abcdefghijklmnopqrstuvwxyz",
+	"uppercase": "ABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"digits":    "0123456789",
+	"symbols":   "!#$%&()*+,-.:;<=>?@[]^_{}~",
+}
+
+// defaultCharacterClasses are used when character classes are not given in the policy
+var defaultCharacterClasses = []string{"lowercase", "uppercase", "digits", "symbols"}
+
+// PasswordRotationInterface declares method signatures to be defined by password rotation utils
+type PasswordRotationInterface interface {
+	RotateBmcPassword(bmcObj *infraiov1.Bmc) infraiov1.BmcPasswordRotation
+	GeneratePassword() (string, error)
+	StoreBmcCredentials(bmcObj *infraiov1.Bmc, password string) (string, error)
+	RepairBmcCredentials(bmcObj *infraiov1.Bmc) infraiov1.BmcPasswordRotation
+}
+
+type passwordRotationUtils struct {
+	ctx        context.Context
+	policyObj  *infraiov1.PasswordRotationPolicy
+	commonRec  utils.ReconcilerInterface
+	restClient restclient.RestClientInterface
+	commonUtil common.CommonInterface
+	namespace  string
+}
+
+// GetPasswordRotationUtils will return passwordRotationUtils struct
+func GetPasswordRotationUtils(ctx context.Context, policyObj *infraiov1.PasswordRotationPolicy, commonRec utils.ReconcilerInterface, restClient restclient.RestClientInterface, ns string) PasswordRotationInterface {
+	return &passwordRotationUtils{
+		ctx:        ctx,
+		policyObj:  policyObj,
+		commonRec:  commonRec,
+		restClient: restClient,
+		commonUtil: common.GetCommonUtils(restClient),
+		namespace:  ns,
+	}
+}
+
+// GeneratePassword generates a random password with the length and character classes of the policy,
+// password contains at least one character from every character class
+func (pu *passwordRotationUtils) GeneratePassword() (string, error) {
+	return generatePassword(pu.policyObj.Spec.Length, pu.policyObj.Spec.CharacterClasses)
+}
+
+func generatePassword(length int, classes []string) (string, error) {
+	if length == 0 {
+		length = defaultPasswordLength
+	}
+	if len(classes) == 0 {
+		classes = defaultCharacterClasses
+	}
+	if length < minPasswordLength || length > maxPasswordLength {
+		return "", fmt.Errorf("password length should be between %d and %d", minPasswordLength, maxPasswordLength)
+	}
+	if length < len(classes) {
+		return "", fmt.Errorf("password length %d is less than number of character classes", length)
+	}
+	var allChars string
+	password := []byte{}
+	for _, class := range classes {
+		chars, ok := characterClasses[strings.ToLower(class)]
+		if !ok {
+			return "", fmt.Errorf("unsupported character class %s", class)
+		}
+		char, err := randomChar(chars)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, char)
+		allChars += chars
+	}
+	for len(password) < length {
+		char, err := randomChar(allChars)
+		if err != nil {
+			return "", err
+		}
+		password = append(password, char)
+	}
+	// shuffle so that the mandatory characters are not always at the beginning
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+	return string(password), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	idx, err := rand.Int(rand.Reader, big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, err
+	}
+	return chars[idx.Int64()], nil
+}
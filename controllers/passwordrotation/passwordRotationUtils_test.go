@@ -0,0 +1,66 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"strings"
+	"testing"
+)
+
+func Test_generatePassword(t *testing.T) {
+	tests := []struct {
+		name       string
+		length     int
+		classes    []string
+		wantLength int
+		wantErr    bool
+	}{
+		{name: "default length and classes", wantLength: defaultPasswordLength},
+		{name: "digits and uppercase only", length: 12, classes: []string{"digits", "uppercase"}, wantLength: 12},
+		{name: "too short", length: 4, wantErr: true},
+		{name: "too long", length: 64, wantErr: true},
+		{name: "unsupported class", length: 12, classes: []string{"emoji"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := generatePassword(tt.length, tt.classes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("generatePassword() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != tt.wantLength {
+				t.Errorf("generatePassword() length = %d, want %d", len(got), tt.wantLength)
+			}
+			classes := tt.classes
+			if len(classes) == 0 {
+				classes = defaultCharacterClasses
+			}
+			allowed := ""
+			for _, class := range classes {
+				if !strings.ContainsAny(got, characterClasses[class]) {
+					t.Errorf("generatePassword() = %s, does not contain %s", got, class)
+				}
+				allowed += characterClasses[class]
+			}
+			for _, char := range got {
+				if !strings.ContainsRune(allowed, char) {
+					t.Errorf("generatePassword() = %s, contains unexpected character %c", got, char)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,387 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+// Package controllers ...
+package controllers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	Error "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	bmc "github.com/ODIM-Project/BMCOperator/controllers/bmc"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	credentials "github.com/ODIM-Project/BMCOperator/controllers/credentials"
+	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	"github.com/google/uuid"
+)
+
+// PasswordRotationPolicyReconciler reconciles a PasswordRotationPolicy object
+type PasswordRotationPolicyReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+var podName = os.Getenv("POD_NAME")
+
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=passwordrotationpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=passwordrotationpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infra.io.odimra,resources=passwordrotationpolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;create;patch;update;watch
+
+// Reconcile rotates the password of every selected BMC whose last rotation is older than the policy interval
+// and requeues the policy for the next due rotation
+func (r *PasswordRotationPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	transactionId := uuid.New()
+	ctx = l.CreateContextForLogging(ctx, transactionId.String(), constants.BmcOperator, constants.PasswordRotationActionID, constants.PasswordRotationActionName, podName)
+	policyObj := &infraiov1.PasswordRotationPolicy{}
+	err := r.Get(ctx, req.NamespacedName, policyObj)
+	if err != nil {
+		if Error.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	interval := policyObj.Spec.Interval.Duration
+	if interval <= 0 {
+		l.LogWithFields(ctx).Info(fmt.Sprintf("Interval of %s password rotation policy is not valid", policyObj.Name))
+		return ctrl.Result{}, nil
+	}
+	selector := labels.Everything()
+	if policyObj.Spec.BmcSelector != nil {
+		selector, err = metav1.LabelSelectorAsSelector(policyObj.Spec.BmcSelector)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Invalid bmc selector in %s password rotation policy: %s", policyObj.Name, err.Error())
+			return ctrl.Result{}, nil
+		}
+	}
+	bmcList := &infraiov1.BmcList{}
+	err = r.List(ctx, bmcList, client.InNamespace(req.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		l.LogWithFields(ctx).Error("Error fetching BMC objects: " + err.Error())
+		return ctrl.Result{}, err
+	}
+	commonRec := utils.GetCommonReconciler(r.Client, r.Scheme)
+	odimObj := commonRec.GetOdimObject(ctx, constants.MetadataName, "odim", req.Namespace)
+	if odimObj == nil {
+		return ctrl.Result{RequeueAfter: interval}, nil
+	}
+	restClient, err := restclient.NewRestClient(ctx, odimObj, commonRec.(*utils.CommonReconciler), constants.BMCOPERATOR)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Failed to get rest client for ODIM" + err.Error())
+		return ctrl.Result{}, err
+	}
+	policyList := &infraiov1.PasswordRotationPolicyList{}
+	err = r.List(ctx, policyList, client.InNamespace(req.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Error("Error fetching password rotation policies: " + err.Error())
+		return ctrl.Result{}, err
+	}
+	rotationUtil := GetPasswordRotationUtils(ctx, policyObj, commonRec, restClient, req.Namespace)
+	requeueAfter := interval
+	now := time.Now()
+	for i := range bmcList.Items {
+		bmcObj := &bmcList.Items[i]
+		if bmcObj.Status.BmcAddStatus != "yes" {
+			continue
+		}
+		rotation := policyObj.Status.Bmcs[bmcObj.Name]
+		conflicts := getConflictingPolicies(policyObj.Name, policyList.Items, bmcObj)
+		switch {
+		case rotation.Status == rotationDesynced:
+			// the rotation already applied on the BMC is completed whatever the other policies
+			rotation = rotationUtil.RepairBmcCredentials(bmcObj)
+		case len(conflicts) != 0:
+			l.LogWithFields(ctx).Info(fmt.Sprintf("%s BMC is selected by %s password rotation policy and by %s, skipping password rotation", bmcObj.Spec.BmcDetails.Address, policyObj.Name, strings.Join(conflicts, ", ")))
+			rotation.Status = rotationConflict
+			rotation.Message = fmt.Sprintf("BMC is also selected by %s password rotation policies", strings.Join(conflicts, ", "))
+		case rotation.LastRotationTime != nil && now.Before(rotation.LastRotationTime.Add(interval)):
+			if nextRotation := rotation.LastRotationTime.Add(interval); nextRotation.Sub(now) < requeueAfter {
+				requeueAfter = nextRotation.Sub(now)
+			}
+			continue
+		default:
+			rotation = rotationUtil.RotateBmcPassword(bmcObj)
+		}
+		if rotation.Status == rotationDesynced && desyncedRetryInterval < requeueAfter {
+			requeueAfter = desyncedRetryInterval
+		}
+		// status is written for every BMC, so that a rotation is recorded even if the next one fails
+		err = r.updateRotationStatus(ctx, policyObj, bmcObj.Name, rotation)
+		if err != nil {
+			l.LogWithFields(ctx).Error(fmt.Sprintf("Error: Updating status of %s password rotation policy: %s", policyObj.Name, err.Error()))
+			return ctrl.Result{}, err
+		}
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// updateRotationStatus records the rotation of the BMC in the status of the latest version of the policy,
+// the update is retried on conflict
+func (r *PasswordRotationPolicyReconciler) updateRotationStatus(ctx context.Context, policyObj *infraiov1.PasswordRotationPolicy, bmcName string, rotation infraiov1.BmcPasswordRotation) error {
+	if current, ok := policyObj.Status.Bmcs[bmcName]; ok && reflect.DeepEqual(current, rotation) {
+		return nil
+	}
+	key := types.NamespacedName{Name: policyObj.Name, Namespace: policyObj.Namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestPolicyObj := &infraiov1.PasswordRotationPolicy{}
+		err := r.Get(ctx, key, latestPolicyObj)
+		if err != nil {
+			return err
+		}
+		if latestPolicyObj.Status.Bmcs == nil {
+			latestPolicyObj.Status.Bmcs = map[string]infraiov1.BmcPasswordRotation{}
+		}
+		latestPolicyObj.Status.Bmcs[bmcName] = rotation
+		if rotation.Status == rotationSuccess && rotation.LastRotationTime != nil &&
+			(latestPolicyObj.Status.LastRotationTime == nil || latestPolicyObj.Status.LastRotationTime.Before(rotation.LastRotationTime)) {
+			latestPolicyObj.Status.LastRotationTime = rotation.LastRotationTime
+		}
+		err = r.Status().Update(ctx, latestPolicyObj)
+		if err != nil {
+			return err
+		}
+		*policyObj = *latestPolicyObj
+		return nil
+	})
+}
+
+// getConflictingPolicies returns the names of the other policies selecting the bmc object, sorted by name
+func getConflictingPolicies(policyName string, policies []infraiov1.PasswordRotationPolicy, bmcObj *infraiov1.Bmc) []string {
+	conflicts := []string{}
+	for _, policy := range policies {
+		if policy.Name == policyName || policy.GetDeletionTimestamp() != nil {
+			continue
+		}
+		selector := labels.Everything()
+		if policy.Spec.BmcSelector != nil {
+			var err error
+			selector, err = metav1.LabelSelectorAsSelector(policy.Spec.BmcSelector)
+			if err != nil {
+				continue
+			}
+		}
+		if selector.Matches(labels.Set(bmcObj.GetLabels())) {
+			conflicts = append(conflicts, policy.Name)
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PasswordRotationPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&infraiov1.PasswordRotationPolicy{}).
+		Watches(&source.Kind{Type: &infraiov1.Bmc{}}, handler.EnqueueRequestsFromMapFunc(r.getPoliciesForBmc)).
+		WithEventFilter(utils.IgnoreStatusUpdate()).
+		Complete(r)
+}
+
+// getPoliciesForBmc returns the policies of the bmc namespace, so that newly added BMCs are rotated
+func (r *PasswordRotationPolicyReconciler) getPoliciesForBmc(obj client.Object) []reconcile.Request {
+	policyList := &infraiov1.PasswordRotationPolicyList{}
+	err := r.List(context.TODO(), policyList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for _, policy := range policyList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: policy.Name, Namespace: policy.Namespace}})
+	}
+	return requests
+}
+
+// RotateBmcPassword generates a new password, applies it on the BMC and ODIM and stores it in a secret,
+// returns the rotation details to be recorded in the policy status
+func (pu *passwordRotationUtils) RotateBmcPassword(bmcObj *infraiov1.Bmc) infraiov1.BmcPasswordRotation {
+	rotation := pu.policyObj.Status.Bmcs[bmcObj.Name]
+	rotation.Message = ""
+	if bmcObj.Spec.Credentials.CredentialRef != "" && strings.EqualFold(config.Data.CredentialProvider, credentials.VaultProvider) {
+		l.LogWithFields(pu.ctx).Info(fmt.Sprintf("Credentials of %s BMC are managed in vault, skipping password rotation", bmcObj.Spec.BmcDetails.Address))
+		rotation.Status = rotationSkipped
+		return rotation
+	}
+	if !utils.IsEncrypted(bmcObj.Spec.Credentials.Password) {
+		l.LogWithFields(pu.ctx).Info(fmt.Sprintf("Password change is in progress for %s BMC, skipping password rotation", bmcObj.Spec.BmcDetails.Address))
+		rotation.Status = rotationSkipped
+		return rotation
+	}
+	password, err := pu.GeneratePassword()
+	if err != nil {
+		l.LogWithFields(pu.ctx).Errorf("Error generating password for %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+		rotation.Status = rotationFailed
+		return rotation
+	}
+	bmcUtil := bmc.GetBmcUtils(pu.ctx, bmcObj, pu.namespace, &pu.commonRec, &pu.restClient, pu.commonUtil, false)
+	err = bmcUtil.RotatePassword(password)
+	// when only ODIM could not be updated the new password is kept and ODIM is updated again on repair
+	odimUpdatePending := errors.Is(err, bmc.ErrOdimPasswordNotUpdated)
+	if err != nil && !odimUpdatePending {
+		l.LogWithFields(pu.ctx).Errorf("Error rotating password for %s BMC: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+		rotation.Status = rotationFailed
+		return rotation
+	}
+	rotationTime := metav1.Now()
+	rotation.LastRotationTime = &rotationTime
+	secretName, err := pu.StoreBmcCredentials(bmcObj, password)
+	if err != nil {
+		// password is already applied, it is still stored encrypted in the bmc object below
+		l.LogWithFields(pu.ctx).Errorf("Error storing rotated password of %s BMC in secret: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+	} else {
+		rotation.SecretName = secretName
+	}
+	err = pu.updateBmcCredentials(bmcObj, bmcObj.Spec.Credentials.Password, rotation.SecretName)
+	if err != nil {
+		// password is already applied on the BMC and ODIM, the bmc object is repaired from the secret in the next reconcile
+		l.LogWithFields(pu.ctx).Errorf("Error: Updating rotated password in %s BMC object: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+		rotation.Status = rotationDesynced
+		rotation.OdimUpdatePending = odimUpdatePending
+		rotation.Message = bmcObjectDesyncedMessage
+		return rotation
+	}
+	if odimUpdatePending {
+		l.LogWithFields(pu.ctx).Errorf("Rotated password of %s BMC could not be updated in ODIM, retrying in %s", bmcObj.Spec.BmcDetails.Address, desyncedRetryInterval)
+		rotation.Status = rotationDesynced
+		rotation.OdimUpdatePending = true
+		rotation.Message = odimDesyncedMessage
+		return rotation
+	}
+	rotation.Status = rotationSuccess
+	return rotation
+}
+
+// RepairBmcCredentials stores the rotated password kept in the secret of the rotation in the bmc object,
+// used when the bmc object could not be updated after the password was rotated
+func (pu *passwordRotationUtils) RepairBmcCredentials(bmcObj *infraiov1.Bmc) infraiov1.BmcPasswordRotation {
+	rotation := pu.policyObj.Status.Bmcs[bmcObj.Name]
+	if rotation.SecretName == "" {
+		l.LogWithFields(pu.ctx).Errorf("Rotated password of %s BMC was not stored in a secret, update the credentials of the BMC object manually", bmcObj.Spec.BmcDetails.Address)
+		return rotation
+	}
+	secret := &corev1.Secret{}
+	err := pu.commonRec.GetCommonReconcilerClient().Get(pu.ctx, types.NamespacedName{Name: rotation.SecretName, Namespace: config.Data.Namespace}, secret)
+	if err != nil {
+		l.LogWithFields(pu.ctx).Errorf("Error getting %s secret of %s BMC: %s", rotation.SecretName, bmcObj.Spec.BmcDetails.Address, err.Error())
+		return rotation
+	}
+	password := utils.DecryptWithPrivateKey(pu.ctx, string(secret.Data["password"]), *utils.GetPrivateKey(), false) //doBase64Decode = false, because password fetched from secret is already decoded
+	if password == "" {
+		l.LogWithFields(pu.ctx).Errorf("Could not decrypt password stored in %s secret of %s BMC", rotation.SecretName, bmcObj.Spec.BmcDetails.Address)
+		return rotation
+	}
+	if rotation.OdimUpdatePending {
+		bmcUtil := bmc.GetBmcUtils(pu.ctx, bmcObj.DeepCopy(), pu.namespace, &pu.commonRec, &pu.restClient, pu.commonUtil, false)
+		err = bmcUtil.UpdateOdimPassword(password)
+		if err != nil {
+			l.LogWithFields(pu.ctx).Errorf("Error: Updating rotated password of %s BMC in ODIM: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+			return rotation
+		}
+		l.LogWithFields(pu.ctx).Info(fmt.Sprintf("Rotated password of %s BMC is updated in ODIM", bmcObj.Spec.BmcDetails.Address))
+		rotation.OdimUpdatePending = false
+	}
+	encryptedPassword := utils.EncryptWithPublicKey(pu.ctx, password, *utils.GetPublicKey())
+	if encryptedPassword == "" {
+		return rotation
+	}
+	err = pu.updateBmcCredentials(bmcObj, encryptedPassword, rotation.SecretName)
+	if err != nil {
+		l.LogWithFields(pu.ctx).Errorf("Error: Updating rotated password in %s BMC object: %s", bmcObj.Spec.BmcDetails.Address, err.Error())
+		rotation.Message = bmcObjectDesyncedMessage
+		return rotation
+	}
+	l.LogWithFields(pu.ctx).Info(fmt.Sprintf("Rotated password of %s BMC is restored in the BMC object from %s secret", bmcObj.Spec.BmcDetails.Address, rotation.SecretName))
+	rotation.Status = rotationSuccess
+	rotation.Message = ""
+	return rotation
+}
+
+// updateBmcCredentials stores the encrypted password and the credential secret in the latest version of the bmc object,
+// the update is retried on conflict
+func (pu *passwordRotationUtils) updateBmcCredentials(bmcObj *infraiov1.Bmc, encryptedPassword, secretName string) error {
+	k8sClient := pu.commonRec.GetCommonReconcilerClient()
+	key := types.NamespacedName{Name: bmcObj.Name, Namespace: bmcObj.Namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestBmcObj := &infraiov1.Bmc{}
+		err := k8sClient.Get(pu.ctx, key, latestBmcObj)
+		if err != nil {
+			return err
+		}
+		latestBmcObj.Spec.Credentials.Password = encryptedPassword
+		if latestBmcObj.Spec.Credentials.CredentialRef == "" {
+			latestBmcObj.Spec.Credentials.CredentialRef = secretName
+		}
+		err = k8sClient.Update(pu.ctx, latestBmcObj)
+		if err != nil {
+			return err
+		}
+		*bmcObj = *latestBmcObj
+		return nil
+	})
+}
+
+// StoreBmcCredentials stores the username and the password encrypted with operator public key in
+// <bmc name>-credentials secret, the secret can be used as credentialRef of the bmc object
+func (pu *passwordRotationUtils) StoreBmcCredentials(bmcObj *infraiov1.Bmc, password string) (string, error) {
+	secretName := bmcObj.Spec.Credentials.CredentialRef
+	if secretName == "" {
+		secretName = fmt.Sprintf("%s-credentials", bmcObj.Name)
+	}
+	encryptedPassword := utils.EncryptToBytes(pu.ctx, password, *utils.GetPublicKey())
+	if len(encryptedPassword) == 0 {
+		return "", fmt.Errorf("could not encrypt password")
+	}
+	k8sClient := pu.commonRec.GetCommonReconcilerClient()
+	secret := &corev1.Secret{}
+	err := k8sClient.Get(pu.ctx, types.NamespacedName{Name: secretName, Namespace: config.Data.Namespace}, secret)
+	if err != nil && !Error.IsNotFound(err) {
+		return "", err
+	}
+	secret.Data = map[string][]byte{
+		"username": []byte(bmcObj.Spec.Credentials.Username),
+		"password": encryptedPassword,
+	}
+	if Error.IsNotFound(err) {
+		secret.ObjectMeta = metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: config.Data.Namespace,
+			Labels:    map[string]string{"bmc": bmcObj.Name},
+		}
+		secret.Type = corev1.SecretTypeBasicAuth
+		return secretName, k8sClient.Create(pu.ctx, secret)
+	}
+	return secretName, k8sClient.Update(pu.ctx, secret)
+}
@@ -0,0 +1,65 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_getConflictingPolicies(t *testing.T) {
+	policy := func(name string, selector *metav1.LabelSelector) infraiov1.PasswordRotationPolicy {
+		return infraiov1.PasswordRotationPolicy{ObjectMeta: metav1.ObjectMeta{Name: name}, Spec: infraiov1.PasswordRotationPolicySpec{BmcSelector: selector}}
+	}
+	bmcObj := &infraiov1.Bmc{ObjectMeta: metav1.ObjectMeta{Name: "bmc1", Labels: map[string]string{"vendor": "HPE", "rack": "r12"}}}
+	tests := []struct {
+		name     string
+		policies []infraiov1.PasswordRotationPolicy
+		want     []string
+	}{
+		{
+			name:     "only policy",
+			policies: []infraiov1.PasswordRotationPolicy{policy("hpe", &metav1.LabelSelector{MatchLabels: map[string]string{"vendor": "HPE"}})},
+			want:     []string{},
+		},
+		{
+			name: "other policy selects other bmcs",
+			policies: []infraiov1.PasswordRotationPolicy{
+				policy("hpe", &metav1.LabelSelector{MatchLabels: map[string]string{"vendor": "HPE"}}),
+				policy("dell", &metav1.LabelSelector{MatchLabels: map[string]string{"vendor": "DELL"}}),
+			},
+			want: []string{},
+		},
+		{
+			name: "other policies select the bmc",
+			policies: []infraiov1.PasswordRotationPolicy{
+				policy("hpe", &metav1.LabelSelector{MatchLabels: map[string]string{"vendor": "HPE"}}),
+				policy("rack", &metav1.LabelSelector{MatchLabels: map[string]string{"rack": "r12"}}),
+				policy("all", nil),
+			},
+			want: []string{"all", "rack"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getConflictingPolicies("hpe", tt.policies, bmcObj); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getConflictingPolicies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"github.com/ODIM-Project/BMCOperator/config/constants"
+	bios "github.com/ODIM-Project/BMCOperator/controllers/bios"
+	common "github.com/ODIM-Project/BMCOperator/controllers/common"
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+)
+
+// EventHandler reconciles the resources affected by an event
+type EventHandler func(ctx context.Context, r *PollingReconciler, event OdimEvent)
+
+// EventRoute routes events whose OriginOfCondition matches OriginPattern and whose event type is one of EventTypes
+// or whose message name is one of MessageNames to the Handler, the message name is the message ID without the
+// registry prefix and version, for example ServerPoweredOn for iLOEvents.3.2.ServerPoweredOn
+type EventRoute struct {
+	Name          string
+	OriginPattern *regexp.Regexp
+	EventTypes    []string
+	MessageNames  []string
+	Handler       EventHandler
+}
+
+const resourceIDPattern = "[a-zA-Z0-9._-]+"
+
+// names of the event routes, additional message names can be configured for a route in eventRouteMessages
+const (
+	systemRoute   = "system"
+	biosRoute     = "bios"
+	volumeRoute   = "volume"
+	firmwareRoute = "firmware"
+	powerRoute    = "power"
+)
+
+// eventRoutes is not modified after initialization so that it can be read by the event workers without a lock,
+// events matching several routes are handled by each of them
+var eventRoutes = []EventRoute{
+	{
+		Name:          systemRoute,
+		OriginPattern: regexp.MustCompile("^/redfish/v1/Systems/" + resourceIDPattern + "[/]*$"),
+		MessageNames:  []string{"ResourceAdded", "ResourceRemoved", "ServerPostDiscoveryComplete"},
+		Handler: func(ctx context.Context, r *PollingReconciler, event OdimEvent) {
+			r.processEventsForSystemsResource(ctx, event.MessageID, event.OriginOfCondition.Oid)
+		},
+	},
+	{
+		Name:          biosRoute,
+		OriginPattern: regexp.MustCompile("^/redfish/v1/Systems/" + resourceIDPattern + "/Bios(/Settings)?[/]*$"),
+		EventTypes:    []string{"ResourceUpdated"},
+		MessageNames:  []string{"ResourceUpdated", "ResourceChanged"},
+		Handler:       checkBiosForEvent,
+	},
+	{
+		Name:          volumeRoute,
+		OriginPattern: regexp.MustCompile("^/redfish/v1/Systems/" + resourceIDPattern + "/Storage(/.*)?$"),
+		EventTypes:    []string{"ResourceAdded", "ResourceRemoved", "ResourceUpdated"},
+		MessageNames:  []string{"ResourceAdded", "ResourceRemoved", "ResourceUpdated", "ResourceCreated", "ResourceChanged"},
+		Handler:       checkVolumesForEvent,
+	},
+	{
+		Name:          firmwareRoute,
+		OriginPattern: regexp.MustCompile("^/redfish/v1/(Managers|UpdateService/FirmwareInventory)/" + resourceIDPattern + "[/]*$"),
+		EventTypes:    []string{"ResourceUpdated"},
+		MessageNames:  []string{"ResourceUpdated", "ResourceChanged"},
+		Handler:       checkFirmwareForEvent,
+	},
+	{
+		Name:          powerRoute,
+		OriginPattern: regexp.MustCompile("^/redfish/v1/Systems/" + resourceIDPattern + "[/]*$"),
+		MessageNames: []string{
+			// iLOEvents registry of HPE iLO
+			"ServerPoweredOn", "ServerPoweredOff",
+			// IDRAC registry of Dell iDRAC, system is turning on, system is turning off and system CPU resetting
+			"SYS1000", "SYS1001", "SYS1003",
+			// registry of Lenovo XClarity Controller, host power turned off and host power cycled
+			"FQXSPPW0008I", "FQXSPPW0009I",
+		},
+		Handler: checkPowerStateForEvent,
+	},
+}
+
+// getMatchingEventRoutes returns the routes matching the event
+func getMatchingEventRoutes(event OdimEvent) []EventRoute {
+	routes := []EventRoute{}
+	if event.OriginOfCondition == nil {
+		return routes
+	}
+	messageName := getMessageName(event.MessageID)
+	for _, route := range eventRoutes {
+		if !route.OriginPattern.MatchString(event.OriginOfCondition.Oid) {
+			continue
+		}
+		messageNames := append(append([]string{}, route.MessageNames...), config.Data.EventRouteMessages[route.Name]...)
+		if containsFold(route.EventTypes, event.EventType) || containsFold(messageNames, messageName) {
+			routes = append(routes, route)
+		}
+	}
+	return routes
+}
+
+// getMessageName returns the message ID without the registry prefix and version
+func getMessageName(messageID string) string {
+	return messageID[strings.LastIndex(messageID, ".")+1:]
+}
+
+// containsFold checks if the list contains the value ignoring the case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if value != "" && strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// getBmcObjectForOrigin returns the bmc object of the resource which originated the event
+func (r *PollingReconciler) getBmcObjectForOrigin(ctx context.Context, originOfCondition string) *infraiov1.Bmc {
+	return utils.GetBmcObjectForOrigin(ctx, r.commonRec, originOfCondition, r.namespace)
+}
+
+// checkBiosForEvent accommodates or reverts the BIOS attributes of the system whose BIOS is updated
+func checkBiosForEvent(ctx context.Context, r *PollingReconciler, event OdimEvent) {
+	bmcObj := r.getBmcObjectForOrigin(ctx, event.OriginOfCondition.Oid)
+	if bmcObj == nil {
+		return
+	}
+	switch config.Data.Reconciliation {
+	case constants.Accommodate:
+		biosUtil := bios.GetBiosUtils(ctx, &infraiov1.BiosSetting{}, r.commonRec, r.pollRestClient, r.namespace)
+		r.AccommodateBiosInfo(ctx, biosUtil, bmcObj.Status.BmcSystemID)
+	case constants.Revert:
+		r.CheckAndRevertBios(ctx, *bmcObj, r.pollRestClient)
+	}
+}
+
+// checkVolumesForEvent accommodates or reverts the volumes of the system whose storage is updated
+func checkVolumesForEvent(ctx context.Context, r *PollingReconciler, event OdimEvent) {
+	bmcObj := r.getBmcObjectForOrigin(ctx, event.OriginOfCondition.Oid)
+	if bmcObj == nil {
+		return
+	}
+	volumeObjectsForStorageControllerInOperator := r.commonRec.GetAllVolumeObjectIds(ctx, bmcObj, r.namespace)
+	volumeObjectsForStorageControllerFromODIM := r.getAllVolumeObjectIdsFromODIM(bmcObj, ctx)
+	if volumeObjectsForStorageControllerInOperator == nil || volumeObjectsForStorageControllerFromODIM == nil {
+		l.LogWithFields(ctx).Info(fmt.Sprintf("Could not successfully retrieve volume IDs for %s Bmc", bmcObj.ObjectMeta.Name))
+		return
+	}
+	switch config.Data.Reconciliation {
+	case constants.Accommodate:
+		r.checkVolumeCreatedAndAccommodate(bmcObj, volumeObjectsForStorageControllerFromODIM, volumeObjectsForStorageControllerInOperator)
+		r.checkVolumeDeletedAndAccommodate(bmcObj, volumeObjectsForStorageControllerFromODIM, volumeObjectsForStorageControllerInOperator)
+	case constants.Revert:
+		r.checkVolumeCreatedAndRevert(bmcObj, volumeObjectsForStorageControllerFromODIM, volumeObjectsForStorageControllerInOperator)
+		r.checkVolumeDeletedAndRevert(bmcObj, volumeObjectsForStorageControllerFromODIM, volumeObjectsForStorageControllerInOperator)
+	}
+}
+
+// checkFirmwareForEvent accommodates or reverts the firmware version of the BMC whose manager or firmware inventory is updated
+func checkFirmwareForEvent(ctx context.Context, r *PollingReconciler, event OdimEvent) {
+	bmcObj := r.getBmcObjectForOrigin(ctx, event.OriginOfCondition.Oid)
+	if bmcObj == nil {
+		return
+	}
+	if common.MapOfFirmware[bmcObj.GetName()] {
+		l.LogWithFields(ctx).Info(fmt.Sprintf("Firmware update is in progress for %s BMC, skipping firmware check", bmcObj.GetName()))
+		return
+	}
+	r.bmcObject = bmcObj
+	switch config.Data.Reconciliation {
+	case constants.Accommodate:
+		r.CheckAndAccomodateFirmware(ctx, r.pollRestClient)
+	case constants.Revert:
+		r.CheckAndRevertFirmwareVersion(ctx, *bmcObj, r.pollRestClient)
+	}
+}
+
+// checkPowerStateForEvent accommodates or reverts the power state of the system which is powered on, off or reset
+func checkPowerStateForEvent(ctx context.Context, r *PollingReconciler, event OdimEvent) {
+	r.bmcObject = r.getBmcObjectForOrigin(ctx, event.OriginOfCondition.Oid)
+	if r.bmcObject == nil {
+		return
+	}
+	if r.checkIfSystemIsUndergoingReset() {
+		l.LogWithFields(ctx).Info(fmt.Sprintf("%s BMC is undergoing reset, skipping power state check", r.bmcObject.GetName()))
+		return
+	}
+	switch config.Data.Reconciliation {
+	case constants.Accommodate:
+		r.AccommodateState()
+	case constants.Revert:
+		r.RevertState()
+	}
+}
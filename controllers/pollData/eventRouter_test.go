@@ -0,0 +1,57 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	config "github.com/ODIM-Project/BMCOperator/controllers/config"
+)
+
+func Test_getMatchingEventRoutes(t *testing.T) {
+	config.Data.EventRouteMessages = map[string][]string{powerRoute: {"CustomPowerMessage"}}
+	defer func() { config.Data.EventRouteMessages = nil }()
+	tests := []struct {
+		name      string
+		eventType string
+		messageID string
+		origin    string
+		want      []string
+	}{
+		{name: "system added", eventType: "ResourceAdded", messageID: "ResourceEvent.1.2.0.ResourceAdded", origin: "/redfish/v1/Systems/0b3f0e2c.1", want: []string{systemRoute}},
+		{name: "post discovery complete", eventType: "Alert", messageID: "iLOEvents.3.2.ServerPostDiscoveryComplete", origin: "/redfish/v1/Systems/0b3f0e2c.1/", want: []string{systemRoute}},
+		{name: "bios updated", eventType: "ResourceUpdated", messageID: "ResourceEvent.1.2.0.ResourceUpdated", origin: "/redfish/v1/Systems/0b3f0e2c.1/Bios/Settings", want: []string{biosRoute}},
+		{name: "volume created", eventType: "ResourceAdded", messageID: "ResourceEvent.1.2.0.ResourceCreated", origin: "/redfish/v1/Systems/0b3f0e2c.1/Storage/DE00A000/Volumes/1", want: []string{volumeRoute}},
+		{name: "manager updated", eventType: "ResourceUpdated", messageID: "ResourceEvent.1.2.0.ResourceChanged", origin: "/redfish/v1/Managers/0b3f0e2c.1", want: []string{firmwareRoute}},
+		{name: "firmware inventory updated", eventType: "ResourceUpdated", messageID: "ResourceEvent.1.2.0.ResourceUpdated", origin: "/redfish/v1/UpdateService/FirmwareInventory/0b3f0e2c.3", want: []string{firmwareRoute}},
+		{name: "iLO power on", eventType: "Alert", messageID: "iLOEvents.3.2.ServerPoweredOn", origin: "/redfish/v1/Systems/0b3f0e2c.1", want: []string{powerRoute}},
+		{name: "Dell power off", eventType: "Alert", messageID: "IDRAC.2.8.SYS1001", origin: "/redfish/v1/Systems/0b3f0e2c.1", want: []string{powerRoute}},
+		{name: "configured message name", eventType: "Alert", messageID: "Vendor.1.0.CustomPowerMessage", origin: "/redfish/v1/Systems/0b3f0e2c.1", want: []string{powerRoute}},
+		{name: "unrelated alert", eventType: "Alert", messageID: "iLOEvents.3.2.FanFailed", origin: "/redfish/v1/Chassis/0b3f0e2c.1", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := OdimEvent{EventType: tt.eventType, MessageID: tt.messageID, OriginOfCondition: &Link{Oid: tt.origin}}
+			got := []string{}
+			for _, route := range getMatchingEventRoutes(event) {
+				got = append(got, route.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getMatchingEventRoutes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -34,9 +34,24 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
+// Poller runs PollDetails as a runnable of the manager, so that polling and reverting happen only on the leader
+type Poller struct {
+	Manager manager.Manager
+}
+
+// Start polls the details until the manager is stopped
+func (p *Poller) Start(ctx context.Context) error {
+	PollDetails(ctx, p.Manager)
+	return nil
+}
+
+// NeedLeaderElection makes the manager start polling only on the elected leader
+func (p *Poller) NeedLeaderElection() bool {
+	return true
+}
+
 // PollDetails is used to get data a some time interval
-func PollDetails(mgr manager.Manager) {
-	ctx := context.Background()
+func PollDetails(ctx context.Context, mgr manager.Manager) {
 	transactionID := uuid.New()
 	ctx = l.CreateContextForLogging(ctx, transactionID.String(), constants.BmcOperator, constants.PollingActionID, constants.PollingActionName, podName)
 	r := PollingReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()} //TODO: getPollingUtils
@@ -48,7 +63,12 @@ func PollDetails(mgr manager.Manager) {
 	config.Ticker = time.NewTicker(interval)
 	defer config.Ticker.Stop()
 	var wg sync.WaitGroup
-	for range config.Ticker.C {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-config.Ticker.C:
+		}
 		l.LogWithFields(ctx).Info("reconciling....")
 
 		if config.Data.Reconciliation != "" || config.Data.EventSubReconciliation != "" {
@@ -417,7 +417,7 @@ func (m *mockEventSubscriptionUtil) MapOriginResources(subscriptionDetails map[s
 func (m *MockCommonRec) GetRootCAFromSecret(ctx context.Context) []byte {
 	return []byte{}
 }
-func (m *mockEventSubscriptionUtil) GetMessageRegistryDetails(bmcObj *infraiov1.Bmc, registryName string) ([]string, []map[string]interface{}) {
+func (m *mockEventSubscriptionUtil) GetMessageRegistryDetails(bmcObj *infraiov1.Bmc, registryPrefixes []string) ([]string, []map[string]interface{}) {
 	return []string{}, nil
 }
 func (m *mockEventSubscriptionUtil) ValidateMessageIDs(messageIDs []string) error {
@@ -18,31 +18,30 @@ package controllers
 import (
 	"context"
 	"path"
-	"regexp"
 	"strings"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
 	"github.com/ODIM-Project/BMCOperator/config/constants"
 	bios "github.com/ODIM-Project/BMCOperator/controllers/bios"
+	bmc "github.com/ODIM-Project/BMCOperator/controllers/bmc"
 	common "github.com/ODIM-Project/BMCOperator/controllers/common"
 	config "github.com/ODIM-Project/BMCOperator/controllers/config"
 	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
+	volume "github.com/ODIM-Project/BMCOperator/controllers/volume"
 	l "github.com/ODIM-Project/BMCOperator/logs"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-var resourceReconcilerMap map[string]string = map[string]string{
-	"bmc": "/redfish/v1/Systems/[a-zA-Z0-9._-]+[/]*$",
-}
-
 // OdimEventMessage contains information of Events and message details including arguments
 type OdimEventMessage struct {
-	OdataType string      `json:"@odata.type"`
-	Name      string      `json:"Name"`
-	Context   string      `json:"@odata.context"`
-	Events    []OdimEvent `json:"Events"`
+	OdataType string `json:"@odata.type"`
+	Name      string `json:"Name"`
+	Context   string `json:"@odata.context"`
+	// SubscriptionContext is the context of the event subscription the event is sent for
+	SubscriptionContext string      `json:"Context"`
+	Events              []OdimEvent `json:"Events"`
 }
 
 // OdimEvent contains the details of the event subscribed from PMB
@@ -66,31 +65,46 @@ type Link struct {
 func ProcessOdimEvent(ctx context.Context, client client.Client,
 	Scheme *runtime.Scheme, eventName string, event OdimEvent) {
 
-	for resource, regexString := range resourceReconcilerMap {
-		regExp := regexp.MustCompile(regexString)
-		if ok := regExp.MatchString(event.OriginOfCondition.Oid); ok {
-			switch resource {
-			case "bmc":
-				r, err := GetPollingReconciler(ctx, client, Scheme)
-				if err != nil {
-					l.LogWithFields(ctx).Warnf("Event can not be processed: %s", err.Error())
-					return
-				}
-				r.processEventsForSystemsResource(ctx, event.MessageID, event.OriginOfCondition.Oid)
-			}
-		}
+	if event.OriginOfCondition == nil {
+		return
+	}
+	routes := getMatchingEventRoutes(event)
+	if len(routes) == 0 {
+		l.LogWithFields(ctx).Debugf("No reconciliation is registered for event with messageID %s originOfCondition %s", event.MessageID, event.OriginOfCondition.Oid)
+		return
+	}
+	r, err := GetPollingReconciler(ctx, client, Scheme)
+	if err != nil {
+		l.LogWithFields(ctx).Warnf("Event can not be processed: %s", err.Error())
+		return
+	}
+	if err = r.prepareForEvent(ctx); err != nil {
+		l.LogWithFields(ctx).Errorf("Failed to get rest client for BMC: %s", err.Error())
+		return
+	}
+	for _, route := range routes {
+		l.LogWithFields(ctx).Debugf("Routing event with messageID %s originOfCondition %s to %s reconciliation", event.MessageID, event.OriginOfCondition.Oid, route.Name)
+		route.Handler(ctx, r, event)
 	}
 }
 
-func (r *PollingReconciler) processEventsForSystemsResource(ctx context.Context, messageID string, originOfCondition string) {
+// prepareForEvent initializes the rest client and the utils used by the event handlers
+func (r *PollingReconciler) prepareForEvent(ctx context.Context) error {
 	client, err := restclient.NewRestClient(ctx, r.odimObj, r.commonRec.(*utils.CommonReconciler), constants.BMCOPERATOR)
 	if err != nil {
-		l.LogWithFields(ctx).Errorf("Failed to get rest client for BMC: %s", err.Error())
+		return err
 	}
 	r.pollRestClient = client
 	r.commonUtil = common.GetCommonUtils(client)
 	r.ctx = ctx
 	r.namespace = config.Data.Namespace
+	r.bmcUtil = bmc.GetBmcUtils(ctx, nil, r.namespace, &r.commonRec, &r.pollRestClient, r.commonUtil, true)
+	r.volUtil = volume.GetVolumeUtils(ctx, client, r.commonRec, &infraiov1.Volume{}, r.namespace)
+	return nil
+}
+
+func (r *PollingReconciler) processEventsForSystemsResource(ctx context.Context, messageID string, originOfCondition string) {
+	client := r.pollRestClient
 	l.LogWithFields(ctx).Debugf("Processing events for system resources with messageID %s originOfCondition %s", messageID, originOfCondition)
 	switch {
 	case strings.Contains(messageID, "ResourceAdded"):
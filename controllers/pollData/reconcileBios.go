@@ -16,12 +16,12 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"path"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
 	"github.com/ODIM-Project/BMCOperator/config/constants"
 	bios "github.com/ODIM-Project/BMCOperator/controllers/bios"
-	controllers "github.com/ODIM-Project/BMCOperator/controllers/bmc"
 	common "github.com/ODIM-Project/BMCOperator/controllers/common"
 	restclient "github.com/ODIM-Project/BMCOperator/controllers/restclient"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
@@ -64,38 +64,60 @@ func (r PollingReconciler) RevertBiosDetails(ctx context.Context, restClient res
 	}
 }
 
+// CheckAndRevertBios reverts the attributes of the BiosSetting spec whose actual value has drifted on the BMC,
+// the reverted attributes are recorded as pending for the reset of the system, which is done by the reset coordinator
+// according to the reset policy and the maintenance windows of the BMC
 func (r PollingReconciler) CheckAndRevertBios(ctx context.Context, bmc infraiov1.Bmc, restClient restclient.RestClientInterface) {
-	if !common.RestartRequired[bmc.Status.BmcSystemID] {
-		r.bmcObject = &bmc
-		biosObj := r.commonRec.GetBiosObject(ctx, constants.MetadataName, r.bmcObject.Name, r.odimObj.Namespace)
-		biosUtil := bios.GetBiosUtils(ctx, biosObj, r.commonRec, restClient, r.namespace)
-		if bmc.Status.BiosAttributeRegistry != "" {
-			mapOfattributes := biosUtil.GetBiosAttributes(r.bmcObject)
-			if mapOfattributes != nil {
-				isequal, mapOfBiosAttribute := utils.CompareMaps(biosObj.Status.BiosAttributes, mapOfattributes)
-				if !isequal {
-					biosObj.Spec.Bios = mapOfBiosAttribute
-					ok, body := biosUtil.ValidateBiosAttributes(bmc.Status.BiosAttributeRegistry)
-					if ok {
-						if isUpdated := common.BiosAttributeUpdation(ctx, body, bmc.Status.BmcSystemID, restClient); isUpdated {
-							common.RestartRequired[bmc.Status.BmcSystemID] = true
-							go func() {
-								bmc.Spec.BmcDetails.ResetType = "ForceRestart"
-								bmcUtil := controllers.GetBmcUtils(ctx, &bmc, r.namespace, &r.commonRec, &restClient, common.GetCommonUtils(restClient), false)
-								resetdone := bmcUtil.ResetSystem(true, true)
-								if resetdone {
-									attributes := biosUtil.GetBiosAttributes(r.bmcObject)
-									biosObj.Spec.Bios = map[string]string{}
-									biosObj.Status = infraiov1.BiosSettingStatus{
-										BiosAttributes: attributes,
-									}
-									r.Client.Status().Update(ctx, biosObj)
-								}
-							}()
-						}
-					}
-				}
-			}
+	// attributes are checked again once the changes pending for the reset are applied
+	if len(bmc.Status.PendingResets) != 0 {
+		return
+	}
+	r.bmcObject = &bmc
+	biosObj := r.commonRec.GetBiosObject(ctx, constants.MetadataName, r.bmcObject.Name, r.odimObj.Namespace)
+	if biosObj == nil || len(biosObj.Spec.Bios) == 0 {
+		return
+	}
+	// attributes applied from the BiosSetting are not in effect until the system is reset by the user
+	if bmc.Status.SystemReset == fmt.Sprintf("%s Bios", constants.PendingForResetEvent) {
+		return
+	}
+	biosUtil := bios.GetBiosUtils(ctx, biosObj, r.commonRec, restClient, r.namespace)
+	if bmc.Status.BiosAttributeRegistry == "" {
+		return
+	}
+	mapOfattributes := biosUtil.GetBiosAttributes(r.bmcObject)
+	if mapOfattributes == nil {
+		return
+	}
+	isequal, driftedAttributes := utils.CompareMaps(biosObj.Spec.Bios, mapOfattributes)
+	if isequal || len(driftedAttributes) == 0 {
+		return
+	}
+	for attr := range driftedAttributes {
+		l.LogWithFields(ctx).Infof("Bios attribute %s of %s BMC drifted from BiosSetting", attr, bmc.Spec.BmcDetails.Address)
+	}
+	driftObj := biosObj.DeepCopy()
+	driftObj.Spec.Bios = driftedAttributes
+	driftUtil := bios.GetBiosUtils(ctx, driftObj, r.commonRec, restClient, r.namespace)
+	validation, err := driftUtil.ValidateBiosAttributes(bmc.Status.BiosAttributeRegistry, mapOfattributes)
+	if err != nil {
+		return
+	}
+	if !validation.IsValid() {
+		// like the BiosSetting controller, no attribute is applied when any attribute is rejected
+		l.LogWithFields(ctx).Infof("Drifted bios attributes of %s BMC are not reverted, attributes are not valid: %s", bmc.Spec.BmcDetails.Address, validation.String())
+		return
+	}
+	if len(validation.Attributes) == 0 {
+		return
+	}
+	if isUpdated := common.BiosAttributeUpdation(ctx, validation.Attributes, bmc.Status.BmcSystemID, restClient); isUpdated {
+		err = utils.RecordPendingReset(ctx, r.commonRec.GetCommonReconcilerClient(), &bmc, constants.PendingResetBios, biosObj.ObjectMeta.Name,
+			bios.GetExpectedAttributes(validation, validation.Attributes), fmt.Sprintf("%s Bios", constants.PendingForResetEvent))
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Error: Recording pending revert of bios attributes of %s BMC: %s", bmc.Spec.BmcDetails.Address, err.Error())
+			return
 		}
+		l.LogWithFields(ctx).Infof("Drifted bios attributes of %s BMC are reverted, they are applied on the next reset of the system", bmc.Spec.BmcDetails.Address)
 	}
 }
@@ -18,8 +18,6 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"reflect"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -144,6 +142,11 @@ func (pr PollingReconciler) checkVolumeCreatedAndRevert(bmc *infraiov1.Bmc, volu
 // filteredOutVolumesAlreadyInProgress will filter out all volumes from deletedVolumesPerStorageController which is being created in ODIM, i.e operation is inprogress
 // volumes in filteredOutVolumesAlreadyInProgress will be created by operator in ODIM directly taking details from volume object already present
 func (pr PollingReconciler) checkVolumeDeletedAndRevert(bmc *infraiov1.Bmc, volumeObjectsForStorageControllerFromODIM, volumeObjectsForStorageControllerInOperator map[string][]string) {
+	// volumes created before the reset of the system are not visible in ODIM until the changes pending for the reset are applied
+	if len(bmc.Status.PendingResets) != 0 {
+		l.LogWithFields(pr.ctx).Info(fmt.Sprintf("Changes of %s BMC are pending for a reset, deleted volumes are checked after the reset", bmc.ObjectMeta.Name))
+		return
+	}
 	// do not change the sequence in which maps are sent to getVolumes
 	deletedVolumesPerStorageController := pr.getVolumes(volumeObjectsForStorageControllerInOperator, volumeObjectsForStorageControllerFromODIM)
 	l.LogWithFields(pr.ctx).Debug("Difference in volumes from ODIM & Operator:", deletedVolumesPerStorageController)
@@ -294,7 +297,9 @@ func (pr PollingReconciler) deleteVolumeObjects(bmc *infraiov1.Bmc, storageContr
 	}
 }
 
-// getVolumeObjectDetailsAndCreateVolumeInODIM will get deleted volume details from existing volume objects and create volumes
+// getVolumeObjectDetailsAndCreateVolumeInODIM will get deleted volume details from existing volume objects and create volumes,
+// the created volumes are recorded as pending for the reset of the system, which is done by the reset coordinator according to
+// the reset policy and the maintenance windows of the BMC, the volume objects are updated with the new volume ID after the reset
 func (pr PollingReconciler) getVolumeObjectDetailsAndCreateVolumeInODIM(bmcObj *infraiov1.Bmc, storageController string, volumeIds []string) {
 	for _, volId := range volumeIds {
 		existingVolObj := pr.commonRec.GetVolumeObjectByVolumeID(pr.ctx, volId, pr.namespace)
@@ -306,73 +311,12 @@ func (pr PollingReconciler) getVolumeObjectDetailsAndCreateVolumeInODIM(bmcObj *
 			l.LogWithFields(pr.ctx).Error(fmt.Sprintf("error while creating volume for %s:", existingVolObj.Name) + err.Error())
 			continue
 		}
-		//reset here
-		bmcObj.Spec.BmcDetails.ResetType = "ForceRestart"
-		pr.bmcUtil.UpdateBmcObject(bmcObj)
-		resetdone := pr.bmcUtil.ResetSystem(false, false) // setting updateBmcDependents = false, since we do not want those actions to take place, we just need to reset for our volume to be created again
-		if resetdone {
-			if isCreated {
-				newVolumeID, err := pr.getNewVolumeIDForObject(bmcObj, storageController, existingVolObj)
-				if err != nil || newVolumeID == "" {
-					l.LogWithFields(pr.ctx).Error(fmt.Sprintf("error while getting new volume ID for exsisting volume object %s:", existingVolObj.Name) + err.Error())
-					continue
-				}
-				l.LogWithFields(pr.ctx).Debug(fmt.Sprintf("New volume ID for exsisting object %s is : %s", existingVolObj.ObjectMeta.Name, newVolumeID))
-				existingVolObj.Status.VolumeID = newVolumeID
-				err = pr.commonRec.GetCommonReconcilerClient().Status().Update(pr.ctx, existingVolObj)
-				if err != nil {
-					l.LogWithFields(pr.ctx).Errorf("Error: Updating Status of %s Volume : %s", existingVolObj.Status.VolumeName, err.Error())
-				}
-				l.LogWithFields(pr.ctx).Info(fmt.Sprintf("Updated volume %s in ODIM successfully", existingVolObj.ObjectMeta.Name))
-			} else {
-				l.LogWithFields(pr.ctx).Info(fmt.Sprintf("Could not update volume %s in ODIM", existingVolObj.ObjectMeta.Name))
-			}
-		} else {
-			l.LogWithFields(pr.ctx).Info("Reset operation not successful, volume is not visible on ODIM, hence unable to update new volume ID")
-		}
-	}
-}
-
-// getNewVolumeIDForObject will get the new VolumeID for the volume object whose Volume was deleted from ODIM, and had to be added back as part of Revert use case
-func (pr PollingReconciler) getNewVolumeIDForObject(bmcObj *infraiov1.Bmc, storageController string, volObj *infraiov1.Volume) (string, error) {
-	getAllVolumesResp, sCode, err := pr.pollRestClient.Get(fmt.Sprintf("/redfish/v1/Systems/%s/Storage/%s/Volumes", bmcObj.Status.BmcSystemID, storageController), "Fetching all volumes..")
-	if err != nil {
-		l.LogWithFields(pr.ctx).Error("error while fetching all volumes:" + err.Error())
-		return "", err
-	} else if sCode != http.StatusOK {
-		l.LogWithFields(pr.ctx).Info("Failed to get all volumes, try again.")
-		return "", nil
-	}
-	volumesList := getAllVolumesResp["Members"].([]interface{})
-	for _, vol := range volumesList {
-		volURL := vol.(map[string]interface{})["@odata.id"].(string)
-		getEachVolResp, sCode, err := pr.pollRestClient.Get(volURL, fmt.Sprintf("Fetching %s volume details..", volURL[len(volURL)-1:]))
-		if err != nil {
-			l.LogWithFields(pr.ctx).Error(fmt.Sprintf("error while fetching %s volume details:", volURL[len(volURL)-1:]) + err.Error())
-			return "", err
-		} else if sCode != http.StatusOK {
-			l.LogWithFields(pr.ctx).Info(fmt.Sprintf("Failed to get %s volume details, try again.", volURL[len(volURL)-1:]))
-			return "", nil
-		} else {
-			//getting all drives in the volume
-			drives := []int{}
-			driveLinks := getEachVolResp["Links"].(map[string]interface{})["Drives"].([]interface{})
-			for _, dl := range driveLinks {
-				drive := dl.(map[string]interface{})["@odata.id"].(string)
-				driveID, err := strconv.Atoi(drive[len(drive)-1:])
-				if err != nil {
-					l.Log.Info("Could not convert drive id to Integer")
-				}
-				drives = append(drives, driveID)
-			}
-			sort.Ints(drives)
-			sort.Ints(volObj.Status.Drives)
-			if getEachVolResp["Name"].(string) == volObj.Status.VolumeName && getEachVolResp["RAIDType"] == volObj.Status.RAIDType && reflect.DeepEqual(drives, volObj.Status.Drives) {
-				return getEachVolResp["Id"].(string), nil
-			}
+		if !isCreated {
+			l.LogWithFields(pr.ctx).Info(fmt.Sprintf("Could not create volume %s in ODIM", existingVolObj.ObjectMeta.Name))
+			continue
 		}
+		l.LogWithFields(pr.ctx).Info(fmt.Sprintf("Volume %s is created in ODIM, it is visible after the next reset of the system", existingVolObj.ObjectMeta.Name))
 	}
-	return "", nil
 }
 
 // getVolumeDetailsAndDeleteVolumeInODIM will delete the volumes for each storage controller on ODIM directly
@@ -183,7 +183,7 @@ func (r PollingReconciler) bmcAdditionOperation(ctx context.Context, restClient
 	bmcUtil := controllers.GetBmcUtils(ctx, r.bmcObject, config.Data.Namespace, &r.commonRec, &restClient, common.GetCommonUtils(restClient), false)
 	connMeth := bmcUtil.GetConnectionMethod(r.odimObj)
 	if connMeth != "" {
-		decryptedPass := utils.DecryptWithPrivateKey(r.ctx, r.bmcObject.Spec.Credentials.Password, *utils.PrivateKey, true) //doBase64Decode = true, because bmc password is encrypted n encoded
+		decryptedPass := utils.DecryptWithPrivateKey(r.ctx, r.bmcObject.Spec.Credentials.Password, *utils.GetPrivateKey(), true) //doBase64Decode = true, because bmc password is encrypted n encoded
 		// Prepare Bmc Payload
 		body, err := r.prepareBmcPayload(ctx, connMeth, decryptedPass)
 		if err != nil {
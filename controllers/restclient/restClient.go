@@ -29,30 +29,40 @@ import (
 	"strings"
 
 	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	credentials "github.com/ODIM-Project/BMCOperator/controllers/credentials"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
 	l "github.com/ODIM-Project/BMCOperator/logs"
-	corev1 "k8s.io/api/core/v1"
 )
 
 var (
 	httpConn HTTPClientInterface
-	err      error
+	// streamConn is used for event streams only, a stream is read until it is closed so it has no timeout
+	// and does not share the connections of the rest calls
+	streamConn HTTPClientInterface
+	err        error
 )
 
 // -------------------------------NEW CLIENT CALLS---------------------------------
 // NewRestClient creates rest client for odim
 func NewRestClient(ctx context.Context, odimObj *infraiov1.Odim, commonRec *utils.CommonReconciler, rootdir string) (RestClientInterface, error) {
 	//getting secrets
-	secret := corev1.Secret{}
 	var secretName string
 	for field, val := range odimObj.Annotations {
 		if strings.Contains(field, "auth") {
 			secretName = val
 		}
 	}
-	username, password, authType := commonRec.GetObjectSecret(ctx, &secret, secretName, rootdir)
-	//get password in base64 form
-	pass := utils.DecryptWithPrivateKey(ctx, password, *utils.PrivateKey, false) //doBase64Decode = false, because odim password fetched from secret is already decoded
+	provider, err := credentials.GetCredentialProvider(commonRec)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Error getting the credential provider" + err.Error())
+		return nil, err
+	}
+	creds, err := provider.GetCredentials(ctx, secretName)
+	if err != nil {
+		l.LogWithFields(ctx).Error("Error fetching credentials of ODIM" + err.Error())
+		return nil, err
+	}
+	username, pass, authType := creds.Username, creds.Password, creds.AuthType
 	l.LogWithFields(ctx).Info("Fetching Odim address")
 	//get host,port
 	host, port, err := utils.GetHostPort(ctx, odimObj.Spec.URL)
@@ -60,7 +70,7 @@ func NewRestClient(ctx context.Context, odimObj *infraiov1.Odim, commonRec *util
 		l.LogWithFields(ctx).Error("Error fetching host/port of ODIM" + err.Error())
 		return nil, err
 	}
-	rootCA := utils.RootCA
+	rootCA := utils.GetRootCA()
 	//Create rc object
 	restClient, err := getNewRestClient(ctx, username, pass, authType, host, port, rootCA)
 	if err != nil {
@@ -197,6 +207,28 @@ func (rc *RestClient) Get(uri, reason string) (map[string]interface{}, int, erro
 	return data, resp.StatusCode, nil
 }
 
+// Stream sends a GET request for a Server-Sent Events stream, the caller reads and closes the body of the response,
+// the stream is closed when ctx is done
+func (rc *RestClient) Stream(ctx context.Context, uri, reason string) (*http.Response, error) {
+	url := fmt.Sprintf("https://%s:%s%s", rc.host, rc.port, uri)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error: Creating HTTP request GET on %s to query ODIM: %s", url, err.Error())
+	}
+	for header, val := range rc.reqHeaderAuth.getHeaderDetails() {
+		req.Header.Set(header, val)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if streamConn == nil {
+		streamConn = getConn(rc.rootCA)
+	}
+	res, err := streamConn.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Error: Sending HTTP request GET on %s to query ODIM: %s", url, err.Error())
+	}
+	return res, nil
+}
+
 // -----------------------------------REQUEST CALLS------------------------------------
 // sendRequest sends the request
 func (request *requestDetails) sendRequest(method, reason, uri string, body interface{}, rootCA []byte) (*http.Response, error) {
@@ -249,8 +281,13 @@ func getConn(rootCA []byte) HTTPClientInterface {
 	connection := &http.Client{
 		Transport: &http.Transport{
 			DialTLS: func(network, addr string) (net.Conn, error) {
+				// prefer the latest root CA, so that a rotated CA is used by long living rest clients
+				pool := utils.GetRootCAPool()
+				if pool == nil {
+					pool = capool
+				}
 				conn, err := tls.Dial(network, addr, &tls.Config{
-					RootCAs: capool,
+					RootCAs: pool,
 				})
 				return conn, err
 			},
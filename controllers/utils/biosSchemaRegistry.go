@@ -0,0 +1,110 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// registryDisplayProperties are the properties of registry attributes which only affect how they are displayed,
+// they are not compared between registries
+var registryDisplayProperties = map[string]bool{
+	"DisplayName":  true,
+	"DisplayOrder": true,
+	"HelpText":     true,
+	"MenuPath":     true,
+	"WarningText":  true,
+}
+
+// GetBiosRegistryChange returns the attributes added, removed or changed from the previous to the current registry
+func GetBiosRegistryChange(previous, current *infraiov1.BiosSchemaRegistry) *infraiov1.BiosRegistryChange {
+	change := &infraiov1.BiosRegistryChange{
+		PreviousRegistry: previous.Spec.ID,
+		CurrentRegistry:  current.Spec.ID,
+		ChangeTime:       metav1.Now(),
+	}
+	previousEntries := getRegistryEntries(&previous.Spec)
+	currentEntries := getRegistryEntries(&current.Spec)
+	for attr, entry := range currentEntries {
+		previousEntry, ok := previousEntries[attr]
+		if !ok {
+			change.AddedAttributes = append(change.AddedAttributes, attr)
+			continue
+		}
+		if changed := getChangedProperties(previousEntry, entry); changed != "" {
+			if change.ChangedAttributes == nil {
+				change.ChangedAttributes = map[string]string{}
+			}
+			change.ChangedAttributes[attr] = changed
+		}
+	}
+	for attr := range previousEntries {
+		if _, ok := currentEntries[attr]; !ok {
+			change.RemovedAttributes = append(change.RemovedAttributes, attr)
+		}
+	}
+	sort.Strings(change.AddedAttributes)
+	sort.Strings(change.RemovedAttributes)
+	return change
+}
+
+// getRegistryEntries returns the attributes of the registry by name
+func getRegistryEntries(registry *infraiov1.BiosSchemaRegistrySpec) map[string]map[string]string {
+	entries := map[string]map[string]string{}
+	for _, entry := range registry.Attributes {
+		entries[entry["AttributeName"]] = entry
+	}
+	return entries
+}
+
+// getChangedProperties describes the properties which differ between two entries of an attribute, sorted by property.
+// The values of enumerations are not described since they can be long
+func getChangedProperties(previous, current map[string]string) string {
+	properties := map[string]bool{}
+	for property := range previous {
+		properties[property] = true
+	}
+	for property := range current {
+		properties[property] = true
+	}
+	changes := []string{}
+	for property := range properties {
+		if registryDisplayProperties[property] || previous[property] == current[property] {
+			continue
+		}
+		if property == "Value" {
+			changes = append(changes, "Value changed")
+			continue
+		}
+		changes = append(changes, fmt.Sprintf("%s: %q -> %q", property, previous[property], current[property]))
+	}
+	sort.Strings(changes)
+	return strings.Join(changes, ", ")
+}
+
+// IsBiosSchemaRegistryOfBmc tells whether the registry object is the BIOS attribute registry of the bmc
+func IsBiosSchemaRegistryOfBmc(registryName string, bmcObj *infraiov1.Bmc) bool {
+	return bmcObj.Status.BiosAttributeRegistry != "" && RemoveSpecialChar(bmcObj.Status.BiosAttributeRegistry) == registryName
+}
+
+// IsBiosSchemaRegistryOfSnapshot tells whether the BIOS attributes of the snapshot were captured with the registry
+func IsBiosSchemaRegistryOfSnapshot(registryName string, snapshotObj *infraiov1.BiosSnapshot) bool {
+	return snapshotObj.Spec.Registry != "" && RemoveSpecialChar(snapshotObj.Spec.Registry) == registryName
+}
@@ -0,0 +1,96 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+)
+
+func TestGetBiosRegistryChange(t *testing.T) {
+	previous := &infraiov1.BiosSchemaRegistry{Spec: infraiov1.BiosSchemaRegistrySpec{
+		ID: "BiosAttributeRegistryU30.v1_2_00",
+		Attributes: []map[string]string{
+			{"AttributeName": "BootMode", "Type": "Enumeration", "Value": `[{"ValueName":"Uefi"},{"ValueName":"LegacyBios"}]`},
+			{"AttributeName": "ThermalShutdownTime", "Type": "Integer", "LowerBound": "0", "UpperBound": "100", "HelpText": "Time before shutdown"},
+			{"AttributeName": "ServerName", "Type": "String", "MaxLength": "10"},
+			{"AttributeName": "LegacyUsb", "Type": "Enumeration"},
+		},
+	}}
+	current := &infraiov1.BiosSchemaRegistry{Spec: infraiov1.BiosSchemaRegistrySpec{
+		ID: "BiosAttributeRegistryU30.v1_3_00",
+		Attributes: []map[string]string{
+			{"AttributeName": "BootMode", "Type": "Enumeration", "Value": `[{"ValueName":"Uefi"}]`},
+			{"AttributeName": "ThermalShutdownTime", "Type": "Integer", "LowerBound": "0", "UpperBound": "200", "HelpText": "Delay before shutdown"},
+			{"AttributeName": "ServerName", "Type": "String", "MaxLength": "10"},
+			{"AttributeName": "TpmChipId", "Type": "Enumeration"},
+			{"AttributeName": "SecureBoot", "Type": "Boolean"},
+		},
+	}}
+	got := GetBiosRegistryChange(previous, current)
+	if got.PreviousRegistry != previous.Spec.ID || got.CurrentRegistry != current.Spec.ID {
+		t.Errorf("GetBiosRegistryChange() registries = %s, %s", got.PreviousRegistry, got.CurrentRegistry)
+	}
+	if want := []string{"SecureBoot", "TpmChipId"}; !reflect.DeepEqual(got.AddedAttributes, want) {
+		t.Errorf("GetBiosRegistryChange() added = %v, want %v", got.AddedAttributes, want)
+	}
+	if want := []string{"LegacyUsb"}; !reflect.DeepEqual(got.RemovedAttributes, want) {
+		t.Errorf("GetBiosRegistryChange() removed = %v, want %v", got.RemovedAttributes, want)
+	}
+	wantChanged := map[string]string{
+		"BootMode":            "Value changed",
+		"ThermalShutdownTime": `UpperBound: "100" -> "200"`,
+	}
+	if !reflect.DeepEqual(got.ChangedAttributes, wantChanged) {
+		t.Errorf("GetBiosRegistryChange() changed = %v, want %v", got.ChangedAttributes, wantChanged)
+	}
+}
+
+func TestIsBiosSchemaRegistryOfBmc(t *testing.T) {
+	tests := []struct {
+		name     string
+		registry string
+		bmcObj   *infraiov1.Bmc
+		want     bool
+	}{
+		{
+			name:     "registry of the bmc",
+			registry: "biosattributeregistryu30v1200",
+			bmcObj:   &infraiov1.Bmc{Status: infraiov1.BmcStatus{BiosAttributeRegistry: "BiosAttributeRegistryU30.v1_2_00"}},
+			want:     true,
+		},
+		{
+			name:     "other registry",
+			registry: "biosattributeregistryu30v1300",
+			bmcObj:   &infraiov1.Bmc{Status: infraiov1.BmcStatus{BiosAttributeRegistry: "BiosAttributeRegistryU30.v1_2_00"}},
+			want:     false,
+		},
+		{
+			name:     "bmc without registry",
+			registry: "",
+			bmcObj:   &infraiov1.Bmc{},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsBiosSchemaRegistryOfBmc(tt.registry, tt.bmcObj); got != tt.want {
+				t.Errorf("IsBiosSchemaRegistryOfBmc() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
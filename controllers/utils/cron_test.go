@@ -0,0 +1,107 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    bool
+	}{
+		{name: "every minute", expression: "* * * * *"},
+		{name: "lists, ranges and steps", expression: "0,30 */2 1-15 1-12/3 1-5"},
+		{name: "sunday as 7", expression: "0 22 * * 7"},
+		{name: "missing field", expression: "0 22 * *", wantErr: true},
+		{name: "value out of range", expression: "60 22 * * *", wantErr: true},
+		{name: "invalid range", expression: "0 22 10-5 * *", wantErr: true},
+		{name: "invalid step", expression: "*/0 * * * *", wantErr: true},
+		{name: "not a number", expression: "0 night * * *", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCronSchedule(tt.expression)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCronSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2023-03-15 is a wednesday
+	from := time.Date(2023, 3, 15, 10, 20, 30, 0, time.UTC)
+	tests := []struct {
+		name       string
+		expression string
+		want       time.Time
+	}{
+		{
+			name:       "next minute",
+			expression: "* * * * *",
+			want:       time.Date(2023, 3, 15, 10, 21, 0, 0, time.UTC),
+		},
+		{
+			name:       "later the same day",
+			expression: "0 22 * * *",
+			want:       time.Date(2023, 3, 15, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "next saturday",
+			expression: "0 22 * * 6",
+			want:       time.Date(2023, 3, 18, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "sunday as 7",
+			expression: "0 2 * * 7",
+			want:       time.Date(2023, 3, 19, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "day of month or day of week",
+			expression: "0 0 1 * 5",
+			want:       time.Date(2023, 3, 17, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "next month",
+			expression: "30 1 1 * *",
+			want:       time.Date(2023, 4, 1, 1, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "next leap day",
+			expression: "0 0 29 2 *",
+			want:       time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "never matches",
+			expression: "0 0 31 2 *",
+			want:       time.Time{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseCronSchedule() error = %v", err)
+			}
+			if got := schedule.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
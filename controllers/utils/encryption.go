@@ -24,7 +24,10 @@ import (
 	l "github.com/ODIM-Project/BMCOperator/logs"
 )
 
-// Encryption with OAEP padding
+// minCipherTextSize is the size of a cipher text of the smallest supported RSA key of 1024 bits
+const minCipherTextSize = 128
+
+// Encryption with OAEP padding, cipher text is tagged with the ID of the key
 func EncryptWithPublicKey(ctx context.Context, secretMessage string,
 	key rsa.PublicKey) string {
 
@@ -37,29 +40,66 @@ func EncryptWithPublicKey(ctx context.Context, secretMessage string,
 		return ""
 	}
 
-	return base64.StdEncoding.EncodeToString(ciphertext)
+	return GetKeyID(&key) + keyIDSeparator + base64.StdEncoding.EncodeToString(ciphertext)
 }
 
-// Decryption
+// Decryption, the key the cipher text is tagged with is tried first, followed by privKey and
+// every other known key so that passwords encrypted with previous keys can still be decrypted
 func DecryptWithPrivateKey(ctx context.Context, cipherText string,
 	privKey rsa.PrivateKey, doBase64Decode bool) string {
 	ct := []byte(cipherText)
+	var keyID string
 	if doBase64Decode {
 		//Decode the Cipher text
 		var err error
-		ct, err = base64.StdEncoding.DecodeString(cipherText)
+		var encoded string
+		keyID, encoded = splitCipherText(cipherText)
+		ct, err = base64.StdEncoding.DecodeString(encoded)
 		if err != nil {
 			l.LogWithFields(ctx).Errorf("Unable to decode password: %s", err.Error())
 			return ""
 		}
 	}
 	rng := rand.Reader
-	secrettext, err := rsa.DecryptOAEP(sha512.New(),
-		rng, &privKey, ct, nil)
+	var err error
+	for _, key := range getDecryptionKeys(keyID, &privKey) {
+		var secrettext []byte
+		secrettext, err = rsa.DecryptOAEP(sha512.New(),
+			rng, key, ct, nil)
+		if err == nil {
+			return string(secrettext)
+		}
+	}
+	l.LogWithFields(ctx).Errorf("Unable to decrypt password: %s", err.Error())
+	return ""
+}
 
+// EncryptToBytes encrypts the message with the public key and returns the untagged cipher text,
+// used for the passwords stored in secrets which are decrypted with doBase64Decode = false
+func EncryptToBytes(ctx context.Context, secretMessage string, key rsa.PublicKey) []byte {
+	_, encoded := splitCipherText(EncryptWithPublicKey(ctx, secretMessage, key))
+	ciphertext, err := base64.StdEncoding.DecodeString(encoded)
 	if err != nil {
-		l.LogWithFields(ctx).Errorf("Unable to decrypt password: %s", err.Error())
-		return ""
+		return nil
+	}
+	return ciphertext
+}
+
+// IsEncrypted returns true if the password is a base64 encoded cipher text, tagged with a key ID or untagged,
+// plain text passwords set by the user or read from a credential provider return false
+func IsEncrypted(password string) bool {
+	_, encoded := splitCipherText(password)
+	cipherText, err := base64.StdEncoding.DecodeString(encoded)
+	return err == nil && len(cipherText) >= minCipherTextSize
+}
+
+// IsEncryptedWithActiveKey returns true if the raw cipher text stored in a secret can be decrypted with
+// the private key of the active key pair, raw cipher text is not tagged with a key ID
+func IsEncryptedWithActiveKey(cipherText []byte) bool {
+	key := GetPrivateKey()
+	if key == nil {
+		return false
 	}
-	return string(secrettext)
+	_, err := rsa.DecryptOAEP(sha512.New(), rand.Reader, key, cipherText, nil)
+	return err == nil
 }
@@ -0,0 +1,158 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// previousPrivateKeyPrefix is the prefix of secret keys holding the previous private keys,
+	// for example privateKey.2023-01
+	previousPrivateKeyPrefix = "privateKey."
+	// keyIDSeparator separates the key ID from the base64 encoded cipher text
+	keyIDSeparator = ":"
+)
+
+var (
+	// keyRing holds all the private keys known to the operator indexed by key ID
+	keyRing      = map[string]*rsa.PrivateKey{}
+	keyRingMutex = &sync.RWMutex{}
+	// privateKey and publicKey are the key pair used for encryption, they are replaced when the keys are reloaded
+	// and are read with GetPrivateKey and GetPublicKey
+	privateKey *rsa.PrivateKey
+	publicKey  *rsa.PublicKey
+)
+
+// GetKeyID returns the ID of the public key, the ID is derived from the SHA256 fingerprint of the key
+func GetKeyID(publicKey *rsa.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:4])
+}
+
+// SetEncryptionKeys sets the key pair used for encryption and the previous private keys used only for decryption
+func SetEncryptionKeys(private *rsa.PrivateKey, public *rsa.PublicKey, previousKeys []*rsa.PrivateKey) {
+	keyRingMutex.Lock()
+	defer keyRingMutex.Unlock()
+	keyRing = map[string]*rsa.PrivateKey{GetKeyID(public): private}
+	for _, key := range previousKeys {
+		keyRing[GetKeyID(&key.PublicKey)] = key
+	}
+	privateKey = private
+	publicKey = public
+}
+
+// GetPrivateKey returns the private key of the key pair used for encryption
+func GetPrivateKey() *rsa.PrivateKey {
+	keyRingMutex.RLock()
+	defer keyRingMutex.RUnlock()
+	return privateKey
+}
+
+// GetPublicKey returns the public key used for encryption
+func GetPublicKey() *rsa.PublicKey {
+	keyRingMutex.RLock()
+	defer keyRingMutex.RUnlock()
+	return publicKey
+}
+
+// GetActiveKeyID returns the ID of the key used for encryption
+func GetActiveKeyID() string {
+	keyRingMutex.RLock()
+	defer keyRingMutex.RUnlock()
+	if publicKey == nil {
+		return ""
+	}
+	return GetKeyID(publicKey)
+}
+
+// GetCipherTextKeyID returns the ID of the key used to encrypt the base64 encoded cipher text,
+// empty string is returned for cipher text encrypted before key IDs were introduced
+func GetCipherTextKeyID(cipherText string) string {
+	keyID, _ := splitCipherText(cipherText)
+	return keyID
+}
+
+// splitCipherText splits the tagged cipher text into key ID and base64 encoded cipher text
+func splitCipherText(cipherText string) (string, string) {
+	if idx := strings.Index(cipherText, keyIDSeparator); idx > 0 {
+		return cipherText[:idx], cipherText[idx+1:]
+	}
+	return "", cipherText
+}
+
+// getDecryptionKeys returns the key with given ID first followed by all the other known keys
+func getDecryptionKeys(keyID string, fallback *rsa.PrivateKey) []*rsa.PrivateKey {
+	keyRingMutex.RLock()
+	defer keyRingMutex.RUnlock()
+	keys := []*rsa.PrivateKey{}
+	if key, ok := keyRing[keyID]; ok {
+		keys = append(keys, key)
+	}
+	if fallback != nil && (len(keys) == 0 || !keys[0].Equal(fallback)) {
+		keys = append(keys, fallback)
+	}
+	ids := make([]string, 0, len(keyRing))
+	for id := range keyRing {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if id == keyID || (fallback != nil && keyRing[id].Equal(fallback)) {
+			continue
+		}
+		keys = append(keys, keyRing[id])
+	}
+	return keys
+}
+
+// ParseKeysFromSecret returns the key pair and the previous private keys stored in the keys secret
+func ParseKeysFromSecret(data map[string][]byte) (*rsa.PrivateKey, *rsa.PublicKey, []*rsa.PrivateKey, error) {
+	privateKey, err := ParseRsaPrivateKeyFromPemStr(string(data["privateKey"]))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing private key: %s", err.Error())
+	}
+	publicKey, err := ParseRsaPublicKeyFromPemStr(string(data["publicKey"]))
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("error parsing public key: %s", err.Error())
+	}
+	if !privateKey.PublicKey.Equal(publicKey) {
+		return nil, nil, nil, errors.New("public key does not belong to the private key")
+	}
+	previousKeys := []*rsa.PrivateKey{}
+	for field, val := range data {
+		if !strings.HasPrefix(field, previousPrivateKeyPrefix) {
+			continue
+		}
+		key, err := ParseRsaPrivateKeyFromPemStr(string(val))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error parsing %s key: %s", field, err.Error())
+		}
+		previousKeys = append(previousKeys, key)
+	}
+	return privateKey, publicKey, previousKeys, nil
+}
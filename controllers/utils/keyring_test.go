@@ -0,0 +1,112 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+)
+
+func TestDecryptWithPrivateKey_KeyRotation(t *testing.T) {
+	ctx := context.TODO()
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	unknownKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+	SetEncryptionKeys(oldKey, &oldKey.PublicKey, nil)
+	oldCipherText := EncryptWithPublicKey(ctx, "Passw0rd", *GetPublicKey())
+	oldRawCipherText := string(EncryptToBytes(ctx, "Passw0rd", *GetPublicKey()))
+	if GetCipherTextKeyID(oldCipherText) != GetKeyID(&oldKey.PublicKey) {
+		t.Fatalf("cipher text %s is not tagged with the key ID", oldCipherText)
+	}
+	// rotate to the new key keeping the old key for decryption
+	SetEncryptionKeys(newKey, &newKey.PublicKey, []*rsa.PrivateKey{oldKey})
+	newCipherText := EncryptWithPublicKey(ctx, "Passw0rd", *GetPublicKey())
+	if GetCipherTextKeyID(newCipherText) != GetActiveKeyID() {
+		t.Fatalf("cipher text %s is not tagged with the active key ID", newCipherText)
+	}
+	tests := []struct {
+		name           string
+		cipherText     string
+		doBase64Decode bool
+		want           string
+	}{
+		{name: "encrypted with active key", cipherText: newCipherText, doBase64Decode: true, want: "Passw0rd"},
+		{name: "encrypted with previous key", cipherText: oldCipherText, doBase64Decode: true, want: "Passw0rd"},
+		{name: "untagged cipher text", cipherText: oldCipherText[len(GetKeyID(&oldKey.PublicKey))+1:], doBase64Decode: true, want: "Passw0rd"},
+		{name: "raw cipher text from secret", cipherText: oldRawCipherText, doBase64Decode: false, want: "Passw0rd"},
+		{name: "encrypted with unknown key", cipherText: EncryptWithPublicKey(ctx, "Passw0rd", unknownKey.PublicKey), doBase64Decode: true, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecryptWithPrivateKey(ctx, tt.cipherText, *GetPrivateKey(), tt.doBase64Decode); got != tt.want {
+				t.Errorf("DecryptWithPrivateKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEncrypted(t *testing.T) {
+	ctx := context.TODO()
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	SetEncryptionKeys(key, &key.PublicKey, nil)
+	cipherText := EncryptWithPublicKey(ctx, "Passw0rd", *GetPublicKey())
+	tests := []struct {
+		name     string
+		password string
+		want     bool
+	}{
+		{name: "tagged cipher text", password: cipherText, want: true},
+		{name: "untagged cipher text", password: cipherText[len(GetKeyID(&key.PublicKey))+1:], want: true},
+		{name: "short plain text", password: "Passw0rd", want: false},
+		{name: "long plain text", password: "ThisIsALongPlainTextPassw0rd", want: false},
+		{name: "plain text with separator", password: "admin:Passw0rd", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEncrypted(tt.password); got != tt.want {
+				t.Errorf("IsEncrypted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsEncryptedWithActiveKey(t *testing.T) {
+	ctx := context.TODO()
+	oldKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	SetEncryptionKeys(oldKey, &oldKey.PublicKey, nil)
+	oldCipherText := EncryptToBytes(ctx, "Passw0rd", *GetPublicKey())
+	SetEncryptionKeys(newKey, &newKey.PublicKey, []*rsa.PrivateKey{oldKey})
+	newCipherText := EncryptToBytes(ctx, "Passw0rd", *GetPublicKey())
+	tests := []struct {
+		name       string
+		cipherText []byte
+		want       bool
+	}{
+		{name: "encrypted with active key", cipherText: newCipherText, want: true},
+		{name: "encrypted with previous key", cipherText: oldCipherText, want: false},
+		{name: "plain text", cipherText: []byte("Passw0rd"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsEncryptedWithActiveKey(tt.cipherText); got != tt.want {
+				t.Errorf("IsEncryptedWithActiveKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
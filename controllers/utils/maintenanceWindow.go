@@ -0,0 +1,127 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+	// time zone database for the time zones of the maintenance windows, the operator image has none
+	_ "time/tzdata"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	l "github.com/ODIM-Project/BMCOperator/logs"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetMaintenanceWindowState tells whether the maintenance window is open at now and returns the end of the window
+// when it is open, otherwise the start of the next window
+func GetMaintenanceWindowState(window *infraiov1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	schedule, err := ParseCronSchedule(window.Spec.Schedule)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	if window.Spec.Duration.Duration <= 0 {
+		return false, time.Time{}, fmt.Errorf("duration %s must be positive", window.Spec.Duration.Duration)
+	}
+	location := time.UTC
+	if window.Spec.TimeZone != "" {
+		location, err = time.LoadLocation(window.Spec.TimeZone)
+		if err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid time zone %s: %s", window.Spec.TimeZone, err.Error())
+		}
+	}
+	now = now.In(location)
+	// the first window starting after now minus the duration is either open or the next one
+	start := schedule.Next(now.Add(-window.Spec.Duration.Duration))
+	if start.IsZero() {
+		return false, time.Time{}, fmt.Errorf("schedule %s never matches", window.Spec.Schedule)
+	}
+	if !start.After(now) {
+		return true, start.Add(window.Spec.Duration.Duration), nil
+	}
+	return false, start, nil
+}
+
+// IsBmcSelectedByMaintenanceWindow tells whether the maintenance window applies to the bmc object
+func IsBmcSelectedByMaintenanceWindow(window *infraiov1.MaintenanceWindow, bmcObj *infraiov1.Bmc) (bool, error) {
+	if window.Spec.BmcSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(window.Spec.BmcSelector)
+	if err != nil {
+		return false, err
+	}
+	return selector.Matches(labels.Set(bmcObj.GetLabels())), nil
+}
+
+// IsBmcSelectedByAnyMaintenanceWindow tells whether at least one maintenance window applies to the bmc object
+func IsBmcSelectedByAnyMaintenanceWindow(ctx context.Context, c client.Client, bmcObj *infraiov1.Bmc) bool {
+	windowList := &infraiov1.MaintenanceWindowList{}
+	err := c.List(ctx, windowList, client.InNamespace(bmcObj.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error fetching maintenance windows: %s", err.Error())
+		return false
+	}
+	for i := range windowList.Items {
+		if selected, err := IsBmcSelectedByMaintenanceWindow(&windowList.Items[i], bmcObj); err == nil && selected {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMaintenanceWindowDeferral tells whether the disruptive operations on the bmc are deferred by the
+// maintenance windows selecting it and returns the time the next window opens. Operations are not deferred
+// when no window selects the bmc or when one of them is open
+func GetMaintenanceWindowDeferral(ctx context.Context, c client.Client, bmcObj *infraiov1.Bmc) (bool, time.Time) {
+	if bmcObj == nil {
+		return false, time.Time{}
+	}
+	windowList := &infraiov1.MaintenanceWindowList{}
+	err := c.List(ctx, windowList, client.InNamespace(bmcObj.Namespace))
+	if err != nil {
+		l.LogWithFields(ctx).Errorf("Error fetching maintenance windows: %s", err.Error())
+		return false, time.Time{}
+	}
+	var deferredUntil time.Time
+	now := time.Now()
+	for i := range windowList.Items {
+		window := &windowList.Items[i]
+		selected, err := IsBmcSelectedByMaintenanceWindow(window, bmcObj)
+		if err != nil || !selected {
+			continue
+		}
+		open, next, err := GetMaintenanceWindowState(window, now)
+		if err != nil {
+			l.LogWithFields(ctx).Errorf("Ignoring %s maintenance window: %s", window.Name, err.Error())
+			continue
+		}
+		if open {
+			return false, time.Time{}
+		}
+		if deferredUntil.IsZero() || next.Before(deferredUntil) {
+			deferredUntil = next
+		}
+	}
+	return !deferredUntil.IsZero(), deferredUntil
+}
+
+// GetDeferredMessage returns the status message of an operation deferred until the given time
+func GetDeferredMessage(deferredUntil time.Time) string {
+	return fmt.Sprintf("Deferred until %s", deferredUntil.UTC().Format(time.RFC3339))
+}
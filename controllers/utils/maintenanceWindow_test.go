@@ -0,0 +1,79 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetMaintenanceWindowState(t *testing.T) {
+	// 2023-03-18 is a saturday
+	now := time.Date(2023, 3, 18, 23, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		spec     infraiov1.MaintenanceWindowSpec
+		wantOpen bool
+		want     time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "open window",
+			spec:     infraiov1.MaintenanceWindowSpec{Schedule: "0 22 * * 6", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			wantOpen: true,
+			want:     time.Date(2023, 3, 19, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "closed window",
+			spec: infraiov1.MaintenanceWindowSpec{Schedule: "0 22 * * 6", Duration: metav1.Duration{Duration: 30 * time.Minute}},
+			want: time.Date(2023, 3, 25, 22, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "time zone",
+			spec: infraiov1.MaintenanceWindowSpec{Schedule: "0 22 * * 6", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Asia/Kolkata"},
+			want: time.Date(2023, 3, 25, 16, 30, 0, 0, time.UTC),
+		},
+		{
+			name:    "invalid schedule",
+			spec:    infraiov1.MaintenanceWindowSpec{Schedule: "0 22 * *", Duration: metav1.Duration{Duration: time.Hour}},
+			wantErr: true,
+		},
+		{
+			name:    "no duration",
+			spec:    infraiov1.MaintenanceWindowSpec{Schedule: "0 22 * * 6"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time zone",
+			spec:    infraiov1.MaintenanceWindowSpec{Schedule: "0 22 * * 6", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Mars/Olympus"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			window := &infraiov1.MaintenanceWindow{Spec: tt.spec}
+			gotOpen, got, err := GetMaintenanceWindowState(window, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetMaintenanceWindowState() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotOpen != tt.wantOpen || !got.Equal(tt.want) {
+				t.Errorf("GetMaintenanceWindowState() = %v, %v, want %v, %v", gotOpen, got, tt.wantOpen, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ParseMessageID splits a message ID into the registry prefix, the registry version and the message key,
+// for example ResourceEvent.1.2.0.ResourceRemoved is split into ResourceEvent, 1.2.0 and ResourceRemoved
+func ParseMessageID(messageID string) (string, string, string, error) {
+	first := strings.Index(messageID, ".")
+	last := strings.LastIndex(messageID, ".")
+	if first <= 0 || last <= first+1 || last == len(messageID)-1 {
+		return "", "", "", fmt.Errorf("invalid message ID %s, expected format is RegistryPrefix.Version.MessageKey", messageID)
+	}
+	return messageID[:first], messageID[first+1 : last], messageID[last+1:], nil
+}
+
+// GetEventMessageRegistryForMessage returns the EventsMessageRegistry object of the registry which defines the message ID,
+// nil is returned when no registry with the prefix and version of the message ID is present in the namespace
+func GetEventMessageRegistryForMessage(ctx context.Context, commonRec ReconcilerInterface, messageID, ns string) (*infraiov1.EventsMessageRegistry, error) {
+	registryPrefix, registryVersion, _, err := ParseMessageID(messageID)
+	if err != nil {
+		return nil, err
+	}
+	list := &infraiov1.EventsMessageRegistryList{}
+	err = commonRec.GetCommonReconcilerClient().List(ctx, list, client.InNamespace(ns))
+	if err != nil {
+		return nil, err
+	}
+	return findMessageRegistry(list.Items, registryPrefix, registryVersion), nil
+}
+
+// findMessageRegistry returns the registry with the prefix and version, message IDs carry only the major and minor
+// version of the registry, so a registry with the same major and minor version is returned when the version differs
+func findMessageRegistry(registries []infraiov1.EventsMessageRegistry, registryPrefix, registryVersion string) *infraiov1.EventsMessageRegistry {
+	var match *infraiov1.EventsMessageRegistry
+	for i := range registries {
+		registry := &registries[i]
+		if !strings.EqualFold(registry.Spec.RegistryPrefix, registryPrefix) {
+			continue
+		}
+		if registry.Spec.RegistryVersion == registryVersion {
+			return registry
+		}
+		if match == nil && getMajorMinorVersion(registry.Spec.RegistryVersion) == getMajorMinorVersion(registryVersion) {
+			match = registry
+		}
+	}
+	return match
+}
+
+func getMajorMinorVersion(version string) string {
+	parts := strings.Split(version, ".")
+	if len(parts) > 2 {
+		parts = parts[:2]
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,83 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+)
+
+func TestParseMessageID(t *testing.T) {
+	tests := []struct {
+		name        string
+		messageID   string
+		wantPrefix  string
+		wantVersion string
+		wantKey     string
+		wantErr     bool
+	}{
+		{name: "major and minor version", messageID: "iLOEvents.3.2.ServerPoweredOn", wantPrefix: "iLOEvents", wantVersion: "3.2", wantKey: "ServerPoweredOn"},
+		{name: "full version", messageID: "ResourceEvent.1.2.0.ResourceRemoved", wantPrefix: "ResourceEvent", wantVersion: "1.2.0", wantKey: "ResourceRemoved"},
+		{name: "vendor registry", messageID: "IDRAC.2.8.SYS1003", wantPrefix: "IDRAC", wantVersion: "2.8", wantKey: "SYS1003"},
+		{name: "missing version", messageID: "Base.Success", wantErr: true},
+		{name: "message key only", messageID: "ServerPoweredOn", wantErr: true},
+		{name: "missing message key", messageID: "Base.1.8.", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, version, key, err := ParseMessageID(tt.messageID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMessageID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if prefix != tt.wantPrefix || version != tt.wantVersion || key != tt.wantKey {
+				t.Errorf("ParseMessageID() = %s, %s, %s", prefix, version, key)
+			}
+		})
+	}
+}
+
+func Test_findMessageRegistry(t *testing.T) {
+	registry := func(prefix, version string) infraiov1.EventsMessageRegistry {
+		return infraiov1.EventsMessageRegistry{Spec: infraiov1.EventsMessageRegistrySpec{RegistryPrefix: prefix, RegistryVersion: version}}
+	}
+	registries := []infraiov1.EventsMessageRegistry{registry("Base", "1.8.1"), registry("iLOEvents", "3.2"), registry("IDRAC", "2.8"), registry("Base", "1.13.0")}
+	tests := []struct {
+		name        string
+		prefix      string
+		version     string
+		wantVersion string
+	}{
+		{name: "exact version", prefix: "iLOEvents", version: "3.2", wantVersion: "3.2"},
+		{name: "prefix is case insensitive", prefix: "iloevents", version: "3.2", wantVersion: "3.2"},
+		{name: "major and minor version", prefix: "Base", version: "1.13", wantVersion: "1.13.0"},
+		{name: "unknown version", prefix: "Base", version: "1.9", wantVersion: ""},
+		{name: "unknown prefix", prefix: "TaskEvent", version: "1.0", wantVersion: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := findMessageRegistry(registries, tt.prefix, tt.version)
+			if tt.wantVersion == "" {
+				if got != nil {
+					t.Errorf("findMessageRegistry() = %s, want nil", got.Spec.RegistryVersion)
+				}
+				return
+			}
+			if got == nil || got.Spec.RegistryVersion != tt.wantVersion {
+				t.Errorf("findMessageRegistry() = %v, want version %s", got, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -109,6 +109,12 @@ func IgnoreStatusUpdate() predicate.Predicate {
 	}
 }
 
+// IsDeletionTimestampChanged returns true when the object is marked for deletion or the deletion is cancelled,
+// deletion timestamps are pointers decoded separately for both objects so only their presence is compared
+func IsDeletionTimestampChanged(oldObj, newObj client.Object) bool {
+	return (oldObj.GetDeletionTimestamp() == nil) != (newObj.GetDeletionTimestamp() == nil)
+}
+
 // ----------------------------GET OBJECTS---------------------------------
 // GetBmcObject is used to get bmc object details based on given field and value
 func (r *CommonReconciler) GetBmcObject(ctx context.Context, field, value, ns string) *infraiov1.Bmc {
@@ -129,6 +135,35 @@ func (r *CommonReconciler) GetBmcObject(ctx context.Context, field, value, ns st
 	return &list.Items[0]
 }
 
+// GetBmcObjectForOrigin returns the bmc object of the resource which originated an event, resources other than
+// systems, for example managers, chassis and firmware inventory, are mapped to the system sharing the ODIM resource UUID
+func GetBmcObjectForOrigin(ctx context.Context, commonRec ReconcilerInterface, originOfCondition, ns string) *infraiov1.Bmc {
+	segments := strings.Split(strings.Trim(originOfCondition, "/"), "/")
+	if len(segments) < 4 {
+		return nil
+	}
+	if segments[2] == "Systems" {
+		return commonRec.GetBmcObject(ctx, constants.StatusBmcSystemID, segments[3], ns)
+	}
+	resourceID := segments[3]
+	if segments[2] == "UpdateService" {
+		resourceID = segments[len(segments)-1]
+	}
+	resourceUUID := strings.Split(resourceID, ".")[0]
+	bmcObjs := commonRec.GetAllBmcObject(ctx, ns)
+	if bmcObjs == nil {
+		return nil
+	}
+	for _, bmcObj := range *bmcObjs {
+		if strings.Split(bmcObj.Status.BmcSystemID, ".")[0] == resourceUUID {
+			bmcObj := bmcObj
+			return &bmcObj
+		}
+	}
+	l.LogWithFields(ctx).Info(fmt.Sprintf("Couldn't find any BMC object for %s", originOfCondition))
+	return nil
+}
+
 // GetAllBmcObject is used to get all bmc object details based on given namespace
 func (r *CommonReconciler) GetAllBmcObject(ctx context.Context, ns string) *[]infraiov1.Bmc {
 	list := &infraiov1.BmcList{}
@@ -511,8 +546,20 @@ func (r *CommonReconciler) CheckAndCreateBiosSchemaObject(ctx context.Context, a
 		supportedSystems = append(supportedSystems, supportedSystem)
 	}
 	biosSchema.Spec.SupportedSystems = supportedSystems
+	registryEntries, _ := attributeResp["RegistryEntries"].(map[string]interface{})
+	biosSchema.Spec.Dependencies = GetBiosAttributeDependencies(registryEntries["Dependencies"])
+	dependencies := biosSchema.Spec.Dependencies
 	key := client.ObjectKey{Namespace: bmcObj.Namespace, Name: biosID}
 	err := r.Client.Get(ctx, key, &biosSchema)
+	if err == nil && len(biosSchema.Spec.Dependencies) == 0 && len(dependencies) != 0 {
+		// objects created before the dependencies were stored are completed
+		biosSchema.Spec.Dependencies = dependencies
+		err = r.Client.Update(ctx, &biosSchema)
+		if err != nil {
+			l.LogWithFields(ctx).Error(fmt.Sprintf("Error while updating dependencies of BIOS-SCHEMA object %s: %s", biosID, err.Error()))
+		}
+		return true
+	}
 	if err != nil {
 		err := r.Client.Create(ctx, &biosSchema)
 		if err != nil {
@@ -524,6 +571,50 @@ func (r *CommonReconciler) CheckAndCreateBiosSchemaObject(ctx context.Context, a
 	return true
 }
 
+// GetBiosAttributeDependencies converts the Dependencies section of an attribute registry,
+// the values of the conditions are stored as strings like the properties of the attributes
+func GetBiosAttributeDependencies(dependencies interface{}) []infraiov1.BiosAttributeDependency {
+	entries, _ := dependencies.([]interface{})
+	result := []infraiov1.BiosAttributeDependency{}
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		dependency := infraiov1.BiosAttributeDependency{
+			DependencyFor: getStringValue(entryMap["DependencyFor"]),
+			Type:          getStringValue(entryMap["Type"]),
+		}
+		dependencyMap, _ := entryMap["Dependency"].(map[string]interface{})
+		dependency.Dependency.MapToAttribute = getStringValue(dependencyMap["MapToAttribute"])
+		dependency.Dependency.MapToProperty = getStringValue(dependencyMap["MapToProperty"])
+		dependency.Dependency.MapToValue = getStringValue(dependencyMap["MapToValue"])
+		mapFrom, _ := dependencyMap["MapFrom"].([]interface{})
+		for _, condition := range mapFrom {
+			conditionMap, ok := condition.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			dependency.Dependency.MapFrom = append(dependency.Dependency.MapFrom, infraiov1.BiosDependencyCondition{
+				MapFromAttribute: getStringValue(conditionMap["MapFromAttribute"]),
+				MapFromCondition: getStringValue(conditionMap["MapFromCondition"]),
+				MapFromProperty:  getStringValue(conditionMap["MapFromProperty"]),
+				MapFromValue:     getStringValue(conditionMap["MapFromValue"]),
+				MapTerms:         getStringValue(conditionMap["MapTerms"]),
+			})
+		}
+		result = append(result, dependency)
+	}
+	return result
+}
+
+func getStringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
 // CreateEventSubscriptionObject creates an eventsubscription object in system
 func (r *CommonReconciler) CreateEventSubscriptionObject(ctx context.Context, subscriptionDetails map[string]interface{}, ns string, originResources []string) bool {
 	eventsubscriptionObj := infraiov1.Eventsubscription{}
@@ -567,17 +658,32 @@ func (r *CommonReconciler) CreateEventSubscriptionObject(ctx context.Context, su
 // CheckAndCreateEventMessageObject checks if the message registry object already exist, if not then the object is created
 func (r *CommonReconciler) CheckAndCreateEventMessageObject(ctx context.Context, messageRegistryResp map[string]interface{}, bmcObj *infraiov1.Bmc) bool {
 	messages := map[string]infraiov1.EventMessage{}
-	registryID := RemoveSpecialChar(messageRegistryResp["Id"].(string))
-	messageEntries := messageRegistryResp["Messages"].(map[string]interface{})
+	registryIDValue, _ := messageRegistryResp["Id"].(string)
+	registryID := RemoveSpecialChar(registryIDValue)
+	messageEntries, _ := messageRegistryResp["Messages"].(map[string]interface{})
 	for key, value := range messageEntries {
-		messageDetails := value.(map[string]interface{})
+		messageDetails, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// standard registries and registries of other vendors may omit description and parameter types,
+		// and newer registries carry the severity in MessageSeverity
+		description, _ := messageDetails["Description"].(string)
+		message, _ := messageDetails["Message"].(string)
+		numberOfArgs, _ := messageDetails["NumberOfArgs"].(float64)
+		resolution, _ := messageDetails["Resolution"].(string)
+		severity, _ := messageDetails["Severity"].(string)
+		if messageSeverity, ok := messageDetails["MessageSeverity"].(string); ok && severity == "" {
+			severity = messageSeverity
+		}
+		paramTypes, _ := messageDetails["ParamTypes"].([]interface{})
 		eventMessage := infraiov1.EventMessage{
-			Description:  messageDetails["Description"].(string),
-			Message:      messageDetails["Message"].(string),
-			NumberOfArgs: fmt.Sprintf("%f", messageDetails["NumberOfArgs"].(float64)),
-			Resolution:   messageDetails["Resolution"].(string),
-			Severity:     messageDetails["Severity"].(string),
-			ParamTypes:   ConvertInterfaceToStringArray(messageDetails["ParamTypes"].([]interface{})),
+			Description:  description,
+			Message:      message,
+			NumberOfArgs: fmt.Sprintf("%f", numberOfArgs),
+			Resolution:   resolution,
+			Severity:     severity,
+			ParamTypes:   ConvertInterfaceToStringArray(paramTypes),
 			Oem:          map[string]infraiov1.Oem{},
 		}
 		var dataType, healthCatagory, eventType string
@@ -608,9 +714,9 @@ func (r *CommonReconciler) CheckAndCreateEventMessageObject(ctx context.Context,
 	messageRegistryObj := infraiov1.EventsMessageRegistry{}
 	messageRegistryObj.ObjectMeta.Name = objName
 	messageRegistryObj.ObjectMeta.Namespace = bmcObj.Namespace
-	messageRegistryObj.Spec.Name = messageRegistryResp["Name"].(string)
-	messageRegistryObj.Spec.ID = messageRegistryResp["Id"].(string)
-	messageRegistryObj.Spec.OwningEntity = messageRegistryResp["OwningEntity"].(string)
+	messageRegistryObj.Spec.Name, _ = messageRegistryResp["Name"].(string)
+	messageRegistryObj.Spec.ID = registryIDValue
+	messageRegistryObj.Spec.OwningEntity, _ = messageRegistryResp["OwningEntity"].(string)
 	messageRegistryObj.Spec.RegistryPrefix = messageRegistryResp["RegistryPrefix"].(string)
 	messageRegistryObj.Spec.RegistryVersion = messageRegistryResp["RegistryVersion"].(string)
 	messageRegistryObj.Spec.Messages = messages
@@ -631,10 +737,8 @@ func (r *CommonReconciler) CheckAndCreateEventMessageObject(ctx context.Context,
 // ----------------------------------------UPDATE OBJECT STATUS---------------------------------------
 // UpdateBiosSettingObject used to update the bios object
 func (r *CommonReconciler) UpdateBiosSettingObject(ctx context.Context, biosAttributes map[string]string, biosObj *infraiov1.BiosSetting) bool {
-	biosObj.Spec.Bios = map[string]string{}
-	biosObj.Status = infraiov1.BiosSettingStatus{
-		BiosAttributes: biosAttributes,
-	}
+	biosObj.Status.BiosAttributes = biosAttributes
+	biosObj.Status.AttributesInSync = GetAttributesInSync(biosObj.Spec.Bios, biosAttributes)
 	err := r.Client.Status().Update(ctx, biosObj)
 	if err != nil {
 		l.LogWithFields(ctx).Error(fmt.Sprintf("Error: Updating status with bios attributes for %s BMC: %s", biosObj.Name, err.Error()))
@@ -698,6 +802,11 @@ func (r *CommonReconciler) UpdateEventsubscriptionStatus(ctx context.Context, ev
 	if name, ok := eventsubscriptionDetails["Name"].(string); ok {
 		eventsubObj.Status.Name = name
 	}
+	// the subscription may have been replaced, so properties missing in the details are cleared
+	eventsubObj.Status.EventTypes = nil
+	eventsubObj.Status.MessageIds = nil
+	eventsubObj.Status.ResourceTypes = nil
+	eventsubObj.Status.OriginResources = nil
 	if eventTypes, ok := eventsubscriptionDetails["EventTypes"].([]interface{}); ok {
 		eventsubObj.Status.EventTypes = ConvertInterfaceToStringArray(eventTypes)
 	}
@@ -711,6 +820,13 @@ func (r *CommonReconciler) UpdateEventsubscriptionStatus(ctx context.Context, ev
 	if len(originResources) > 0 {
 		eventsubObj.Status.OriginResources = originResources
 	}
+	if status, ok := eventsubscriptionDetails["Status"].(map[string]interface{}); ok {
+		eventsubObj.Status.State, _ = status["State"].(string)
+		eventsubObj.Status.Health, _ = status["Health"].(string)
+	}
+	if policy, ok := eventsubscriptionDetails["DeliveryRetryPolicy"].(string); ok {
+		eventsubObj.Status.DeliveryRetryPolicy = policy
+	}
 
 	err := r.Client.Status().Update(ctx, eventsubObj)
 	if err != nil {
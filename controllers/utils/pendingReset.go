@@ -0,0 +1,75 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"context"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RecordPendingReset adds the change to the latest version of the bmc object and updates its status, the update
+// is retried on conflict so that the record is not lost. systemReset is set as reset status of the system when
+// not empty. bmcObj is refreshed with the updated object
+func RecordPendingReset(ctx context.Context, c client.Client, bmcObj *infraiov1.Bmc, kind, name string, values map[string]string, systemReset string) error {
+	key := types.NamespacedName{Name: bmcObj.Name, Namespace: bmcObj.Namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		latestBmcObj := &infraiov1.Bmc{}
+		err := c.Get(ctx, key, latestBmcObj)
+		if err != nil {
+			return err
+		}
+		AddPendingReset(latestBmcObj, kind, name, values)
+		if systemReset != "" {
+			latestBmcObj.Status.SystemReset = systemReset
+		}
+		err = c.Status().Update(ctx, latestBmcObj)
+		if err != nil {
+			return err
+		}
+		*bmcObj = *latestBmcObj
+		return nil
+	})
+}
+
+// AddPendingReset records on the bmc object a change which takes effect on the next reset of the system,
+// the values are merged into a change already pending for the same object. The status of the bmc object is not updated
+func AddPendingReset(bmcObj *infraiov1.Bmc, kind, name string, values map[string]string) {
+	pending := infraiov1.PendingReset{
+		Kind:          kind,
+		Name:          name,
+		Values:        values,
+		RequestedTime: metav1.Now(),
+	}
+	for i, existing := range bmcObj.Status.PendingResets {
+		if existing.Kind == kind && existing.Name == name {
+			for key, value := range existing.Values {
+				if _, ok := values[key]; !ok {
+					if pending.Values == nil {
+						pending.Values = map[string]string{}
+					}
+					pending.Values[key] = value
+				}
+			}
+			bmcObj.Status.PendingResets[i] = pending
+			return
+		}
+	}
+	bmcObj.Status.PendingResets = append(bmcObj.Status.PendingResets, pending)
+}
@@ -0,0 +1,115 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	// rootCAPool holds the parsed RootCA, used to verify ODIM and the event senders
+	rootCAPool *x509.CertPool
+	// eventListenerCert holds the certificate served by the event listener
+	eventListenerCert *tls.Certificate
+	tlsMutex          = &sync.RWMutex{}
+)
+
+// SetRootCA replaces the root CA, connections established after the call are verified with the new root CA
+func SetRootCA(rootCA []byte) error {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(rootCA) {
+		return errors.New("failed to load CA certificate")
+	}
+	tlsMutex.Lock()
+	defer tlsMutex.Unlock()
+	RootCA = rootCA
+	rootCAPool = pool
+	return nil
+}
+
+// GetRootCA returns the PEM encoded root CA currently in use
+func GetRootCA() []byte {
+	tlsMutex.RLock()
+	defer tlsMutex.RUnlock()
+	return RootCA
+}
+
+// GetRootCAPool returns the root CA currently in use as a cert pool, nil is returned if root CA is not loaded
+func GetRootCAPool() *x509.CertPool {
+	tlsMutex.RLock()
+	defer tlsMutex.RUnlock()
+	return rootCAPool
+}
+
+// SetEventListenerCertificate replaces the certificate served by the event listener, the new certificate
+// is served from the next TLS handshake
+func SetEventListenerCertificate(certPEM, keyPEM []byte) error {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair: %v", err)
+	}
+	tlsMutex.Lock()
+	defer tlsMutex.Unlock()
+	eventListenerCert = &cert
+	return nil
+}
+
+// GetEventListenerCertificate returns the certificate currently served by the event listener
+func GetEventListenerCertificate() (*tls.Certificate, error) {
+	tlsMutex.RLock()
+	defer tlsMutex.RUnlock()
+	if eventListenerCert == nil {
+		return nil, errors.New("event listener certificate is not loaded")
+	}
+	return eventListenerCert, nil
+}
+
+// GetTLSMaterial returns the root CA, the event listener certificate and its key stored in the keys secret,
+// the keys written by cert-manager (ca.crt, tls.crt and tls.key) are used when rootCACert, eventClientCert
+// and eventClientKey are not set
+func GetTLSMaterial(data map[string][]byte) (rootCA, cert, key []byte) {
+	rootCA = data["rootCACert"]
+	if len(rootCA) == 0 {
+		rootCA = data["ca.crt"]
+	}
+	cert, key = data["eventClientCert"], data["eventClientKey"]
+	if len(cert) == 0 && len(key) == 0 {
+		cert, key = data["tls.crt"], data["tls.key"]
+	}
+	return rootCA, cert, key
+}
+
+// LoadTLSMaterialFromSecret loads the root CA and the event listener certificate stored in the keys secret,
+// both are loaded even when one of them fails so that a broken certificate does not prevent loading the root CA,
+// the errors of both are returned
+func LoadTLSMaterialFromSecret(data map[string][]byte) error {
+	rootCA, cert, key := GetTLSMaterial(data)
+	var errs []string
+	if err := SetRootCA(rootCA); err != nil {
+		errs = append(errs, fmt.Sprintf("error loading rootCACert: %s", err.Error()))
+	}
+	if err := SetEventListenerCertificate(cert, key); err != nil {
+		errs = append(errs, fmt.Sprintf("error loading eventClientCert: %s", err.Error()))
+	}
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
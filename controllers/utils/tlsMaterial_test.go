@@ -0,0 +1,95 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// generateCertificate returns a PEM encoded self signed certificate and its key
+func generateCertificate(t *testing.T, commonName string) ([]byte, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer})
+}
+
+func TestLoadTLSMaterialFromSecret(t *testing.T) {
+	oldCert, oldKey := generateCertificate(t, "old")
+	newCert, newKey := generateCertificate(t, "new")
+	tests := []struct {
+		name     string
+		data     map[string][]byte
+		wantErr  bool
+		wantCN   string
+		wantRoot []byte
+	}{
+		{name: "initial load", data: map[string][]byte{"rootCACert": oldCert, "eventClientCert": oldCert, "eventClientKey": oldKey}, wantCN: "old", wantRoot: oldCert},
+		{name: "renewed certificates", data: map[string][]byte{"rootCACert": newCert, "eventClientCert": newCert, "eventClientKey": newKey}, wantCN: "new", wantRoot: newCert},
+		{name: "invalid root CA keeps previous root CA and loads certificate", data: map[string][]byte{"rootCACert": []byte("invalid"), "eventClientCert": oldCert, "eventClientKey": oldKey}, wantErr: true, wantCN: "old", wantRoot: newCert},
+		{name: "mismatched key keeps previous certificate and loads root CA", data: map[string][]byte{"rootCACert": oldCert, "eventClientCert": newCert, "eventClientKey": oldKey}, wantErr: true, wantCN: "old", wantRoot: oldCert},
+		{name: "cert-manager keys", data: map[string][]byte{"ca.crt": newCert, "tls.crt": newCert, "tls.key": newKey}, wantCN: "new", wantRoot: newCert},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := LoadTLSMaterialFromSecret(tt.data); (err != nil) != tt.wantErr {
+				t.Fatalf("LoadTLSMaterialFromSecret() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			cert, err := GetEventListenerCertificate()
+			if err != nil {
+				t.Fatal(err)
+			}
+			leaf, err := x509.ParseCertificate(cert.Certificate[0])
+			if err != nil {
+				t.Fatal(err)
+			}
+			if leaf.Subject.CommonName != tt.wantCN {
+				t.Errorf("GetEventListenerCertificate() CN = %s, want %s", leaf.Subject.CommonName, tt.wantCN)
+			}
+			if string(GetRootCA()) != string(tt.wantRoot) {
+				t.Errorf("GetRootCA() is not the expected root CA")
+			}
+			if _, err := leaf.Verify(x509.VerifyOptions{Roots: GetRootCAPool()}); !tt.wantErr && err != nil {
+				t.Errorf("served certificate is not verified with the root CA pool: %v", err)
+			}
+		})
+	}
+}
@@ -15,7 +15,6 @@
 package controllers
 
 import (
-	"crypto/rsa"
 	"reflect"
 	"regexp"
 	"sort"
@@ -25,10 +24,6 @@ import (
 )
 
 var (
-	// PrivateKey holds the value for PrivateKey
-	PrivateKey *rsa.PrivateKey
-	// PublicKey holds the value for PublicKey
-	PublicKey *rsa.PublicKey
 	// RootCA contains the RootCA value
 	RootCA []byte
 )
@@ -90,6 +85,19 @@ func CaseInsensitiveContains(s string) (string, bool) {
 	return "", false
 }
 
+// GetAttributesInSync returns for every desired attribute whether its actual value is the desired one
+func GetAttributesInSync(desired, actual map[string]string) map[string]bool {
+	if len(desired) == 0 {
+		return nil
+	}
+	inSync := make(map[string]bool, len(desired))
+	for k, v := range desired {
+		actualValue, ok := actual[k]
+		inSync[k] = ok && actualValue == v
+	}
+	return inSync
+}
+
 // CompareMaps function compares values of two maps and return true if equal else returns false
 func CompareMaps(m1, m2 map[string]string) (bool, map[string]string) {
 	distinctMap := make(map[string]string)
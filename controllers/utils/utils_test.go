@@ -0,0 +1,73 @@
+//(C) Copyright [2023] Hewlett Packard Enterprise Development LP
+//
+//Licensed under the Apache License, Version 2.0 (the "License"); you may
+//not use this file except in compliance with the License. You may obtain
+//a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+//Unless required by applicable law or agreed to in writing, software
+//distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+//WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+//License for the specific language governing permissions and limitations
+// under the License.
+
+package controllers
+
+import (
+	"reflect"
+	"testing"
+
+	infraiov1 "github.com/ODIM-Project/BMCOperator/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetAttributesInSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired map[string]string
+		actual  map[string]string
+		want    map[string]bool
+	}{
+		{
+			name:   "no desired attributes",
+			actual: map[string]string{"BootMode": "Uefi"},
+			want:   nil,
+		},
+		{
+			name:    "attributes in sync, drifted and missing",
+			desired: map[string]string{"BootMode": "Uefi", "ProcTurbo": "Enabled", "WorkloadProfile": "Virtualization-MaxPerformance"},
+			actual:  map[string]string{"BootMode": "Uefi", "ProcTurbo": "Disabled"},
+			want:    map[string]bool{"BootMode": true, "ProcTurbo": false, "WorkloadProfile": false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetAttributesInSync(tt.desired, tt.actual); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAttributesInSync() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsDeletionTimestampChanged(t *testing.T) {
+	deletionTime := metav1.Now()
+	sameDeletionTime := deletionTime
+	tests := []struct {
+		name   string
+		oldObj *infraiov1.Bmc
+		newObj *infraiov1.Bmc
+		want   bool
+	}{
+		{name: "not deleted", oldObj: &infraiov1.Bmc{}, newObj: &infraiov1.Bmc{}, want: false},
+		{name: "marked for deletion", oldObj: &infraiov1.Bmc{}, newObj: &infraiov1.Bmc{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deletionTime}}, want: true},
+		{name: "same deletion timestamp decoded twice", oldObj: &infraiov1.Bmc{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &deletionTime}}, newObj: &infraiov1.Bmc{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &sameDeletionTime}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsDeletionTimestampChanged(tt.oldObj, tt.newObj); got != tt.want {
+				t.Errorf("IsDeletionTimestampChanged() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -106,6 +106,9 @@ func (r *VolumeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	isVolumeMarkedToBeDeleted := volObj.GetDeletionTimestamp() != nil
 	if isVolumeMarkedToBeDeleted {
 		if controllerutil.ContainsFinalizer(volObj, VolFinalizer) {
+			if deferred, result, err := r.deferVolumeOperation(ctx, volObj, bmcObj); deferred {
+				return result, err
+			}
 			isVolumeDeleted := volUtil.DeleteVolume(bmcObj)
 			if isVolumeDeleted {
 				controllerutil.RemoveFinalizer(volObj, VolFinalizer)
@@ -125,6 +128,9 @@ func (r *VolumeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, nil
 	}
 	if volObj.Spec.StorageControllerID != "" && volObj.Spec.RAIDType != "" && len(volObj.Spec.Drives) != 0 {
+		if deferred, result, err := r.deferVolumeOperation(ctx, volObj, bmcObj); deferred {
+			return result, err
+		}
 		// Add finalizer for this CR
 		if !controllerutil.ContainsFinalizer(volObj, VolFinalizer) {
 			controllerutil.AddFinalizer(volObj, VolFinalizer)
@@ -146,6 +152,29 @@ func (r *VolumeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	return ctrl.Result{}, nil
 }
 
+// deferVolumeOperation tells whether creating or deleting the volume is deferred by a maintenance window
+// and records until when in the volume status
+func (r *VolumeReconciler) deferVolumeOperation(ctx context.Context, volObj *infraiov1.Volume, bmcObj *infraiov1.Bmc) (bool, ctrl.Result, error) {
+	deferred, deferredUntil := utils.GetMaintenanceWindowDeferral(ctx, r.Client, bmcObj)
+	message := ""
+	if deferred {
+		message = utils.GetDeferredMessage(deferredUntil)
+	}
+	if volObj.Status.Deferred != message {
+		volObj.Status.Deferred = message
+		err := r.Status().Update(ctx, volObj)
+		if err != nil {
+			l.LogWithFields(ctx).Error(fmt.Sprintf("Error: Updating status of %s Volume object: %s", volObj.Name, err.Error()))
+			return true, ctrl.Result{}, err
+		}
+	}
+	if !deferred {
+		return false, ctrl.Result{}, nil
+	}
+	l.LogWithFields(ctx).Info(fmt.Sprintf("Operation on %s Volume is deferred until %s", volObj.Name, deferredUntil.Format(time.RFC3339)))
+	return true, ctrl.Result{RequeueAfter: time.Until(deferredUntil)}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *VolumeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -180,7 +209,10 @@ func (vu *volumeUtils) CreateVolume(bmcObj *infraiov1.Bmc, dispName string, name
 		// BmcObj name is sent as ""
 		done, _ := vu.commonUtil.MoniteringTaskmon(resp.Header, vu.ctx, common.CREATEVOLUME, vu.volObj.ObjectMeta.Name)
 		if done {
-			commonRec.UpdateBmcObjectOnReset(vu.ctx, bmcObj, fmt.Sprintf("%s %s Volume", constants.PendingForResetEvent, dispName))
+			err = utils.RecordPendingReset(vu.ctx, vu.commonRec.GetCommonReconcilerClient(), bmcObj, constants.PendingResetVolume, vu.volObj.ObjectMeta.Name, nil, fmt.Sprintf("%s %s Volume", constants.PendingForResetEvent, dispName))
+			if err != nil {
+				l.LogWithFields(vu.ctx).Errorf("Error: Recording pending volume %s of %s BMC: %s", vu.volObj.ObjectMeta.Name, bmcObj.Spec.BmcDetails.Address, err.Error())
+			}
 			return true, nil
 		}
 	}
@@ -201,7 +233,11 @@ func (vu *volumeUtils) CreateVolume(bmcObj *infraiov1.Bmc, dispName string, name
 
 // updateVolumeStatusAndClearSpec will clear the spec and upidate the status fields of volume object
 func (vu *volumeUtils) UpdateVolumeStatusAndClearSpec(bmcObj *infraiov1.Bmc, dispName string) (bool, string) {
-	getAllVolumesResp, sCode, err := vu.volumeRestClient.Get(fmt.Sprintf("/redfish/v1/Systems/%s/Storage/%s/Volumes", bmcObj.Status.BmcSystemID, vu.volObj.Spec.StorageControllerID), "Fetching all volumes..")
+	storageControllerID, raidType, volumeDrives := vu.volObj.Spec.StorageControllerID, vu.volObj.Spec.RAIDType, vu.volObj.Spec.Drives
+	if storageControllerID == "" { // check added for reconcile volumes case, where the details of the volume are in status
+		storageControllerID, raidType, volumeDrives = vu.volObj.Status.StorageControllerID, vu.volObj.Status.RAIDType, vu.volObj.Status.Drives
+	}
+	getAllVolumesResp, sCode, err := vu.volumeRestClient.Get(fmt.Sprintf("/redfish/v1/Systems/%s/Storage/%s/Volumes", bmcObj.Status.BmcSystemID, storageControllerID), "Fetching all volumes..")
 	volumeNotUpdatedMsg := "Could not update volume status, but volume is created"
 	if err != nil {
 		l.LogWithFields(vu.ctx).Error("error while fetching all volumes:" + err.Error())
@@ -234,8 +270,8 @@ func (vu *volumeUtils) UpdateVolumeStatusAndClearSpec(bmcObj *infraiov1.Bmc, dis
 				drives = append(drives, driveID)
 			}
 			sort.Ints(drives)
-			sort.Ints(vu.volObj.Spec.Drives)
-			if getEachVolResp["Name"].(string) == dispName && getEachVolResp["RAIDType"] == vu.volObj.Spec.RAIDType && reflect.DeepEqual(drives, vu.volObj.Spec.Drives) {
+			sort.Ints(volumeDrives)
+			if getEachVolResp["Name"].(string) == dispName && getEachVolResp["RAIDType"] == raidType && reflect.DeepEqual(drives, volumeDrives) {
 				vu.volObj.ObjectMeta.Annotations["odata.id"] = getEachVolResp["@odata.id"].(string)
 				err = vu.commonRec.GetCommonReconcilerClient().Update(vu.ctx, vu.volObj)
 				if err != nil {
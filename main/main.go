@@ -27,10 +27,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
@@ -41,9 +43,12 @@ import (
 	bmc "github.com/ODIM-Project/BMCOperator/controllers/bmc"
 	boot "github.com/ODIM-Project/BMCOperator/controllers/boot"
 	configuration "github.com/ODIM-Project/BMCOperator/controllers/config"
+	encryption "github.com/ODIM-Project/BMCOperator/controllers/encryption"
+	eventsClient "github.com/ODIM-Project/BMCOperator/controllers/eventsClient"
 	eventsubscription "github.com/ODIM-Project/BMCOperator/controllers/eventsubscription"
 	firmware "github.com/ODIM-Project/BMCOperator/controllers/firmware"
 	odim "github.com/ODIM-Project/BMCOperator/controllers/odim"
+	passwordrotation "github.com/ODIM-Project/BMCOperator/controllers/passwordrotation"
 	pollData "github.com/ODIM-Project/BMCOperator/controllers/pollData"
 	utils "github.com/ODIM-Project/BMCOperator/controllers/utils"
 	volume "github.com/ODIM-Project/BMCOperator/controllers/volume"
@@ -100,6 +105,12 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "a247721e.odimra",
+		// operator reads only the secrets of its own namespace, secrets of other namespaces are not cached
+		NewCache: cache.BuilderWithOptions(cache.Options{
+			SelectorsByObject: cache.SelectorsByObject{
+				&corev1.Secret{}: {Field: fields.OneTermEqualSelector("metadata.namespace", configuration.Data.Namespace)},
+			},
+		}),
 	})
 
 	cfg, _ := config.GetConfig()
@@ -121,12 +132,42 @@ func main() {
 	}).SetupWithManager(mgr); err != nil {
 		logs.Log.Fatal("unable to create controller" + err.Error())
 	}
+	if err = (&bmc.ResetCoordinatorReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		logs.Log.Fatal("unable to create controller" + err.Error())
+	}
+	if err = (&bmc.MaintenanceWindowReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		logs.Log.Fatal("unable to create controller" + err.Error())
+	}
 	if err = (&bios.BiosSettingReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 	}).SetupWithManager(mgr); err != nil {
 		logs.Log.Fatal("unable to create controller" + err.Error())
 	}
+	if err = (&bios.BiosSchemaRegistryReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		logs.Log.Fatal("unable to create controller" + err.Error())
+	}
+	if err = (&bios.BiosProfileReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		logs.Log.Fatal("unable to create controller" + err.Error())
+	}
+	if err = (&bios.BiosSnapshotReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		logs.Log.Fatal("unable to create controller" + err.Error())
+	}
 	if err = (&boot.BootOrderSettingsReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
@@ -151,6 +192,18 @@ func main() {
 	}).SetupWithManager(mgr); err != nil {
 		log.Fatal("unable to create controller" + err.Error())
 	}
+	if err = (&passwordrotation.PasswordRotationPolicyReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Fatal("unable to create controller" + err.Error())
+	}
+	if err = (&encryption.EncryptionKeysReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Fatal("unable to create controller" + err.Error())
+	}
 	//+kubebuilder:scaffold:builder
 
 	addIndex(mgr)
@@ -161,10 +214,24 @@ func main() {
 	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
 		logs.Log.Fatal("unable to set up ready check" + err.Error())
 	}
-	errChan := make(chan error)
-	go configuration.TrackConfigListener(errChan)
+	// background loops are started by the manager, polling and the event listener run only on the elected leader
+	if err := mgr.Add(&configuration.ConfigListener{}); err != nil {
+		logs.Log.Fatal("unable to add config listener" + err.Error())
+	}
+	if err := mgr.Add(&encryption.KeysLoader{Cache: mgr.GetCache()}); err != nil {
+		logs.Log.Fatal("unable to add keys loader" + err.Error())
+	}
+	if err := mgr.Add(&pollData.Poller{Manager: mgr}); err != nil {
+		logs.Log.Fatal("unable to add poller" + err.Error())
+	}
+	if err := mgr.Add(&eventsClient.EventsClientReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		APIReader: mgr.GetAPIReader(),
+	}); err != nil {
+		logs.Log.Fatal("unable to add event listener" + err.Error())
+	}
 	logs.Log.Info("starting manager")
-	go pollData.PollDetails(mgr)
 
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
 		logs.Log.Fatal("problem running manager" + err.Error())
@@ -173,14 +240,17 @@ func main() {
 
 func updateKeys(secretName, namespace string, client client.Client) error {
 	keysSecret := &corev1.Secret{}
-	encryptedPublicKey, encryptedPrivateKey := bmc.GetEncryptedPemKeysFromSecret(context.TODO(), keysSecret, secretName, namespace, client)
-	privateKey, publicKey := bmc.GetPemKeys(context.TODO(), encryptedPublicKey, encryptedPrivateKey)
-	if privateKey == nil || publicKey == nil {
-		return errors.New("could not find public/private keys")
-	}
-	utils.PrivateKey = privateKey
-	utils.PublicKey = publicKey
-	utils.RootCA = keysSecret.Data["rootCACert"]
+	bmc.GetEncryptedPemKeysFromSecret(context.TODO(), keysSecret, secretName, namespace, client)
+	privateKey, publicKey, previousKeys, err := utils.ParseKeysFromSecret(keysSecret.Data)
+	if err != nil {
+		return errors.New("could not find public/private keys: " + err.Error())
+	}
+	utils.SetEncryptionKeys(privateKey, publicKey, previousKeys)
+	if err = utils.LoadTLSMaterialFromSecret(keysSecret.Data); err != nil {
+		// event listener loads its certificate again when started, rest clients fall back to the configured root CA
+		logs.Log.Error("Error loading TLS material: " + err.Error())
+		utils.RootCA, _, _ = utils.GetTLSMaterial(keysSecret.Data)
+	}
 	return nil
 }
 
@@ -195,6 +265,13 @@ func addIndex(mgr manager.Manager) {
 	if err := cache.IndexField(context.Background(), &infraiov1.Bmc{}, "spec.bmc.address", bmcIpFunc); err != nil {
 		panic(err)
 	}
+	credentialRefFunc := func(obj client.Object) []string {
+		return []string{obj.(*infraiov1.Bmc).Spec.Credentials.CredentialRef}
+	}
+
+	if err := cache.IndexField(context.Background(), &infraiov1.Bmc{}, "spec.credentials.credentialRef", credentialRefFunc); err != nil {
+		panic(err)
+	}
 	serialNoFunc := func(obj client.Object) []string {
 		return []string{obj.(*infraiov1.Bmc).Status.SerialNumber}
 	}